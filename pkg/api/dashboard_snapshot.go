@@ -8,6 +8,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/api/response"
+	common "github.com/grafana/grafana/pkg/apimachinery/apis/common/v0alpha1"
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	dashboardsnapshot "github.com/grafana/grafana/pkg/apis/dashboardsnapshot/v0alpha1"
 	"github.com/grafana/grafana/pkg/infra/metrics"
@@ -92,6 +93,81 @@ func (hs *HTTPServer) CreateDashboardSnapshot(c *contextmodel.ReqContext) {
 	}, cmd, hs.dashboardsnapshotsService)
 }
 
+// swagger:route POST /dashboards/uid/{uid}/snapshot snapshots createServerSideDashboardSnapshot
+//
+// Create a snapshot of a dashboard by executing the queries of all panels on the server.
+//
+// The time range and template variable values default to the ones saved with the dashboard.
+//
+// Responses:
+// 200: createDashboardSnapshotResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) CreateServerSideDashboardSnapshot(c *contextmodel.ReqContext) {
+	if !hs.Cfg.SnapshotEnabled {
+		c.JsonApiErr(http.StatusForbidden, "Dashboard Snapshots are disabled", nil)
+		return
+	}
+
+	cmd := dashboardsnapshots.CreateServerSideSnapshotCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		c.JsonApiErr(http.StatusBadRequest, "bad request data", err)
+		return
+	}
+
+	uid := web.Params(c.Req)[":uid"]
+	evaluator := ac.EvalPermission(dashboards.ActionDashboardsWrite, dashboards.ScopeDashboardsProvider.GetResourceScopeUID(uid))
+	if canSave, err := hs.AccessControl.Evaluate(c.Req.Context(), c.SignedInUser, evaluator); err != nil || !canSave {
+		c.JsonApiErr(http.StatusForbidden, "forbidden", err)
+		return
+	}
+
+	dash, rsp := hs.getDashboardHelper(c.Req.Context(), c.SignedInUser.GetOrgID(), 0, uid)
+	if rsp != nil {
+		rsp.WriteTo(c)
+		return
+	}
+
+	snapshotter := dashboardsnapshots.NewServerSideSnapshotter(hs.queryDataService, hs.DataSourcesService, hs.LibraryElementService)
+	snapshot, err := snapshotter.Snapshot(c.Req.Context(), c.SignedInUser, dash.Data, &cmd)
+	if err != nil {
+		response.ErrOrFallback(http.StatusInternalServerError, "Failed to query dashboard data", err).WriteTo(c)
+		return
+	}
+
+	data, err := snapshot.Encode()
+	if err != nil {
+		c.JsonApiErr(http.StatusInternalServerError, "Failed to encode snapshot", err)
+		return
+	}
+	dashboard := &common.Unstructured{}
+	if err := dashboard.UnmarshalJSON(data); err != nil {
+		c.JsonApiErr(http.StatusInternalServerError, "Failed to encode snapshot", err)
+		return
+	}
+
+	name := cmd.Name
+	if name == "" {
+		name = dash.Title
+	}
+	dashboardsnapshots.CreateDashboardSnapshot(c, dashboardsnapshot.SnapshotSharingOptions{
+		SnapshotsEnabled:     hs.Cfg.SnapshotEnabled,
+		ExternalEnabled:      hs.Cfg.ExternalEnabled,
+		ExternalSnapshotName: hs.Cfg.ExternalSnapshotName,
+		ExternalSnapshotURL:  hs.Cfg.ExternalSnapshotUrl,
+	}, dashboardsnapshots.CreateDashboardSnapshotCommand{
+		DashboardCreateCommand: dashboardsnapshot.DashboardCreateCommand{
+			Name:      name,
+			Dashboard: dashboard,
+			Expires:   cmd.Expires,
+			External:  cmd.External,
+		},
+	}, hs.dashboardsnapshotsService)
+}
+
 // GET /api/snapshots/:key
 // swagger:route GET /snapshots/{key} snapshots getDashboardSnapshot
 //
@@ -314,6 +390,16 @@ type CreateSnapshotParams struct {
 	Body dashboardsnapshots.CreateDashboardSnapshotCommand `json:"body"`
 }
 
+// swagger:parameters createServerSideDashboardSnapshot
+type CreateServerSideSnapshotParams struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+	// in:body
+	// required:true
+	Body dashboardsnapshots.CreateServerSideSnapshotCommand `json:"body"`
+}
+
 // swagger:parameters searchDashboardSnapshots
 type GetSnapshotsParams struct {
 	// Search Query
@@ -167,6 +167,55 @@ var adminCommands = []*cli.Command{
 			},
 		},
 	},
+	{
+		Name:  "unified-storage",
+		Usage: "Exports and imports the resources saved in unified storage",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "export",
+				Usage:  "writes the resources of a namespace to a gzipped tar archive",
+				Action: runRunnerCommand(exportResourcesCommand),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "file",
+						Usage: "The archive to write",
+					},
+					&cli.StringFlag{
+						Name:  "namespace",
+						Usage: "The namespace to export",
+						Value: "default",
+					},
+					&cli.StringFlag{
+						Name:  "group",
+						Usage: "Only export the resources of this API group",
+					},
+					&cli.StringFlag{
+						Name:  "resource",
+						Usage: "Only export this resource",
+					},
+					&cli.BoolFlag{
+						Name:  "with-history",
+						Usage: "Export every version, including deletions, rather than only the latest values",
+					},
+				},
+			},
+			{
+				Name:   "import",
+				Usage:  "writes the resources of an archive in a single transaction. Either every resource is imported or none.",
+				Action: runRunnerCommand(importResourcesCommand),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "file",
+						Usage: "The archive to read",
+					},
+					&cli.StringFlag{
+						Name:  "namespace",
+						Usage: "Import the resources into this namespace rather than the exported one",
+					},
+				},
+			},
+		},
+	},
 	{
 		Name:  "user-manager",
 		Usage: "Runs different helpful user commands",
@@ -0,0 +1,244 @@
+package unifiedstorage
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/grafana/grafana/pkg/storage/unified/resource"
+)
+
+// An archive is a gzipped tar with a manifest followed by the exported values as newline delimited JSON.
+// The values are ordered by key then resource version, so the history can be replayed in order.
+const (
+	manifestFile  = "manifest.json"
+	resourcesFile = "resources.ndjson"
+
+	// the largest line in the resources file
+	maxItemSize = 64 * 1024 * 1024
+)
+
+// Manifest describes the content of an archive
+type Manifest struct {
+	Namespace   string    `json:"namespace"`
+	Group       string    `json:"group,omitempty"`
+	Resource    string    `json:"resource,omitempty"`
+	WithHistory bool      `json:"withHistory"`
+	Count       int64     `json:"count"`
+	Created     time.Time `json:"created"`
+}
+
+// archiveItem is a line of the resources file
+type archiveItem struct {
+	Group           string          `json:"group"`
+	Resource        string          `json:"resource"`
+	Namespace       string          `json:"namespace"`
+	Name            string          `json:"name"`
+	ResourceVersion int64           `json:"resourceVersion"`
+	Action          string          `json:"action"`
+	Value           json.RawMessage `json:"value"`
+}
+
+// Export writes the values returned by an export request to an archive.
+func Export(ctx context.Context, client resource.ResourceStoreClient, req *resource.ExportRequest, w io.Writer) (*Manifest, error) {
+	manifest := &Manifest{
+		Namespace:   req.Key.Namespace,
+		Group:       req.Key.Group,
+		Resource:    req.Key.Resource,
+		WithHistory: req.WithHistory,
+		Created:     time.Now().UTC(),
+	}
+
+	// The size of a tar entry must be known before it is written
+	tmp, err := os.CreateTemp("", "grafana-export-*.ndjson")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	stream, err := client.Export(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	buf := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(buf)
+	for {
+		item, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		err = enc.Encode(&archiveItem{
+			Group:           item.Key.Group,
+			Resource:        item.Key.Resource,
+			Namespace:       item.Key.Namespace,
+			Name:            item.Key.Name,
+			ResourceVersion: item.ResourceVersion,
+			Action:          item.Action.String(),
+			Value:           item.Value,
+		})
+		if err != nil {
+			return nil, err
+		}
+		manifest.Count++
+	}
+	if err := buf.Flush(); err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeTarEntry(tw, manifestFile, manifest.Created, int64(len(raw)), bytes.NewReader(raw)); err != nil {
+		return nil, err
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if err := writeTarEntry(tw, resourcesFile, manifest.Created, size, tmp); err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, gz.Close()
+}
+
+func writeTarEntry(tw *tar.Writer, name string, modTime time.Time, size int64, r io.Reader) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0640,
+		Size:    size,
+		ModTime: modTime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, r)
+	return err
+}
+
+// Import sends the values of an archive in a single BatchWrite request, so either every value is written or none.
+// When namespace is set, the values are moved to that namespace.
+func Import(ctx context.Context, client resource.ResourceStoreClient, r io.Reader, namespace string) (*Manifest, *resource.BatchWriteResponse, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+
+	var manifest *Manifest
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("the archive does not contain %s", resourcesFile)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid archive: %w", err)
+		}
+
+		switch hdr.Name {
+		case manifestFile:
+			manifest = &Manifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, nil, fmt.Errorf("invalid manifest: %w", err)
+			}
+		case resourcesFile:
+			if manifest == nil {
+				return nil, nil, fmt.Errorf("the archive does not start with %s", manifestFile)
+			}
+			rsp, err := importResources(ctx, client, tr, namespace)
+			return manifest, rsp, err
+		}
+	}
+}
+
+func importResources(ctx context.Context, client resource.ResourceStoreClient, r io.Reader, namespace string) (*resource.BatchWriteResponse, error) {
+	// canceling the stream, rather than closing it, makes sure nothing is written after an error
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.BatchWrite(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxItemSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		req, err := readItem(scanner.Bytes(), namespace)
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", resourcesFile, line, err)
+		}
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return stream.CloseAndRecv()
+}
+
+func readItem(raw []byte, namespace string) (*resource.BatchWriteRequest, error) {
+	item := &archiveItem{}
+	if err := json.Unmarshal(raw, item); err != nil {
+		return nil, err
+	}
+	action, ok := resource.WatchEvent_Type_value[item.Action]
+	if !ok {
+		return nil, fmt.Errorf("unknown action %q", item.Action)
+	}
+
+	req := &resource.BatchWriteRequest{
+		Key: &resource.ResourceKey{
+			Group:     item.Group,
+			Resource:  item.Resource,
+			Namespace: item.Namespace,
+			Name:      item.Name,
+		},
+		Action: resource.WatchEvent_Type(action),
+		Value:  item.Value,
+	}
+	if namespace != "" && namespace != item.Namespace {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(item.Value); err != nil {
+			return nil, err
+		}
+		obj.SetNamespace(namespace)
+		value, err := obj.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		req.Key.Namespace = namespace
+		req.Value = value
+	}
+	return req, nil
+}
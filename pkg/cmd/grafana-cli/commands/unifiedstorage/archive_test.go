@@ -0,0 +1,98 @@
+package unifiedstorage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob/memblob"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/storage/unified/resource"
+)
+
+func newTestClient(t *testing.T, ctx context.Context) resource.ResourceStoreClient {
+	t.Helper()
+
+	backend, err := resource.NewCDKBackend(ctx, resource.CDKBackendOptions{
+		Bucket: memblob.OpenBucket(nil),
+	})
+	require.NoError(t, err)
+	server, err := resource.NewResourceServer(resource.ResourceServerOptions{
+		Backend: backend,
+	})
+	require.NoError(t, err)
+	return resource.NewLocalResourceStoreClient(server)
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := identity.WithRequester(context.Background(), &identity.StaticRequester{
+		Type:           identity.TypeUser,
+		Login:          "testuser",
+		UserID:         123,
+		UserUID:        "u123",
+		OrgRole:        identity.RoleAdmin,
+		IsGrafanaAdmin: true, // can do anything
+	})
+	key := func(name, namespace string) *resource.ResourceKey {
+		return &resource.ResourceKey{
+			Group:     "playlist.grafana.app",
+			Resource:  "playlists",
+			Namespace: namespace,
+			Name:      name,
+		}
+	}
+
+	source := newTestClient(t, ctx)
+	for _, name := range []string{"a", "b"} {
+		rsp, err := source.Create(ctx, &resource.CreateRequest{
+			Key: key(name, "default"),
+			Value: []byte(fmt.Sprintf(`{
+				"apiVersion": "playlist.grafana.app/v0alpha1",
+				"kind": "Playlist",
+				"metadata": {"name": %q, "namespace": "default"},
+				"spec": {"title": "hello"}
+			}`, name)),
+		})
+		require.NoError(t, err)
+		require.Nil(t, rsp.Error)
+	}
+	deleted, err := source.Delete(ctx, &resource.DeleteRequest{Key: key("b", "default")})
+	require.NoError(t, err)
+	require.Nil(t, deleted.Error)
+
+	archive := &bytes.Buffer{}
+	manifest, err := Export(ctx, source, &resource.ExportRequest{
+		Key:         &resource.ResourceKey{Namespace: "default"},
+		WithHistory: true,
+	}, archive)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), manifest.Count)
+
+	t.Run("import into another namespace", func(t *testing.T) {
+		target := newTestClient(t, ctx)
+		imported, rsp, err := Import(ctx, target, bytes.NewReader(archive.Bytes()), "stacks-1")
+		require.NoError(t, err)
+		require.Nil(t, rsp.Error)
+		require.Equal(t, "default", imported.Namespace)
+		require.True(t, imported.WithHistory)
+		require.Equal(t, int64(3), rsp.Written)
+
+		found, err := target.Read(ctx, &resource.ReadRequest{Key: key("a", "stacks-1")})
+		require.NoError(t, err)
+		require.Nil(t, found.Error)
+		require.Contains(t, string(found.Value), `"namespace":"stacks-1"`)
+
+		found, err = target.Read(ctx, &resource.ReadRequest{Key: key("b", "stacks-1")})
+		require.NoError(t, err)
+		require.NotNil(t, found.Error)
+	})
+
+	t.Run("invalid archives", func(t *testing.T) {
+		target := newTestClient(t, ctx)
+		_, _, err := Import(ctx, target, bytes.NewReader([]byte("{}")), "")
+		require.ErrorContains(t, err, "invalid archive")
+	})
+}
@@ -0,0 +1,378 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// The anomaly detection algorithm
+// +enum
+type AnomalyAlgorithm string
+
+const (
+	// Mean and standard deviation of the baseline
+	AnomalyAlgorithmZScore AnomalyAlgorithm = "zscore"
+
+	// Median and median absolute deviation of the baseline, robust to outliers
+	AnomalyAlgorithmMAD AnomalyAlgorithm = "mad"
+
+	// Median and median absolute deviation of points at the same phase of the season
+	AnomalyAlgorithmSeasonal AnomalyAlgorithm = "seasonal"
+)
+
+// The series returned by the anomaly detection
+// +enum
+type AnomalyOutput string
+
+const (
+	// Upper band, lower band and anomaly flag series, distinguished by the anomaly_band label
+	AnomalyOutputAll AnomalyOutput = "all"
+
+	// Only the anomaly flag series, with the labels of the input series
+	AnomalyOutputFlag AnomalyOutput = "flag"
+)
+
+const (
+	anomalyBandLabel = "anomaly_band"
+
+	defaultAnomalySensitivity = 3.0
+
+	// madScale makes the median absolute deviation a consistent estimator of the standard deviation for normally distributed data
+	madScale = 1.4826
+)
+
+var supportedAnomalyAlgorithms = []string{
+	string(AnomalyAlgorithmZScore),
+	string(AnomalyAlgorithmMAD),
+	string(AnomalyAlgorithmSeasonal),
+}
+
+// AnomalyCommand is an expression command that detects anomalies in time series locally.
+// For every point it computes an expected band from a baseline and flags the points outside of it.
+type AnomalyCommand struct {
+	ReferenceVar string
+	RefID        string
+	Algorithm    AnomalyAlgorithm
+	Sensitivity  float64
+	// Window is the trailing duration used as baseline by the zscore and mad algorithms. Zero uses the whole series.
+	Window time.Duration
+	// Period is the length of the season used by the seasonal algorithm.
+	Period time.Duration
+	Output AnomalyOutput
+}
+
+// NewAnomalyCommand creates a new AnomalyCommand.
+func NewAnomalyCommand(refID, referenceVar string, algorithm AnomalyAlgorithm, sensitivity float64, rawWindow, rawPeriod string, output AnomalyOutput) (*AnomalyCommand, error) {
+	cmd := &AnomalyCommand{
+		ReferenceVar: referenceVar,
+		RefID:        refID,
+		Algorithm:    algorithm,
+		Sensitivity:  sensitivity,
+		Output:       output,
+	}
+
+	switch algorithm {
+	case AnomalyAlgorithmZScore, AnomalyAlgorithmMAD, AnomalyAlgorithmSeasonal:
+	default:
+		return nil, fmt.Errorf("expected anomaly algorithm to be one of [%s], got %s", strings.Join(supportedAnomalyAlgorithms, ", "), algorithm)
+	}
+
+	if cmd.Sensitivity == 0 {
+		cmd.Sensitivity = defaultAnomalySensitivity
+	}
+	if cmd.Sensitivity < 0 {
+		return nil, fmt.Errorf("anomaly sensitivity must be positive, got %v", sensitivity)
+	}
+
+	switch output {
+	case "":
+		cmd.Output = AnomalyOutputAll
+	case AnomalyOutputAll, AnomalyOutputFlag:
+	default:
+		return nil, fmt.Errorf("expected anomaly output to be one of [%s, %s], got %s", AnomalyOutputAll, AnomalyOutputFlag, output)
+	}
+
+	if rawWindow != "" {
+		window, err := gtime.ParseDuration(rawWindow)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to parse anomaly "window" duration field %q: %w`, rawWindow, err)
+		}
+		if window <= 0 {
+			return nil, fmt.Errorf("anomaly window must be positive, got %s", rawWindow)
+		}
+		cmd.Window = window
+	}
+
+	if algorithm == AnomalyAlgorithmSeasonal {
+		if rawPeriod == "" {
+			return nil, fmt.Errorf("the seasonal anomaly algorithm requires a period")
+		}
+		period, err := gtime.ParseDuration(rawPeriod)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to parse anomaly "period" duration field %q: %w`, rawPeriod, err)
+		}
+		if period <= 0 {
+			return nil, fmt.Errorf("anomaly period must be positive, got %s", rawPeriod)
+		}
+		cmd.Period = period
+	}
+
+	return cmd, nil
+}
+
+// UnmarshalAnomalyCommand creates an AnomalyCommand from Grafana's frontend query.
+func UnmarshalAnomalyCommand(rn *rawNode) (*AnomalyCommand, error) {
+	q := AnomalyQuery{}
+	if err := json.Unmarshal(rn.QueryRaw, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse the anomaly command: %w", err)
+	}
+	referenceVar, err := getReferenceVar(q.Expression, rn.RefID)
+	if err != nil {
+		return nil, err
+	}
+	return NewAnomalyCommand(rn.RefID, referenceVar, q.Algorithm, q.Sensitivity, q.Window, q.Period, q.Output)
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (ac *AnomalyCommand) NeedsVars() []string {
+	return []string{ac.ReferenceVar}
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute.
+func (ac *AnomalyCommand) Execute(ctx context.Context, _ time.Time, vars mathexp.Vars, tracer tracing.Tracer) (mathexp.Results, error) {
+	_, span := tracer.Start(ctx, "SSE.ExecuteAnomaly")
+	defer span.End()
+
+	newRes := mathexp.Results{}
+	for _, val := range vars[ac.ReferenceVar].Values {
+		switch v := val.(type) {
+		case mathexp.Series:
+			newRes.Values = append(newRes.Values, ac.detect(v)...)
+		case mathexp.NoData:
+			newRes.Values = append(newRes.Values, v.New())
+		default:
+			return newRes, fmt.Errorf("can only detect anomalies in type series, got type %v", val.Type())
+		}
+	}
+	return newRes, nil
+}
+
+func (ac *AnomalyCommand) Type() string {
+	return TypeAnomaly.String()
+}
+
+// detect returns the band and flag series for s, depending on the configured output.
+func (ac *AnomalyCommand) detect(s mathexp.Series) []mathexp.Value {
+	points := sortedPoints(s)
+
+	var bands []band
+	if ac.Algorithm == AnomalyAlgorithmSeasonal {
+		bands = ac.seasonalBands(points)
+	} else {
+		bands = ac.rollingBands(points)
+	}
+
+	flag := mathexp.NewSeries(ac.RefID, ac.outputLabels(s, "anomaly"), len(points))
+	upper := mathexp.NewSeries(ac.RefID, ac.outputLabels(s, "upper"), len(points))
+	lower := mathexp.NewSeries(ac.RefID, ac.outputLabels(s, "lower"), len(points))
+	for i, p := range points {
+		b := bands[i]
+		upper.SetPoint(i, p.t, b.upper)
+		lower.SetPoint(i, p.t, b.lower)
+
+		var f *float64
+		if p.v != nil && b.upper != nil && b.lower != nil {
+			if *p.v > *b.upper || *p.v < *b.lower {
+				f = util.Pointer(float64(1))
+			} else {
+				f = util.Pointer(float64(0))
+			}
+		}
+		flag.SetPoint(i, p.t, f)
+	}
+
+	if ac.Output == AnomalyOutputFlag {
+		return []mathexp.Value{flag}
+	}
+	return []mathexp.Value{upper, lower, flag}
+}
+
+func (ac *AnomalyCommand) outputLabels(s mathexp.Series, name string) data.Labels {
+	labels := s.GetLabels().Copy()
+	if ac.Output == AnomalyOutputFlag {
+		return labels
+	}
+	if labels == nil {
+		labels = data.Labels{}
+	}
+	labels[anomalyBandLabel] = name
+	return labels
+}
+
+type point struct {
+	t time.Time
+	v *float64
+}
+
+// band is the expected range of a point. Bounds are nil if there is no baseline to compute them from.
+type band struct {
+	upper *float64
+	lower *float64
+}
+
+// rollingBands computes the bands of the zscore and mad algorithms. The baseline of a point is made of the
+// points in the trailing window before it, or of the whole series if no window is set.
+func (ac *AnomalyCommand) rollingBands(points []point) []band {
+	bands := make([]band, len(points))
+	if ac.Window == 0 {
+		center, spread, ok := ac.baseline(numbers(points))
+		for i := range points {
+			if ok {
+				bands[i] = newBand(center, spread, ac.Sensitivity)
+			}
+		}
+		return bands
+	}
+
+	start := 0
+	for i, p := range points {
+		for !points[start].t.After(p.t.Add(-ac.Window)) {
+			start++
+		}
+		if center, spread, ok := ac.baseline(numbers(points[start:i])); ok {
+			bands[i] = newBand(center, spread, ac.Sensitivity)
+		}
+	}
+	return bands
+}
+
+// seasonalBands computes the bands of the seasonal algorithm. Points are grouped by their phase in the period,
+// rounded to the typical spacing of the series, and the band of each group is computed from its median and
+// median absolute deviation.
+func (ac *AnomalyCommand) seasonalBands(points []point) []band {
+	bands := make([]band, len(points))
+	step := medianStep(points)
+	if step <= 0 || step > ac.Period {
+		step = ac.Period
+	}
+
+	phase := func(t time.Time) int64 {
+		offset := time.Duration(t.UnixNano() % int64(ac.Period))
+		return int64((offset + step/2) / step % (ac.Period / step))
+	}
+
+	groups := map[int64][]float64{}
+	for _, p := range points {
+		if p.v != nil && !math.IsNaN(*p.v) && !math.IsInf(*p.v, 0) {
+			groups[phase(p.t)] = append(groups[phase(p.t)], *p.v)
+		}
+	}
+	for i, p := range points {
+		if center, spread, ok := medianDeviation(groups[phase(p.t)]); ok {
+			bands[i] = newBand(center, spread, ac.Sensitivity)
+		}
+	}
+	return bands
+}
+
+// baseline returns the center and spread of the values, depending on the algorithm.
+func (ac *AnomalyCommand) baseline(values []float64) (float64, float64, bool) {
+	if ac.Algorithm == AnomalyAlgorithmZScore {
+		return meanStdDev(values)
+	}
+	return medianDeviation(values)
+}
+
+func newBand(center, spread, sensitivity float64) band {
+	return band{
+		upper: util.Pointer(center + sensitivity*spread),
+		lower: util.Pointer(center - sensitivity*spread),
+	}
+}
+
+// sortedPoints returns the points of the series sorted by time.
+func sortedPoints(s mathexp.Series) []point {
+	points := make([]point, s.Len())
+	for i := range points {
+		points[i].t, points[i].v = s.GetPoint(i)
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].t.Before(points[j].t)
+	})
+	return points
+}
+
+// numbers returns the real number values of the points, ignoring null, NaN and Inf.
+func numbers(points []point) []float64 {
+	values := make([]float64, 0, len(points))
+	for _, p := range points {
+		if p.v != nil && !math.IsNaN(*p.v) && !math.IsInf(*p.v, 0) {
+			values = append(values, *p.v)
+		}
+	}
+	return values
+}
+
+func meanStdDev(values []float64) (float64, float64, bool) {
+	if len(values) == 0 {
+		return 0, 0, false
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	var sq float64
+	for _, v := range values {
+		sq += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(sq / float64(len(values))), true
+}
+
+func medianDeviation(values []float64) (float64, float64, bool) {
+	if len(values) == 0 {
+		return 0, 0, false
+	}
+	med := median(values)
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - med)
+	}
+	return med, madScale * median(deviations), true
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	l := len(sorted)
+	if l%2 == 1 {
+		return sorted[l/2]
+	}
+	return (sorted[l/2-1] + sorted[l/2]) / 2
+}
+
+// medianStep returns the median duration between consecutive points.
+func medianStep(points []point) time.Duration {
+	if len(points) < 2 {
+		return 0
+	}
+	steps := make([]float64, 0, len(points)-1)
+	for i := 1; i < len(points); i++ {
+		steps = append(steps, float64(points[i].t.Sub(points[i-1].t)))
+	}
+	return time.Duration(median(steps))
+}
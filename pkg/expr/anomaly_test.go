@@ -0,0 +1,159 @@
+package expr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestNewAnomalyCommand(t *testing.T) {
+	cases := []struct {
+		name      string
+		algorithm AnomalyAlgorithm
+		window    string
+		period    string
+		output    AnomalyOutput
+		expectErr string
+	}{
+		{name: "zscore with defaults", algorithm: AnomalyAlgorithmZScore},
+		{name: "mad with window", algorithm: AnomalyAlgorithmMAD, window: "1h"},
+		{name: "seasonal with period", algorithm: AnomalyAlgorithmSeasonal, period: "1d", output: AnomalyOutputFlag},
+		{name: "unknown algorithm", algorithm: "prophet", expectErr: "expected anomaly algorithm"},
+		{name: "seasonal without period", algorithm: AnomalyAlgorithmSeasonal, expectErr: "requires a period"},
+		{name: "invalid window", algorithm: AnomalyAlgorithmZScore, window: "soon", expectErr: "window"},
+		{name: "negative window", algorithm: AnomalyAlgorithmZScore, window: "-5m", expectErr: "window must be positive"},
+		{name: "zero window", algorithm: AnomalyAlgorithmMAD, window: "0s", expectErr: "window must be positive"},
+		{name: "unknown output", algorithm: AnomalyAlgorithmZScore, output: "bands", expectErr: "expected anomaly output"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd, err := NewAnomalyCommand("B", "A", tc.algorithm, 0, tc.window, tc.period, tc.output)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, defaultAnomalySensitivity, cmd.Sensitivity)
+			assert.NotEmpty(t, cmd.Output)
+		})
+	}
+}
+
+func TestUnmarshalAnomalyCommand(t *testing.T) {
+	cmd, err := UnmarshalAnomalyCommand(&rawNode{
+		RefID:    "B",
+		QueryRaw: []byte(`{"type":"anomaly","expression":"$A","algorithm":"mad","sensitivity":2,"window":"30m","output":"flag"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A"}, cmd.NeedsVars())
+	assert.Equal(t, AnomalyAlgorithmMAD, cmd.Algorithm)
+	assert.Equal(t, 2.0, cmd.Sensitivity)
+	assert.Equal(t, 30*time.Minute, cmd.Window)
+	assert.Equal(t, AnomalyOutputFlag, cmd.Output)
+}
+
+func TestAnomalyExecute(t *testing.T) {
+	start := time.Unix(0, 0)
+	newSeries := func(values ...float64) mathexp.Series {
+		s := mathexp.NewSeries("A", data.Labels{"host": "a"}, len(values))
+		for i, v := range values {
+			s.SetPoint(i, start.Add(time.Duration(i)*time.Minute), util.Pointer(v))
+		}
+		return s
+	}
+	flags := func(v mathexp.Value) []float64 {
+		s := v.(mathexp.Series)
+		res := make([]float64, 0, s.Len())
+		for i := 0; i < s.Len(); i++ {
+			if f := s.GetValue(i); f != nil {
+				res = append(res, *f)
+			} else {
+				res = append(res, -1)
+			}
+		}
+		return res
+	}
+
+	t.Run("zscore flags outliers and returns bands", func(t *testing.T) {
+		cmd, err := NewAnomalyCommand("B", "A", AnomalyAlgorithmZScore, 2, "", "", "")
+		require.NoError(t, err)
+
+		res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+			"A": {Values: []mathexp.Value{newSeries(10, 11, 10, 9, 10, 11, 10, 9, 10, 50)}},
+		}, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Len(t, res.Values, 3)
+
+		assert.Equal(t, "upper", res.Values[0].GetLabels()[anomalyBandLabel])
+		assert.Equal(t, "lower", res.Values[1].GetLabels()[anomalyBandLabel])
+		assert.Equal(t, "anomaly", res.Values[2].GetLabels()[anomalyBandLabel])
+		assert.Equal(t, "a", res.Values[2].GetLabels()["host"])
+		assert.Equal(t, []float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, flags(res.Values[2]))
+	})
+
+	t.Run("mad with window uses the trailing points as baseline", func(t *testing.T) {
+		cmd, err := NewAnomalyCommand("B", "A", AnomalyAlgorithmMAD, 3, "3m", "", AnomalyOutputFlag)
+		require.NoError(t, err)
+
+		res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+			"A": {Values: []mathexp.Value{newSeries(10, 11, 10, 11, 30, 31, 30)}},
+		}, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Len(t, res.Values, 1)
+		assert.Equal(t, data.Labels{"host": "a"}, res.Values[0].GetLabels())
+		// The first point has no baseline and the level shift is only flagged until it enters the window
+		assert.Equal(t, []float64{-1, 1, 0, 0, 1, 0, 0}, flags(res.Values[0]))
+	})
+
+	t.Run("seasonal compares points at the same phase of the period", func(t *testing.T) {
+		cmd, err := NewAnomalyCommand("B", "A", AnomalyAlgorithmSeasonal, 3, "", "4m", AnomalyOutputFlag)
+		require.NoError(t, err)
+
+		res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+			"A": {Values: []mathexp.Value{newSeries(
+				1, 100, 1, 1,
+				2, 101, 2, 2,
+				1, 100, 1, 2,
+				2, 1, 2, 1,
+			)}},
+		}, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		// The high values in the second slot are expected, the missing spike in the last period is not
+		assert.Equal(t, []float64{
+			0, 0, 0, 0,
+			0, 0, 0, 0,
+			0, 0, 0, 0,
+			0, 1, 0, 0,
+		}, flags(res.Values[0]))
+	})
+
+	t.Run("no data returns no data", func(t *testing.T) {
+		cmd, err := NewAnomalyCommand("B", "A", AnomalyAlgorithmZScore, 0, "", "", "")
+		require.NoError(t, err)
+
+		res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+			"A": {Values: []mathexp.Value{mathexp.NoData{}.New()}},
+		}, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.True(t, res.IsNoData())
+	})
+
+	t.Run("numbers are not supported", func(t *testing.T) {
+		cmd, err := NewAnomalyCommand("B", "A", AnomalyAlgorithmZScore, 0, "", "", "")
+		require.NoError(t, err)
+
+		_, err = cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+			"A": {Values: []mathexp.Value{mathexp.NewNumber("A", nil)}},
+		}, tracing.InitializeTracerForTest())
+		require.Error(t, err)
+	})
+}
@@ -216,22 +216,27 @@ type ResampleCommand struct {
 	VarToResample string
 	Downsampler   mathexp.ReducerID
 	Upsampler     mathexp.Upsampler
+	MaxGap        int
 	TimeRange     TimeRange
 	refID         string
 }
 
 // NewResampleCommand creates a new ResampleCMD.
-func NewResampleCommand(refID, rawWindow, varToResample string, downsampler mathexp.ReducerID, upsampler mathexp.Upsampler, tr TimeRange) (*ResampleCommand, error) {
+func NewResampleCommand(refID, rawWindow, varToResample string, downsampler mathexp.ReducerID, upsampler mathexp.Upsampler, maxGap int, tr TimeRange) (*ResampleCommand, error) {
 	// TODO: validate reducer here, before execution
 	window, err := gtime.ParseDuration(rawWindow)
 	if err != nil {
 		return nil, fmt.Errorf(`failed to parse resample "window" duration field %q: %w`, window, err)
 	}
+	if maxGap < 0 {
+		return nil, fmt.Errorf(`resample "maxGap" must not be negative, got %d`, maxGap)
+	}
 	return &ResampleCommand{
 		Window:        window,
 		VarToResample: varToResample,
 		Downsampler:   downsampler,
 		Upsampler:     upsampler,
+		MaxGap:        maxGap,
 		TimeRange:     tr,
 		refID:         refID,
 	}, nil
@@ -280,10 +285,20 @@ func UnmarshalResampleCommand(rn *rawNode) (*ResampleCommand, error) {
 		return nil, fmt.Errorf("expected resample downsampler to be a string, got type %T", upsampler)
 	}
 
+	maxGap := 0
+	if rawMaxGap, ok := rn.Query["maxGap"]; ok && rawMaxGap != nil {
+		f, ok := rawMaxGap.(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected resample maxGap to be a number, got type %T", rawMaxGap)
+		}
+		maxGap = int(f)
+	}
+
 	return NewResampleCommand(rn.RefID, window,
 		varToResample,
 		mathexp.ReducerID(downsampler),
 		mathexp.Upsampler(upsampler),
+		maxGap,
 		rn.TimeRange)
 }
 
@@ -306,7 +321,7 @@ func (gr *ResampleCommand) Execute(ctx context.Context, now time.Time, vars math
 		}
 		switch v := val.(type) {
 		case mathexp.Series:
-			num, err := v.Resample(gr.refID, gr.Window, gr.Downsampler, gr.Upsampler, timeRange.From, timeRange.To)
+			num, err := v.Resample(gr.refID, gr.Window, gr.Downsampler, gr.Upsampler, gr.MaxGap, timeRange.From, timeRange.To)
 			if err != nil {
 				return newRes, err
 			}
@@ -343,6 +358,14 @@ const (
 	TypeThreshold
 	// TypeSQL is the CMDType for running SQL expressions
 	TypeSQL
+	// TypeAnomaly is the CMDType for detecting anomalies in time series
+	TypeAnomaly
+	// TypeForecast is the CMDType for forecasting time series
+	TypeForecast
+	// TypeJoin is the CMDType for joining results into a single frame
+	TypeJoin
+	// TypeUnion is the CMDType for concatenating results into a single frame
+	TypeUnion
 )
 
 func (gt CommandType) String() string {
@@ -359,6 +382,14 @@ func (gt CommandType) String() string {
 		return "threshold"
 	case TypeSQL:
 		return "sql"
+	case TypeAnomaly:
+		return "anomaly"
+	case TypeForecast:
+		return "forecast"
+	case TypeJoin:
+		return "join"
+	case TypeUnion:
+		return "union"
 	default:
 		return "unknown"
 	}
@@ -379,6 +410,14 @@ func ParseCommandType(s string) (CommandType, error) {
 		return TypeThreshold, nil
 	case "sql":
 		return TypeSQL, nil
+	case "anomaly":
+		return TypeAnomaly, nil
+	case "forecast":
+		return TypeForecast, nil
+	case "join":
+		return TypeJoin, nil
+	case "union":
+		return TypeUnion, nil
 	default:
 		return TypeUnknown, fmt.Errorf("'%v' is not a recognized expression type", s)
 	}
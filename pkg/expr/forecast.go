@@ -0,0 +1,383 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// The model fitted to the series
+// +enum
+type ForecastModel string
+
+const (
+	// Least squares linear regression
+	ForecastModelLinear ForecastModel = "linear"
+
+	// Additive Holt-Winters triple exponential smoothing, without seasonality if no period is set
+	ForecastModelHoltWinters ForecastModel = "holt_winters"
+)
+
+// The result returned by the forecast
+// +enum
+type ForecastOutput string
+
+const (
+	// Series of forecasted points from the last point of the input to the horizon
+	ForecastOutputSeries ForecastOutput = "series"
+
+	// Number with the forecasted value at the horizon
+	ForecastOutputValue ForecastOutput = "value"
+
+	// Number of seconds until the forecast reaches the threshold, +Inf if it does not within the horizon
+	ForecastOutputTimeToThreshold ForecastOutput = "time_to_threshold"
+)
+
+const (
+	defaultHoltWintersAlpha = 0.5
+	defaultHoltWintersBeta  = 0.1
+	defaultHoltWintersGamma = 0.1
+)
+
+var supportedForecastModels = []string{
+	string(ForecastModelLinear),
+	string(ForecastModelHoltWinters),
+}
+
+var supportedForecastOutputs = []string{
+	string(ForecastOutputSeries),
+	string(ForecastOutputValue),
+	string(ForecastOutputTimeToThreshold),
+}
+
+// ForecastCommand is an expression command that fits a model to time series and predicts their future values.
+type ForecastCommand struct {
+	ReferenceVar string
+	RefID        string
+	Model        ForecastModel
+	// Horizon is how far after the last point of the series the forecast goes.
+	Horizon time.Duration
+	// Window is the trailing duration of the series used to fit the model. Zero uses the whole series.
+	Window time.Duration
+	// Period is the length of the season used by the Holt-Winters model. Zero disables seasonality.
+	Period time.Duration
+	// Alpha, Beta and Gamma are the level, trend and seasonal smoothing factors of the Holt-Winters model.
+	Alpha     float64
+	Beta      float64
+	Gamma     float64
+	Threshold *float64
+	Output    ForecastOutput
+}
+
+// NewForecastCommand creates a new ForecastCommand.
+func NewForecastCommand(refID, referenceVar string, model ForecastModel, rawHorizon, rawWindow, rawPeriod string, alpha, beta, gamma float64, threshold *float64, output ForecastOutput) (*ForecastCommand, error) {
+	cmd := &ForecastCommand{
+		ReferenceVar: referenceVar,
+		RefID:        refID,
+		Model:        model,
+		Threshold:    threshold,
+		Output:       output,
+	}
+
+	switch model {
+	case ForecastModelLinear, ForecastModelHoltWinters:
+	default:
+		return nil, fmt.Errorf("expected forecast model to be one of [%s], got %s", strings.Join(supportedForecastModels, ", "), model)
+	}
+
+	switch output {
+	case "":
+		cmd.Output = ForecastOutputSeries
+	case ForecastOutputSeries, ForecastOutputValue:
+	case ForecastOutputTimeToThreshold:
+		if threshold == nil {
+			return nil, fmt.Errorf("the time_to_threshold forecast output requires a threshold")
+		}
+	default:
+		return nil, fmt.Errorf("expected forecast output to be one of [%s], got %s", strings.Join(supportedForecastOutputs, ", "), output)
+	}
+
+	if rawHorizon == "" {
+		return nil, fmt.Errorf("forecast requires a horizon")
+	}
+	horizon, err := parseForecastDuration("horizon", rawHorizon)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Horizon = horizon
+
+	if rawWindow != "" {
+		if cmd.Window, err = parseForecastDuration("window", rawWindow); err != nil {
+			return nil, err
+		}
+	}
+
+	if model == ForecastModelHoltWinters {
+		if rawPeriod != "" {
+			if cmd.Period, err = parseForecastDuration("period", rawPeriod); err != nil {
+				return nil, err
+			}
+		}
+		if cmd.Alpha, err = smoothingFactor("alpha", alpha, defaultHoltWintersAlpha); err != nil {
+			return nil, err
+		}
+		if cmd.Beta, err = smoothingFactor("beta", beta, defaultHoltWintersBeta); err != nil {
+			return nil, err
+		}
+		if cmd.Gamma, err = smoothingFactor("gamma", gamma, defaultHoltWintersGamma); err != nil {
+			return nil, err
+		}
+	}
+
+	return cmd, nil
+}
+
+func parseForecastDuration(field, raw string) (time.Duration, error) {
+	d, err := gtime.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf(`failed to parse forecast %q duration field %q: %w`, field, raw, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("forecast %s must be positive, got %s", field, raw)
+	}
+	return d, nil
+}
+
+func smoothingFactor(name string, v, def float64) (float64, error) {
+	if v == 0 {
+		return def, nil
+	}
+	if v < 0 || v > 1 {
+		return 0, fmt.Errorf("forecast %s must be between 0 and 1, got %v", name, v)
+	}
+	return v, nil
+}
+
+// UnmarshalForecastCommand creates a ForecastCommand from Grafana's frontend query.
+func UnmarshalForecastCommand(rn *rawNode) (*ForecastCommand, error) {
+	q := ForecastQuery{}
+	if err := json.Unmarshal(rn.QueryRaw, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse the forecast command: %w", err)
+	}
+	referenceVar, err := getReferenceVar(q.Expression, rn.RefID)
+	if err != nil {
+		return nil, err
+	}
+	return NewForecastCommand(rn.RefID, referenceVar, q.Model, q.Horizon, q.Window, q.Period, q.Alpha, q.Beta, q.Gamma, q.Threshold, q.Output)
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (fc *ForecastCommand) NeedsVars() []string {
+	return []string{fc.ReferenceVar}
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute.
+func (fc *ForecastCommand) Execute(ctx context.Context, _ time.Time, vars mathexp.Vars, tracer tracing.Tracer) (mathexp.Results, error) {
+	_, span := tracer.Start(ctx, "SSE.ExecuteForecast")
+	defer span.End()
+
+	newRes := mathexp.Results{}
+	for _, val := range vars[fc.ReferenceVar].Values {
+		switch v := val.(type) {
+		case mathexp.Series:
+			newRes.Values = append(newRes.Values, fc.forecast(v))
+		case mathexp.NoData:
+			newRes.Values = append(newRes.Values, v.New())
+		default:
+			return newRes, fmt.Errorf("can only forecast type series, got type %v", val.Type())
+		}
+	}
+	return newRes, nil
+}
+
+func (fc *ForecastCommand) Type() string {
+	return TypeForecast.String()
+}
+
+// forecaster predicts the value of a series h after its last point.
+type forecaster func(h time.Duration) float64
+
+// forecast fits the model to s and returns the configured output. If there is not enough data to fit the
+// model, the result has null values.
+func (fc *ForecastCommand) forecast(s mathexp.Series) mathexp.Value {
+	points := fc.trainingPoints(s)
+	step := medianStep(points)
+
+	var predict forecaster
+	if len(points) >= 2 && step > 0 {
+		if fc.Model == ForecastModelHoltWinters {
+			predict = fc.holtWinters(points, step)
+		} else {
+			predict = linearRegression(points)
+		}
+	}
+
+	switch fc.Output {
+	case ForecastOutputValue, ForecastOutputTimeToThreshold:
+		n := mathexp.NewNumber(fc.RefID, s.GetLabels().Copy())
+		if predict != nil {
+			if fc.Output == ForecastOutputValue {
+				n.SetValue(util.Pointer(predict(fc.Horizon)))
+			} else {
+				last := *points[len(points)-1].v
+				n.SetValue(util.Pointer(fc.timeToThreshold(predict, last, step)))
+			}
+		}
+		return n
+	default:
+		if predict == nil {
+			return mathexp.NewSeries(fc.RefID, s.GetLabels().Copy(), 0)
+		}
+		last := points[len(points)-1].t
+		steps := int(fc.Horizon / step)
+		out := mathexp.NewSeries(fc.RefID, s.GetLabels().Copy(), steps)
+		for i := 0; i < steps; i++ {
+			h := time.Duration(i+1) * step
+			out.SetPoint(i, last.Add(h), util.Pointer(predict(h)))
+		}
+		return out
+	}
+}
+
+// trainingPoints returns the sorted points with a real number value, restricted to the window if set.
+func (fc *ForecastCommand) trainingPoints(s mathexp.Series) []point {
+	sorted := sortedPoints(s)
+	points := make([]point, 0, len(sorted))
+	for _, p := range sorted {
+		if p.v != nil && !math.IsNaN(*p.v) && !math.IsInf(*p.v, 0) {
+			points = append(points, p)
+		}
+	}
+	if fc.Window == 0 || len(points) == 0 {
+		return points
+	}
+	from := points[len(points)-1].t.Add(-fc.Window)
+	for i, p := range points {
+		if p.t.After(from) {
+			return points[i:]
+		}
+	}
+	return nil
+}
+
+// timeToThreshold returns the number of seconds after the last point until the forecast reaches the
+// threshold coming from the side of the last value, 0 if the last value is already at the threshold,
+// or +Inf if the threshold is not reached within the horizon.
+func (fc *ForecastCommand) timeToThreshold(predict forecaster, last float64, step time.Duration) float64 {
+	threshold := *fc.Threshold
+	if last == threshold {
+		return 0
+	}
+	reached := func(v float64) bool {
+		return (last < threshold && v >= threshold) || (last > threshold && v <= threshold)
+	}
+	for h := step; h <= fc.Horizon; h += step {
+		if !reached(predict(h)) {
+			continue
+		}
+		// Interpolate between the last two steps so the result does not depend on the step of the series
+		prev, cur := predict(h-step), predict(h)
+		frac := 1.0
+		if cur != prev {
+			frac = math.Max(0, math.Min(1, (threshold-prev)/(cur-prev)))
+		}
+		return (h - step).Seconds() + frac*step.Seconds()
+	}
+	return math.Inf(1)
+}
+
+// linearRegression fits a line to the points with least squares.
+func linearRegression(points []point) forecaster {
+	last := points[len(points)-1].t
+	var sumX, sumY, sumXY, sumXX float64
+	for _, p := range points {
+		x := p.t.Sub(last).Seconds()
+		sumX += x
+		sumY += *p.v
+		sumXY += x * *p.v
+		sumXX += x * x
+	}
+	n := float64(len(points))
+	slope := 0.0
+	if d := n*sumXX - sumX*sumX; d != 0 {
+		slope = (n*sumXY - sumX*sumY) / d
+	}
+	intercept := (sumY - slope*sumX) / n
+	return func(h time.Duration) float64 {
+		return intercept + slope*h.Seconds()
+	}
+}
+
+// holtWinters fits an additive Holt-Winters model to the points, assuming they are evenly spaced by step.
+// Seasonality is only used when the series covers at least two periods.
+func (fc *ForecastCommand) holtWinters(points []point, step time.Duration) forecaster {
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = *p.v
+	}
+
+	m := 0
+	if fc.Period > 0 {
+		m = int(math.Round(float64(fc.Period) / float64(step)))
+	}
+	if m < 2 || len(values) < 2*m {
+		m = 0
+	}
+
+	var level, trend float64
+	var seasonal []float64
+	start := 1
+	if m == 0 {
+		level, trend = values[0], values[1]-values[0]
+	} else {
+		first, second := mean(values[:m]), mean(values[m:2*m])
+		level, trend = first, (second-first)/float64(m)
+		seasonal = make([]float64, m)
+		for i := range seasonal {
+			seasonal[i] = values[i] - first
+		}
+		start = m
+	}
+
+	for i := start; i < len(values); i++ {
+		s := 0.0
+		if m > 0 {
+			s = seasonal[i%m]
+		}
+		prevLevel := level
+		level = fc.Alpha*(values[i]-s) + (1-fc.Alpha)*(level+trend)
+		trend = fc.Beta*(level-prevLevel) + (1-fc.Beta)*trend
+		if m > 0 {
+			seasonal[i%m] = fc.Gamma*(values[i]-level) + (1-fc.Gamma)*s
+		}
+	}
+
+	n := len(values)
+	return func(h time.Duration) float64 {
+		steps := h.Seconds() / step.Seconds()
+		v := level + steps*trend
+		if m > 0 {
+			v += seasonal[(n-1+int(math.Round(steps)))%m]
+		}
+		return v
+	}
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
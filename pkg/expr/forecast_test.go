@@ -0,0 +1,171 @@
+package expr
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestNewForecastCommand(t *testing.T) {
+	cases := []struct {
+		name      string
+		model     ForecastModel
+		horizon   string
+		period    string
+		alpha     float64
+		threshold *float64
+		output    ForecastOutput
+		expectErr string
+	}{
+		{name: "linear series", model: ForecastModelLinear, horizon: "4h"},
+		{name: "holt winters with period", model: ForecastModelHoltWinters, horizon: "1d", period: "1d", alpha: 0.3},
+		{name: "time to threshold", model: ForecastModelLinear, horizon: "4h", threshold: util.Pointer(90.0), output: ForecastOutputTimeToThreshold},
+		{name: "unknown model", model: "arima", horizon: "4h", expectErr: "expected forecast model"},
+		{name: "missing horizon", model: ForecastModelLinear, expectErr: "requires a horizon"},
+		{name: "invalid horizon", model: ForecastModelLinear, horizon: "later", expectErr: "horizon"},
+		{name: "time to threshold without threshold", model: ForecastModelLinear, horizon: "4h", output: ForecastOutputTimeToThreshold, expectErr: "requires a threshold"},
+		{name: "smoothing factor out of range", model: ForecastModelHoltWinters, horizon: "4h", alpha: 2, expectErr: "alpha must be between 0 and 1"},
+		{name: "unknown output", model: ForecastModelLinear, horizon: "4h", output: "bands", expectErr: "expected forecast output"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd, err := NewForecastCommand("B", "A", tc.model, tc.horizon, "", tc.period, tc.alpha, 0, 0, tc.threshold, tc.output)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, cmd.Output)
+			if tc.model == ForecastModelHoltWinters {
+				assert.Equal(t, tc.alpha, cmd.Alpha)
+				assert.Equal(t, defaultHoltWintersBeta, cmd.Beta)
+			}
+		})
+	}
+}
+
+func TestUnmarshalForecastCommand(t *testing.T) {
+	cmd, err := UnmarshalForecastCommand(&rawNode{
+		RefID:    "B",
+		QueryRaw: []byte(`{"type":"forecast","expression":"$A","model":"linear","horizon":"4h","window":"1h","threshold":100,"output":"time_to_threshold"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A"}, cmd.NeedsVars())
+	assert.Equal(t, ForecastModelLinear, cmd.Model)
+	assert.Equal(t, 4*time.Hour, cmd.Horizon)
+	assert.Equal(t, time.Hour, cmd.Window)
+	assert.Equal(t, 100.0, *cmd.Threshold)
+	assert.Equal(t, ForecastOutputTimeToThreshold, cmd.Output)
+}
+
+func TestForecastExecute(t *testing.T) {
+	start := time.Unix(0, 0)
+	newSeries := func(values ...float64) mathexp.Series {
+		s := mathexp.NewSeries("A", data.Labels{"host": "a"}, len(values))
+		for i, v := range values {
+			s.SetPoint(i, start.Add(time.Duration(i)*time.Minute), util.Pointer(v))
+		}
+		return s
+	}
+	execute := func(t *testing.T, cmd *ForecastCommand, v mathexp.Value) mathexp.Value {
+		t.Helper()
+		res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+			"A": {Values: []mathexp.Value{v}},
+		}, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Len(t, res.Values, 1)
+		return res.Values[0]
+	}
+
+	t.Run("linear series continues the trend", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastModelLinear, "3m", "", "", 0, 0, 0, nil, "")
+		require.NoError(t, err)
+
+		s := execute(t, cmd, newSeries(10, 12, 14, 16)).(mathexp.Series)
+		require.Equal(t, 3, s.Len())
+		assert.Equal(t, data.Labels{"host": "a"}, s.GetLabels())
+		for i, expected := range []float64{18, 20, 22} {
+			ts, v := s.GetPoint(i)
+			assert.Equal(t, start.Add(time.Duration(4+i)*time.Minute), ts)
+			assert.InDelta(t, expected, *v, 1e-9)
+		}
+	})
+
+	t.Run("linear value at horizon only uses the window", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastModelLinear, "10m", "3m", "", 0, 0, 0, nil, ForecastOutputValue)
+		require.NoError(t, err)
+
+		n := execute(t, cmd, newSeries(50, 0, 1, 2, 3)).(mathexp.Number)
+		assert.InDelta(t, 13, *n.GetFloat64Value(), 1e-9)
+	})
+
+	t.Run("time to threshold", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastModelLinear, "1h", "", "", 0, 0, 0, util.Pointer(20.0), ForecastOutputTimeToThreshold)
+		require.NoError(t, err)
+
+		n := execute(t, cmd, newSeries(10, 12, 14, 16)).(mathexp.Number)
+		assert.InDelta(t, 120, *n.GetFloat64Value(), 1e-9)
+
+		// Moving away from the threshold never reaches it
+		n = execute(t, cmd, newSeries(16, 14, 12, 10)).(mathexp.Number)
+		assert.True(t, math.IsInf(*n.GetFloat64Value(), 1))
+	})
+
+	t.Run("time to threshold beyond the horizon is infinite", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastModelLinear, "1m", "", "", 0, 0, 0, util.Pointer(20.0), ForecastOutputTimeToThreshold)
+		require.NoError(t, err)
+
+		n := execute(t, cmd, newSeries(10, 12, 14, 16)).(mathexp.Number)
+		assert.True(t, math.IsInf(*n.GetFloat64Value(), 1))
+	})
+
+	t.Run("holt winters follows the season", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastModelHoltWinters, "4m", "", "4m", 0, 0, 0, nil, "")
+		require.NoError(t, err)
+
+		s := execute(t, cmd, newSeries(
+			1, 10, 1, 1,
+			1, 10, 1, 1,
+			1, 10, 1, 1,
+		)).(mathexp.Series)
+		require.Equal(t, 4, s.Len())
+		for i, expected := range []float64{1, 10, 1, 1} {
+			_, v := s.GetPoint(i)
+			assert.InDelta(t, expected, *v, 0.5)
+		}
+	})
+
+	t.Run("holt winters without period follows the trend", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastModelHoltWinters, "2m", "", "", 0, 0, 0, nil, ForecastOutputValue)
+		require.NoError(t, err)
+
+		n := execute(t, cmd, newSeries(1, 2, 3, 4, 5, 6)).(mathexp.Number)
+		assert.InDelta(t, 8, *n.GetFloat64Value(), 1e-9)
+	})
+
+	t.Run("not enough points returns a null value", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastModelLinear, "1h", "", "", 0, 0, 0, nil, ForecastOutputValue)
+		require.NoError(t, err)
+
+		n := execute(t, cmd, newSeries(1)).(mathexp.Number)
+		assert.Nil(t, n.GetFloat64Value())
+	})
+
+	t.Run("no data returns no data", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastModelLinear, "1h", "", "", 0, 0, 0, nil, "")
+		require.NoError(t, err)
+
+		v := execute(t, cmd, mathexp.NoData{}.New())
+		assert.IsType(t, mathexp.NoData{}, v)
+	})
+}
@@ -77,7 +77,7 @@ func (dp *DataPipeline) execute(c context.Context, now time.Time, s *Service) (m
 		executeDSNodesGrouped(c, now, vars, s, dsNodes)
 	}
 
-	s.allowLongFrames = hasSqlExpression(*dp)
+	s.allowLongFrames = hasTableExpression(*dp)
 
 	for _, node := range *dp {
 		if groupByDSFlag && node.NodeType() == TypeDatasourceNode {
@@ -371,12 +371,14 @@ func GetCommandsFromPipeline[T Command](pipeline DataPipeline) []T {
 	return results
 }
 
-func hasSqlExpression(dp DataPipeline) bool {
+// hasTableExpression returns true if the pipeline has a command that can read tables, so data source
+// responses in the long format are kept as tables.
+func hasTableExpression(dp DataPipeline) bool {
 	for _, node := range dp {
 		if node.NodeType() == TypeCMDNode {
 			cmdNode := node.(*CMDNode)
-			_, ok := cmdNode.Command.(*SQLCommand)
-			if ok {
+			switch cmdNode.Command.(type) {
+			case *SQLCommand, *JoinCommand, *UnionCommand:
 				return true
 			}
 		}
@@ -0,0 +1,664 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+// How the inputs of a join are matched
+// +enum
+type JoinMode string
+
+const (
+	// Match rows on the values of the listed fields
+	JoinModeField JoinMode = "field"
+
+	// Match rows on their labels, by default the label keys shared by all inputs
+	JoinModeLabels JoinMode = "labels"
+)
+
+// Which rows are kept by a join
+// +enum
+type JoinType string
+
+const (
+	// Only rows with a match in every input
+	JoinTypeInner JoinType = "inner"
+
+	// All rows of the first input
+	JoinTypeLeft JoinType = "left"
+
+	// All rows of every input
+	JoinTypeOuter JoinType = "outer"
+)
+
+// The format of the result of a join or union
+// +enum
+type CombineFormat string
+
+const (
+	// A single frame
+	CombineFormatTable CombineFormat = "table"
+
+	// Numbers, or series if there is a time field, labeled with the string fields. Requires exactly one numeric field
+	CombineFormatLabeled CombineFormat = "labeled"
+)
+
+// JoinCommand is an expression command that joins the results of several queries or expressions into a single frame.
+type JoinCommand struct {
+	RefID      string
+	VarsToJoin []string
+	Mode       JoinMode
+	// Fields are the names of the fields, or label keys, rows are matched on.
+	Fields []string
+	How    JoinType
+	Format CombineFormat
+}
+
+// NewJoinCommand creates a new JoinCommand.
+func NewJoinCommand(refID string, varsToJoin []string, mode JoinMode, fields []string, how JoinType, format CombineFormat) (*JoinCommand, error) {
+	cmd := &JoinCommand{
+		RefID:      refID,
+		VarsToJoin: varsToJoin,
+		Mode:       mode,
+		Fields:     fields,
+		How:        how,
+		Format:     format,
+	}
+
+	if len(varsToJoin) < 2 {
+		return nil, fmt.Errorf("join requires at least two expressions, got %d", len(varsToJoin))
+	}
+
+	switch mode {
+	case "":
+		cmd.Mode = JoinModeLabels
+	case JoinModeLabels:
+	case JoinModeField:
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("join by field requires at least one field")
+		}
+	default:
+		return nil, fmt.Errorf("expected join mode to be one of [%s, %s], got %s", JoinModeField, JoinModeLabels, mode)
+	}
+
+	switch how {
+	case "":
+		cmd.How = JoinTypeInner
+	case JoinTypeInner, JoinTypeLeft, JoinTypeOuter:
+	default:
+		return nil, fmt.Errorf("expected join type to be one of [%s, %s, %s], got %s", JoinTypeInner, JoinTypeLeft, JoinTypeOuter, how)
+	}
+
+	var err error
+	if cmd.Format, err = combineFormat(format); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+func combineFormat(format CombineFormat) (CombineFormat, error) {
+	switch format {
+	case "":
+		return CombineFormatTable, nil
+	case CombineFormatTable, CombineFormatLabeled:
+		return format, nil
+	default:
+		return "", fmt.Errorf("expected format to be one of [%s, %s], got %s", CombineFormatTable, CombineFormatLabeled, format)
+	}
+}
+
+// getReferenceVars returns the variable names of the expressions.
+func getReferenceVars(expressions []string, refID string) ([]string, error) {
+	vars := make([]string, 0, len(expressions))
+	for _, exp := range expressions {
+		v, err := getReferenceVar(exp, refID)
+		if err != nil {
+			return nil, err
+		}
+		vars = append(vars, v)
+	}
+	return vars, nil
+}
+
+// UnmarshalJoinCommand creates a JoinCommand from Grafana's frontend query.
+func UnmarshalJoinCommand(rn *rawNode) (*JoinCommand, error) {
+	q := JoinQuery{}
+	if err := json.Unmarshal(rn.QueryRaw, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse the join command: %w", err)
+	}
+	vars, err := getReferenceVars(q.Expressions, rn.RefID)
+	if err != nil {
+		return nil, err
+	}
+	return NewJoinCommand(rn.RefID, vars, q.Mode, q.Fields, q.How, q.Format)
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (jc *JoinCommand) NeedsVars() []string {
+	return jc.VarsToJoin
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute.
+func (jc *JoinCommand) Execute(ctx context.Context, _ time.Time, vars mathexp.Vars, tracer tracing.Tracer) (mathexp.Results, error) {
+	_, span := tracer.Start(ctx, "SSE.ExecuteJoin")
+	defer span.End()
+
+	tables, err := tablesFromVars(jc.VarsToJoin, vars)
+	if err != nil {
+		return mathexp.Results{}, err
+	}
+
+	keys := jc.Fields
+	if jc.Mode == JoinModeLabels && len(keys) == 0 {
+		keys = sharedLabels(tables)
+		if len(keys) == 0 {
+			return mathexp.Results{}, fmt.Errorf("the inputs of the join have no labels in common")
+		}
+	}
+
+	res, err := joinTables(tables, keys, jc.How)
+	if err != nil {
+		return mathexp.Results{}, err
+	}
+	return res.results(jc.RefID, jc.Format)
+}
+
+func (jc *JoinCommand) Type() string {
+	return TypeJoin.String()
+}
+
+// UnionCommand is an expression command that concatenates the results of several queries or expressions into a single frame.
+type UnionCommand struct {
+	RefID       string
+	VarsToUnion []string
+	Format      CombineFormat
+}
+
+// NewUnionCommand creates a new UnionCommand.
+func NewUnionCommand(refID string, varsToUnion []string, format CombineFormat) (*UnionCommand, error) {
+	if len(varsToUnion) < 2 {
+		return nil, fmt.Errorf("union requires at least two expressions, got %d", len(varsToUnion))
+	}
+	format, err := combineFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	return &UnionCommand{
+		RefID:       refID,
+		VarsToUnion: varsToUnion,
+		Format:      format,
+	}, nil
+}
+
+// UnmarshalUnionCommand creates a UnionCommand from Grafana's frontend query.
+func UnmarshalUnionCommand(rn *rawNode) (*UnionCommand, error) {
+	q := UnionQuery{}
+	if err := json.Unmarshal(rn.QueryRaw, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse the union command: %w", err)
+	}
+	vars, err := getReferenceVars(q.Expressions, rn.RefID)
+	if err != nil {
+		return nil, err
+	}
+	return NewUnionCommand(rn.RefID, vars, q.Format)
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (uc *UnionCommand) NeedsVars() []string {
+	return uc.VarsToUnion
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute.
+func (uc *UnionCommand) Execute(ctx context.Context, _ time.Time, vars mathexp.Vars, tracer tracing.Tracer) (mathexp.Results, error) {
+	_, span := tracer.Start(ctx, "SSE.ExecuteUnion")
+	defer span.End()
+
+	tables, err := tablesFromVars(uc.VarsToUnion, vars)
+	if err != nil {
+		return mathexp.Results{}, err
+	}
+
+	res := &table{}
+	for _, t := range tables {
+		if err := res.appendTable(t); err != nil {
+			return mathexp.Results{}, err
+		}
+	}
+	return res.results(uc.RefID, uc.Format)
+}
+
+func (uc *UnionCommand) Type() string {
+	return TypeUnion.String()
+}
+
+type tableColumn struct {
+	name   string
+	typ    data.FieldType
+	label  bool
+	values []any
+}
+
+// table is a row oriented copy of the results of a variable, with the labels of the fields as string columns.
+type table struct {
+	refID   string
+	columns []*tableColumn
+	rows    int
+}
+
+func tablesFromVars(refIDs []string, vars mathexp.Vars) ([]*table, error) {
+	tables := make([]*table, 0, len(refIDs))
+	for _, refID := range refIDs {
+		t := &table{refID: refID}
+		for _, val := range vars[refID].Values {
+			if err := t.appendValue(refID, val); err != nil {
+				return nil, err
+			}
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+func (t *table) column(name string, typ data.FieldType, label bool) (*tableColumn, error) {
+	typ = typ.NullableType()
+	for _, c := range t.columns {
+		if c.name != name {
+			continue
+		}
+		if c.typ != typ {
+			return nil, fmt.Errorf("field %q has different types %s and %s", name, c.typ, typ)
+		}
+		c.label = c.label || label
+		return c, nil
+	}
+	c := &tableColumn{name: name, typ: typ, label: label, values: make([]any, t.rows)}
+	t.columns = append(t.columns, c)
+	return c, nil
+}
+
+// padColumns fills the columns without a value up to the number of rows with nulls.
+func (t *table) padColumns() {
+	for _, c := range t.columns {
+		for len(c.values) < t.rows {
+			c.values = append(c.values, nil)
+		}
+	}
+}
+
+// appendValue appends the rows of the value to the table. The value field of series and numbers is named after the refID.
+func (t *table) appendValue(refID string, val mathexp.Value) error {
+	switch val.(type) {
+	case mathexp.NoData:
+		return nil
+	case mathexp.Series, mathexp.Number:
+		frame := *val.AsDataFrame()
+		frame.Fields = append([]*data.Field(nil), frame.Fields...)
+		valueField := *frame.Fields[len(frame.Fields)-1]
+		valueField.Name = refID
+		frame.Fields[len(frame.Fields)-1] = &valueField
+		return t.appendFrame(&frame)
+	default:
+		return t.appendFrame(val.AsDataFrame())
+	}
+}
+
+func (t *table) appendFrame(frame *data.Frame) error {
+	rows := frame.Rows()
+	for _, field := range frame.Fields {
+		c, err := t.column(field.Name, field.Type(), false)
+		if err != nil {
+			return err
+		}
+		if len(c.values) > t.rows {
+			return fmt.Errorf("duplicate field %q", field.Name)
+		}
+		for i := 0; i < rows; i++ {
+			v, ok := field.ConcreteAt(i)
+			if !ok {
+				v = nil
+			}
+			c.values = append(c.values, v)
+		}
+		for k, v := range field.Labels {
+			c, err := t.column(k, data.FieldTypeNullableString, true)
+			if err != nil {
+				return err
+			}
+			if len(c.values) > t.rows {
+				// the label is also a field, or a label of another field of the frame
+				continue
+			}
+			for i := 0; i < rows; i++ {
+				c.values = append(c.values, v)
+			}
+		}
+	}
+	t.rows += rows
+	t.padColumns()
+	return nil
+}
+
+func (t *table) appendTable(other *table) error {
+	for _, oc := range other.columns {
+		c, err := t.column(oc.name, oc.typ, oc.label)
+		if err != nil {
+			return fmt.Errorf("%s: %w", other.refID, err)
+		}
+		c.values = append(c.values, oc.values...)
+	}
+	t.rows += other.rows
+	t.padColumns()
+	return nil
+}
+
+func (t *table) columnByName(name string) *tableColumn {
+	for _, c := range t.columns {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// sharedLabels returns the sorted label keys that are columns of every table.
+func sharedLabels(tables []*table) []string {
+	var keys []string
+	for _, c := range tables[0].columns {
+		if !c.label {
+			continue
+		}
+		shared := true
+		for _, t := range tables[1:] {
+			if oc := t.columnByName(c.name); oc == nil || !oc.label {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			keys = append(keys, c.name)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// joinTables joins the tables one after the other on the key columns. The key columns are
+// only returned once, and other columns that exist in several tables are prefixed with the
+// refID of their table.
+func joinTables(tables []*table, keys []string, how JoinType) (*table, error) {
+	counts := map[string]int{}
+	for _, t := range tables {
+		for _, k := range keys {
+			c := t.columnByName(k)
+			if c == nil {
+				return nil, fmt.Errorf("join field %q not found in %s", k, t.refID)
+			}
+			if first := tables[0].columnByName(k); c.typ != first.typ {
+				return nil, fmt.Errorf("join field %q has type %s in %s and %s in %s", k, first.typ, tables[0].refID, c.typ, t.refID)
+			}
+		}
+		for _, c := range t.columns {
+			counts[c.name]++
+		}
+	}
+	isKey := map[string]bool{}
+	for _, k := range keys {
+		isKey[k] = true
+	}
+
+	res := &table{}
+	for _, k := range keys {
+		c := tables[0].columnByName(k)
+		if _, err := res.column(c.name, c.typ, c.label); err != nil {
+			return nil, err
+		}
+	}
+	// offsets[i] is the index of the first non key column of table i in res
+	offsets := make([]int, len(tables))
+	for i, t := range tables {
+		offsets[i] = len(res.columns)
+		for _, c := range t.columns {
+			if isKey[c.name] {
+				continue
+			}
+			name := c.name
+			if counts[name] > 1 {
+				name = t.refID + "." + name
+			}
+			if _, err := res.column(name, c.typ, c.label); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// rows of the result, built by adding the tables one after the other
+	var rows [][]any
+	first := tables[0]
+	for r := 0; r < first.rows; r++ {
+		row := make([]any, len(res.columns))
+		first.fillRow(row, r, keys, isKey, offsets[0])
+		rows = append(rows, row)
+	}
+
+	for i, t := range tables[1:] {
+		offset := offsets[i+1]
+		index := map[string][]int{}
+		for r := 0; r < t.rows; r++ {
+			if k, ok := t.key(r, keys); ok {
+				index[k] = append(index[k], r)
+			}
+		}
+
+		matched := make([]bool, t.rows)
+		var joined [][]any
+		for _, row := range rows {
+			k, ok := rowKey(row[:len(keys)])
+			matches := index[k]
+			if !ok || len(matches) == 0 {
+				if how != JoinTypeInner {
+					joined = append(joined, row)
+				}
+				continue
+			}
+			for _, r := range matches {
+				matched[r] = true
+				out := append([]any(nil), row...)
+				t.fillRow(out, r, keys, isKey, offset)
+				joined = append(joined, out)
+			}
+		}
+		if how == JoinTypeOuter {
+			for r := 0; r < t.rows; r++ {
+				if !matched[r] {
+					row := make([]any, len(res.columns))
+					t.fillRow(row, r, keys, isKey, offset)
+					joined = append(joined, row)
+				}
+			}
+		}
+		rows = joined
+	}
+
+	for _, row := range rows {
+		for i, c := range res.columns {
+			c.values = append(c.values, row[i])
+		}
+	}
+	res.rows = len(rows)
+	return res, nil
+}
+
+// fillRow sets the key values, and the other values starting at offset, of the row from row r of the table.
+func (t *table) fillRow(row []any, r int, keys []string, isKey map[string]bool, offset int) {
+	for i, k := range keys {
+		if v := t.columnByName(k).values[r]; v != nil {
+			row[i] = v
+		}
+	}
+	idx := offset
+	for _, c := range t.columns {
+		if isKey[c.name] {
+			continue
+		}
+		row[idx] = c.values[r]
+		idx++
+	}
+}
+
+func (t *table) key(r int, keys []string) (string, bool) {
+	values := make([]any, len(keys))
+	for i, k := range keys {
+		values[i] = t.columnByName(k).values[r]
+	}
+	return rowKey(values)
+}
+
+// rowKey returns a string identifying the values. Rows with a null key value never match.
+func rowKey(values []any) (string, bool) {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		if v == nil {
+			return "", false
+		}
+		if t, ok := v.(time.Time); ok {
+			v = t.UnixNano()
+		}
+		parts[i] = fmt.Sprint(v)
+	}
+	return strings.Join(parts, "\x00"), true
+}
+
+func (t *table) frame(refID string) *data.Frame {
+	frame := data.NewFrame(refID)
+	frame.RefID = refID
+	for _, c := range t.columns {
+		field := data.NewFieldFromFieldType(c.typ, t.rows)
+		field.Name = c.name
+		for i, v := range c.values {
+			if v != nil {
+				field.SetConcrete(i, v)
+			}
+		}
+		frame.Fields = append(frame.Fields, field)
+	}
+	return frame
+}
+
+// results returns the table in the requested format.
+func (t *table) results(refID string, format CombineFormat) (mathexp.Results, error) {
+	frame := t.frame(refID)
+	if t.rows == 0 {
+		return mathexp.Results{Values: mathexp.Values{mathexp.NoData{Frame: frame}}}, nil
+	}
+	if format != CombineFormatLabeled {
+		return mathexp.Results{Values: mathexp.Values{mathexp.TableData{Frame: frame}}}, nil
+	}
+	vals, err := labeledValues(refID, frame)
+	if err != nil {
+		return mathexp.Results{}, err
+	}
+	return mathexp.Results{Values: vals}, nil
+}
+
+// labeledValues converts a frame with a single numeric field into numbers, or series if it has a time field,
+// labeled with the values of the string fields.
+func labeledValues(refID string, frame *data.Frame) (mathexp.Values, error) {
+	valueIdx, timeIdx := -1, -1
+	var labelIdx []int
+	for i, field := range frame.Fields {
+		ft := field.Type()
+		switch {
+		case ft.Numeric():
+			if valueIdx >= 0 {
+				return nil, fmt.Errorf("the labeled format requires exactly one numeric field, got %s and %s", frame.Fields[valueIdx].Name, field.Name)
+			}
+			valueIdx = i
+		case ft.Time():
+			if timeIdx < 0 {
+				timeIdx = i
+			}
+		case ft.NonNullableType() == data.FieldTypeString:
+			labelIdx = append(labelIdx, i)
+		default:
+			return nil, fmt.Errorf("the labeled format does not support field %s of type %s", field.Name, ft)
+		}
+	}
+	if valueIdx < 0 {
+		return nil, fmt.Errorf("the labeled format requires exactly one numeric field, got none")
+	}
+
+	labelsAt := func(r int) data.Labels {
+		labels := data.Labels{}
+		for _, idx := range labelIdx {
+			if v, ok := frame.ConcreteAt(idx, r); ok {
+				labels[frame.Fields[idx].Name] = v.(string)
+			}
+		}
+		return labels
+	}
+
+	vals := mathexp.Values{}
+	if timeIdx < 0 {
+		for r := 0; r < frame.Rows(); r++ {
+			n := mathexp.NewNumber(refID, labelsAt(r))
+			if _, ok := frame.ConcreteAt(valueIdx, r); ok {
+				v, err := frame.FloatAt(valueIdx, r)
+				if err != nil {
+					return nil, err
+				}
+				n.SetValue(&v)
+			}
+			vals = append(vals, n)
+		}
+		return vals, nil
+	}
+
+	var order []string
+	groups := map[string][]point{}
+	groupLabels := map[string]data.Labels{}
+	for r := 0; r < frame.Rows(); r++ {
+		ts, ok := frame.ConcreteAt(timeIdx, r)
+		if !ok {
+			continue
+		}
+		labels := labelsAt(r)
+		key := labels.String()
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+			groupLabels[key] = labels
+		}
+		var v *float64
+		if _, ok := frame.ConcreteAt(valueIdx, r); ok {
+			f, err := frame.FloatAt(valueIdx, r)
+			if err != nil {
+				return nil, err
+			}
+			v = &f
+		}
+		groups[key] = append(groups[key], point{t: ts.(time.Time), v: v})
+	}
+	for _, key := range order {
+		points := groups[key]
+		sort.SliceStable(points, func(i, j int) bool { return points[i].t.Before(points[j].t) })
+		s := mathexp.NewSeries(refID, groupLabels[key], len(points))
+		for i, p := range points {
+			s.SetPoint(i, p.t, p.v)
+		}
+		vals = append(vals, s)
+	}
+	return vals, nil
+}
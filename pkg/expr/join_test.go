@@ -0,0 +1,236 @@
+package expr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestNewJoinCommand(t *testing.T) {
+	cases := []struct {
+		name      string
+		vars      []string
+		mode      JoinMode
+		fields    []string
+		how       JoinType
+		format    CombineFormat
+		expectErr string
+	}{
+		{name: "labels with defaults", vars: []string{"A", "B"}},
+		{name: "field", vars: []string{"A", "B", "C"}, mode: JoinModeField, fields: []string{"host"}, how: JoinTypeOuter, format: CombineFormatLabeled},
+		{name: "single input", vars: []string{"A"}, expectErr: "at least two"},
+		{name: "field without fields", vars: []string{"A", "B"}, mode: JoinModeField, expectErr: "requires at least one field"},
+		{name: "unknown mode", vars: []string{"A", "B"}, mode: "index", expectErr: "expected join mode"},
+		{name: "unknown type", vars: []string{"A", "B"}, how: "right", expectErr: "expected join type"},
+		{name: "unknown format", vars: []string{"A", "B"}, format: "wide", expectErr: "expected format"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd, err := NewJoinCommand("X", tc.vars, tc.mode, tc.fields, tc.how, tc.format)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.vars, cmd.NeedsVars())
+			assert.NotEmpty(t, cmd.Mode)
+			assert.NotEmpty(t, cmd.How)
+			assert.NotEmpty(t, cmd.Format)
+		})
+	}
+}
+
+func TestUnmarshalJoinAndUnionCommands(t *testing.T) {
+	join, err := UnmarshalJoinCommand(&rawNode{
+		RefID:    "C",
+		QueryRaw: []byte(`{"type":"join","expressions":["$A","B"],"mode":"field","fields":["host"],"how":"left"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "B"}, join.NeedsVars())
+	assert.Equal(t, []string{"host"}, join.Fields)
+	assert.Equal(t, JoinTypeLeft, join.How)
+
+	union, err := UnmarshalUnionCommand(&rawNode{
+		RefID:    "C",
+		QueryRaw: []byte(`{"type":"union","expressions":["$A","$B"],"format":"labeled"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "B"}, union.NeedsVars())
+	assert.Equal(t, CombineFormatLabeled, union.Format)
+}
+
+func TestJoinExecute(t *testing.T) {
+	number := func(refID string, labels data.Labels, v float64) mathexp.Number {
+		n := mathexp.NewNumber(refID, labels)
+		n.SetValue(&v)
+		return n
+	}
+	inventory := data.NewFrame("",
+		data.NewField("host", nil, []string{"a", "b", "c"}),
+		data.NewField("team", nil, []string{"db", "web", "web"}),
+	)
+	vars := mathexp.Vars{
+		"A": {Values: mathexp.Values{
+			number("A", data.Labels{"host": "a"}, 1),
+			number("A", data.Labels{"host": "b"}, 2),
+			number("A", data.Labels{"host": "d"}, 4),
+		}},
+		"B": {Values: mathexp.Values{mathexp.TableData{Frame: inventory}}},
+	}
+	execute := func(t *testing.T, how JoinType, format CombineFormat) mathexp.Results {
+		t.Helper()
+		cmd, err := NewJoinCommand("C", []string{"A", "B"}, JoinModeField, []string{"host"}, how, format)
+		require.NoError(t, err)
+		res, err := cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		return res
+	}
+	column := func(frame *data.Frame, name string) []any {
+		field, idx := frame.FieldByName(name)
+		require.GreaterOrEqual(t, idx, 0, name)
+		values := make([]any, field.Len())
+		for i := range values {
+			if v, ok := field.ConcreteAt(i); ok {
+				values[i] = v
+			}
+		}
+		return values
+	}
+
+	t.Run("inner join by field", func(t *testing.T) {
+		res := execute(t, JoinTypeInner, "")
+		require.Len(t, res.Values, 1)
+		frame := res.Values[0].AsDataFrame()
+		assert.Equal(t, "C", frame.RefID)
+		assert.Equal(t, []any{"a", "b"}, column(frame, "host"))
+		assert.Equal(t, []any{1.0, 2.0}, column(frame, "A"))
+		assert.Equal(t, []any{"db", "web"}, column(frame, "team"))
+	})
+
+	t.Run("left join keeps unmatched rows of the first input", func(t *testing.T) {
+		frame := execute(t, JoinTypeLeft, "").Values[0].AsDataFrame()
+		assert.Equal(t, []any{"a", "b", "d"}, column(frame, "host"))
+		assert.Equal(t, []any{"db", "web", nil}, column(frame, "team"))
+	})
+
+	t.Run("outer join keeps all rows", func(t *testing.T) {
+		frame := execute(t, JoinTypeOuter, "").Values[0].AsDataFrame()
+		assert.Equal(t, []any{"a", "b", "d", "c"}, column(frame, "host"))
+		assert.Equal(t, []any{1.0, 2.0, 4.0, nil}, column(frame, "A"))
+	})
+
+	t.Run("labeled format returns labeled numbers", func(t *testing.T) {
+		res := execute(t, JoinTypeInner, CombineFormatLabeled)
+		require.Len(t, res.Values, 2)
+		assert.Equal(t, data.Labels{"host": "a", "team": "db"}, res.Values[0].GetLabels())
+		assert.Equal(t, 1.0, *res.Values[0].(mathexp.Number).GetFloat64Value())
+		assert.Equal(t, data.Labels{"host": "b", "team": "web"}, res.Values[1].GetLabels())
+	})
+
+	t.Run("join by shared labels prefixes duplicate fields", func(t *testing.T) {
+		series := func(refID, host string, values ...float64) mathexp.Series {
+			s := mathexp.NewSeries(refID, data.Labels{"host": host}, len(values))
+			for i, v := range values {
+				s.SetPoint(i, time.Unix(int64(i*60), 0), util.Pointer(v))
+			}
+			return s
+		}
+		cmd, err := NewJoinCommand("C", []string{"A", "B"}, JoinModeLabels, nil, JoinTypeInner, "")
+		require.NoError(t, err)
+		res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+			"A": {Values: mathexp.Values{series("A", "a", 1, 2)}},
+			"B": {Values: mathexp.Values{series("B", "a", 10), series("B", "b", 20)}},
+		}, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		frame := res.Values[0].AsDataFrame()
+		assert.Equal(t, []any{"a", "a"}, column(frame, "host"))
+		assert.Equal(t, []any{1.0, 2.0}, column(frame, "A"))
+		assert.Equal(t, []any{10.0, 10.0}, column(frame, "B"))
+		assert.Len(t, column(frame, "A.Time"), 2)
+		assert.Len(t, column(frame, "B.Time"), 2)
+	})
+
+	t.Run("missing join field", func(t *testing.T) {
+		cmd, err := NewJoinCommand("C", []string{"A", "B"}, JoinModeField, []string{"region"}, JoinTypeInner, "")
+		require.NoError(t, err)
+		_, err = cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
+		require.ErrorContains(t, err, `join field "region" not found in A`)
+	})
+
+	t.Run("no match returns no data", func(t *testing.T) {
+		cmd, err := NewJoinCommand("C", []string{"A", "B"}, JoinModeField, []string{"host"}, JoinTypeInner, "")
+		require.NoError(t, err)
+		res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+			"A": {Values: mathexp.Values{number("A", data.Labels{"host": "z"}, 1)}},
+			"B": vars["B"],
+		}, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		assert.True(t, res.IsNoData())
+	})
+}
+
+func TestUnionExecute(t *testing.T) {
+	start := time.Unix(0, 0)
+	series := func(refID, host string, values ...float64) mathexp.Series {
+		s := mathexp.NewSeries(refID, data.Labels{"host": host}, len(values))
+		for i, v := range values {
+			s.SetPoint(i, start.Add(time.Duration(i)*time.Minute), util.Pointer(v))
+		}
+		return s
+	}
+	vars := mathexp.Vars{
+		"A": {Values: mathexp.Values{series("A", "a", 1, 2)}},
+		"B": {Values: mathexp.Values{series("B", "b", 3)}},
+	}
+
+	t.Run("table", func(t *testing.T) {
+		cmd, err := NewUnionCommand("C", []string{"A", "B"}, "")
+		require.NoError(t, err)
+		res, err := cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		frame := res.Values[0].AsDataFrame()
+		require.Equal(t, 3, frame.Rows())
+		names := make([]string, 0, len(frame.Fields))
+		for _, f := range frame.Fields {
+			names = append(names, f.Name)
+		}
+		assert.Equal(t, []string{"Time", "A", "host", "B"}, names)
+	})
+
+	t.Run("labeled with a single numeric field returns series", func(t *testing.T) {
+		cmd, err := NewUnionCommand("C", []string{"A", "B"}, CombineFormatLabeled)
+		require.NoError(t, err)
+		// the value field of a series is named after its refID, so B is a table with the same fields as A
+		vars := mathexp.Vars{
+			"A": {Values: mathexp.Values{series("A", "a", 1, 2)}},
+			"B": {Values: mathexp.Values{mathexp.TableData{Frame: data.NewFrame("",
+				data.NewField("Time", nil, []time.Time{start}),
+				data.NewField("A", nil, []*float64{util.Pointer(3.0)}),
+				data.NewField("host", nil, []*string{util.Pointer("b")}),
+			)}}},
+		}
+		res, err := cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Len(t, res.Values, 2)
+		s := res.Values[0].(mathexp.Series)
+		assert.Equal(t, data.Labels{"host": "a"}, s.GetLabels())
+		assert.Equal(t, 2, s.Len())
+		assert.Equal(t, data.Labels{"host": "b"}, res.Values[1].GetLabels())
+	})
+
+	t.Run("labeled with several numeric fields fails", func(t *testing.T) {
+		cmd, err := NewUnionCommand("C", []string{"A", "B"}, CombineFormatLabeled)
+		require.NoError(t, err)
+		_, err = cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
+		require.ErrorContains(t, err, "exactly one numeric field")
+	})
+}
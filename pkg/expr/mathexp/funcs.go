@@ -1,7 +1,11 @@
 package mathexp
 
 import (
+	"fmt"
 	"math"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
 
 	"github.com/grafana/grafana/pkg/expr/mathexp/parse"
 )
@@ -68,6 +72,67 @@ var builtins = map[string]parse.Func{
 		VariantReturn: true,
 		F:             floor,
 	},
+	"sqrt": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet},
+		VariantReturn: true,
+		F:             sqrt,
+	},
+	"exp": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet},
+		VariantReturn: true,
+		F:             exp,
+	},
+	"pow": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet, parse.TypeScalar},
+		VariantReturn: true,
+		F:             pow,
+	},
+	"clamp": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet, parse.TypeScalar, parse.TypeScalar},
+		VariantReturn: true,
+		F:             clamp,
+	},
+	"rate": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      rate,
+	},
+	"delta": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      delta,
+	},
+	"timeshift": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet, parse.TypeString},
+		Return: parse.TypeSeriesSet,
+		F:      timeshift,
+		Check:  checkDurationArg(1),
+	},
+	"minute": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      perTimeFunc("minute", func(t time.Time) int { return t.Minute() }),
+	},
+	"hour": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      perTimeFunc("hour", func(t time.Time) int { return t.Hour() }),
+	},
+	"day_of_week": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      perTimeFunc("day_of_week", func(t time.Time) int { return int(t.Weekday()) }),
+	},
+	"day_of_month": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      perTimeFunc("day_of_month", func(t time.Time) int { return t.Day() }),
+	},
+	"month": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      perTimeFunc("month", func(t time.Time) int { return int(t.Month()) }),
+	},
 }
 
 // abs returns the absolute value for each result in NumberSet, SeriesSet, or Scalar
@@ -307,3 +372,236 @@ func floor(e *State, varSet Results) (Results, error) {
 	}
 	return newRes, nil
 }
+
+// sqrt returns the square root of each result in NumberSet, SeriesSet, or Scalar
+func sqrt(e *State, varSet Results) (Results, error) {
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		newVal, err := perFloat(e, res, math.Sqrt)
+		if err != nil {
+			return newRes, err
+		}
+		newRes.Values = append(newRes.Values, newVal)
+	}
+	return newRes, nil
+}
+
+// exp returns e raised to the power of each result in NumberSet, SeriesSet, or Scalar
+func exp(e *State, varSet Results) (Results, error) {
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		newVal, err := perFloat(e, res, math.Exp)
+		if err != nil {
+			return newRes, err
+		}
+		newRes.Values = append(newRes.Values, newVal)
+	}
+	return newRes, nil
+}
+
+// pow raises each result in NumberSet, SeriesSet, or Scalar to the power of the scalar exponent.
+// If the exponent is null, NaN is returned for each value.
+func pow(e *State, varSet Results, exponent Results) (Results, error) {
+	p, err := scalarArg("pow", exponent)
+	if err != nil {
+		return Results{}, err
+	}
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		newVal, err := perFloat(e, res, func(f float64) float64 {
+			if p == nil {
+				return math.NaN()
+			}
+			return math.Pow(f, *p)
+		})
+		if err != nil {
+			return newRes, err
+		}
+		newRes.Values = append(newRes.Values, newVal)
+	}
+	return newRes, nil
+}
+
+// clamp limits each result in NumberSet, SeriesSet, or Scalar to the range between the min and max scalars.
+// If min is greater than max, or either bound is null, NaN is returned for each value.
+func clamp(e *State, varSet Results, minArg Results, maxArg Results) (Results, error) {
+	lower, err := scalarArg("clamp", minArg)
+	if err != nil {
+		return Results{}, err
+	}
+	upper, err := scalarArg("clamp", maxArg)
+	if err != nil {
+		return Results{}, err
+	}
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		newVal, err := perFloat(e, res, func(f float64) float64 {
+			if lower == nil || upper == nil || *lower > *upper {
+				return math.NaN()
+			}
+			return math.Max(*lower, math.Min(*upper, f))
+		})
+		if err != nil {
+			return newRes, err
+		}
+		newRes.Values = append(newRes.Values, newVal)
+	}
+	return newRes, nil
+}
+
+// rate returns the per-second rate of increase between consecutive points of each series.
+// A decrease in value is treated as a counter reset. The first point, and any point without
+// a previous non-null value, is null.
+func rate(e *State, varSet Results) (Results, error) {
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		newVal, err := perSeries(e, "rate", res, func(prevT, t time.Time, prev, cur float64) *float64 {
+			dt := t.Sub(prevT).Seconds()
+			if dt <= 0 {
+				return nil
+			}
+			increase := cur - prev
+			if increase < 0 {
+				increase = cur
+			}
+			r := increase / dt
+			return &r
+		})
+		if err != nil {
+			return newRes, err
+		}
+		newRes.Values = append(newRes.Values, newVal)
+	}
+	return newRes, nil
+}
+
+// delta returns the difference between consecutive points of each series.
+// The first point, and any point without a previous non-null value, is null.
+func delta(e *State, varSet Results) (Results, error) {
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		newVal, err := perSeries(e, "delta", res, func(_, _ time.Time, prev, cur float64) *float64 {
+			d := cur - prev
+			return &d
+		})
+		if err != nil {
+			return newRes, err
+		}
+		newRes.Values = append(newRes.Values, newVal)
+	}
+	return newRes, nil
+}
+
+// timeshift moves the timestamps of each series forward by the given duration, e.g. "1h" or "1d".
+// A negative duration moves the timestamps back.
+func timeshift(e *State, varSet Results, rawDuration string) (Results, error) {
+	d, err := gtime.ParseDuration(rawDuration)
+	if err != nil {
+		return Results{}, fmt.Errorf("timeshift: invalid duration %q: %w", rawDuration, err)
+	}
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		switch res.Type() {
+		case parse.TypeSeriesSet:
+			resSeries := res.(Series)
+			newSeries := NewSeries(e.RefID, resSeries.GetLabels(), resSeries.Len())
+			for i := 0; i < resSeries.Len(); i++ {
+				t, f := resSeries.GetPoint(i)
+				newSeries.SetPoint(i, t.Add(d), f)
+			}
+			newRes.Values = append(newRes.Values, newSeries)
+		case parse.TypeNoData:
+			newRes.Values = append(newRes.Values, NewNoData())
+		default:
+			return newRes, fmt.Errorf("timeshift: expected a series, got %v", res.Type())
+		}
+	}
+	return newRes, nil
+}
+
+// perTimeFunc returns a function that replaces the value of each point in a series with
+// timeF applied to the point's timestamp in UTC. Null values stay null.
+func perTimeFunc(name string, timeF func(t time.Time) int) func(e *State, varSet Results) (Results, error) {
+	return func(e *State, varSet Results) (Results, error) {
+		newRes := Results{}
+		for _, res := range varSet.Values {
+			switch res.Type() {
+			case parse.TypeSeriesSet:
+				resSeries := res.(Series)
+				newSeries := NewSeries(e.RefID, resSeries.GetLabels(), resSeries.Len())
+				for i := 0; i < resSeries.Len(); i++ {
+					t, f := resSeries.GetPoint(i)
+					var nF *float64
+					if f != nil {
+						v := float64(timeF(t.UTC()))
+						nF = &v
+					}
+					newSeries.SetPoint(i, t, nF)
+				}
+				newRes.Values = append(newRes.Values, newSeries)
+			case parse.TypeNoData:
+				newRes.Values = append(newRes.Values, NewNoData())
+			default:
+				return newRes, fmt.Errorf("%s: expected a series, got %v", name, res.Type())
+			}
+		}
+		return newRes, nil
+	}
+}
+
+// perSeries passes each pair of consecutive non-null points of a Series, sorted by time, to pointF.
+// The first point, and points with a null value, are null in the returned series.
+// Only series (and no data) are supported since the other value types have no timestamps.
+func perSeries(e *State, name string, val Value, pointF func(prevT, t time.Time, prev, cur float64) *float64) (Value, error) {
+	switch val.Type() {
+	case parse.TypeSeriesSet:
+		resSeries := val.(Series)
+		newSeries := NewSeries(e.RefID, resSeries.GetLabels(), resSeries.Len())
+		for i := 0; i < resSeries.Len(); i++ {
+			t, f := resSeries.GetPoint(i)
+			newSeries.SetPoint(i, t, f)
+		}
+		newSeries.SortByTime(false)
+
+		var prevT time.Time
+		var prev *float64
+		for i := 0; i < newSeries.Len(); i++ {
+			t, f := newSeries.GetPoint(i)
+			var nF *float64
+			if f != nil && prev != nil {
+				nF = pointF(prevT, t, *prev, *f)
+			}
+			newSeries.SetPoint(i, t, nF)
+			if f != nil {
+				prevT, prev = t, f
+			}
+		}
+		return newSeries, nil
+	case parse.TypeNoData:
+		return NewNoData(), nil
+	default:
+		return nil, fmt.Errorf("%s: expected a series, got %v", name, val.Type())
+	}
+}
+
+// scalarArg returns the value of a scalar function argument.
+func scalarArg(name string, arg Results) (*float64, error) {
+	if len(arg.Values) != 1 || arg.Values[0].Type() != parse.TypeScalar {
+		return nil, fmt.Errorf("%s: expected a scalar argument", name)
+	}
+	return arg.Values[0].(Scalar).GetFloat64Value(), nil
+}
+
+// checkDurationArg returns a parse check that validates the string argument at argIdx is a duration.
+func checkDurationArg(argIdx int) func(*parse.Tree, *parse.FuncNode) error {
+	return func(t *parse.Tree, f *parse.FuncNode) error {
+		arg, ok := f.Args[argIdx].(*parse.StringNode)
+		if !ok {
+			return fmt.Errorf("parse: expected a string duration for argument %v of %s", argIdx, f.Name)
+		}
+		if _, err := gtime.ParseDuration(arg.Text); err != nil {
+			return fmt.Errorf("parse: invalid duration %q for %s: %w", arg.Text, f.Name, err)
+		}
+		return nil
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/grafana/grafana/pkg/infra/tracing"
 	"github.com/stretchr/testify/require"
 )
@@ -140,3 +142,196 @@ func TestIsNumberFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestMathFuncs(t *testing.T) {
+	var tests = []struct {
+		name    string
+		expr    string
+		vars    Vars
+		results Results
+	}{
+		{
+			name:    "sqrt on scalar",
+			expr:    "sqrt(16)",
+			vars:    Vars{},
+			results: resultValuesNoErr(NewScalar("", float64Pointer(4))),
+		},
+		{
+			name: "exp on number",
+			expr: "exp($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(makeNumber("", nil, float64Pointer(0))),
+			},
+			results: resultValuesNoErr(makeNumber("", nil, float64Pointer(1))),
+		},
+		{
+			name: "pow on series",
+			expr: "pow($A, 2)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(5, 0), float64Pointer(3)},
+						tp{time.Unix(10, 0), nil}),
+				),
+			},
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(5, 0), float64Pointer(9)},
+					tp{time.Unix(10, 0), float64Pointer(math.NaN())}),
+			),
+		},
+		{
+			name: "clamp on series",
+			expr: "clamp($A, 0, 10)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(5, 0), float64Pointer(-3)},
+						tp{time.Unix(10, 0), float64Pointer(5)},
+						tp{time.Unix(15, 0), float64Pointer(30)}),
+				),
+			},
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(5, 0), float64Pointer(0)},
+					tp{time.Unix(10, 0), float64Pointer(5)},
+					tp{time.Unix(15, 0), float64Pointer(10)}),
+			),
+		},
+		{
+			name: "clamp with min greater than max returns NaN",
+			expr: "clamp($A, 10, 0)",
+			vars: Vars{
+				"A": resultValuesNoErr(makeNumber("", nil, float64Pointer(5))),
+			},
+			results: resultValuesNoErr(makeNumber("", nil, float64Pointer(math.NaN()))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(tt.expr)
+			require.NoError(t, err)
+			res, err := e.Execute("", tt.vars, tracing.InitializeTracerForTest())
+			require.NoError(t, err)
+			if diff := cmp.Diff(tt.results, res, data.FrameTestCompareOptions()...); diff != "" {
+				t.Errorf("Result mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSeriesFuncs(t *testing.T) {
+	var tests = []struct {
+		name      string
+		expr      string
+		vars      Vars
+		newErrIs  require.ErrorAssertionFunc
+		execErrIs require.ErrorAssertionFunc
+		results   Results
+	}{
+		{
+			name: "rate handles counter resets and nulls",
+			expr: "rate($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(10, 0), float64Pointer(20)},
+						tp{time.Unix(0, 0), float64Pointer(10)},
+						tp{time.Unix(20, 0), nil},
+						tp{time.Unix(30, 0), float64Pointer(40)},
+						tp{time.Unix(40, 0), float64Pointer(5)}),
+				),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(0, 0), nil},
+					tp{time.Unix(10, 0), float64Pointer(1)},
+					tp{time.Unix(20, 0), nil},
+					tp{time.Unix(30, 0), float64Pointer(1)},
+					tp{time.Unix(40, 0), float64Pointer(0.5)}),
+			),
+		},
+		{
+			name: "delta on series",
+			expr: "delta($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(0, 0), float64Pointer(10)},
+						tp{time.Unix(10, 0), float64Pointer(4)}),
+				),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(0, 0), nil},
+					tp{time.Unix(10, 0), float64Pointer(-6)}),
+			),
+		},
+		{
+			name: "rate on number should error",
+			expr: "rate($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(makeNumber("", nil, float64Pointer(5))),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.Error,
+		},
+		{
+			name: "hour and day_of_week use series timestamps",
+			expr: "hour($A) + day_of_week($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						// Thursday
+						tp{time.Date(2024, 8, 1, 13, 0, 0, 0, time.UTC), float64Pointer(1)},
+						tp{time.Date(2024, 8, 2, 14, 0, 0, 0, time.UTC), nil}),
+				),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Date(2024, 8, 1, 13, 0, 0, 0, time.UTC), float64Pointer(17)},
+					tp{time.Date(2024, 8, 2, 14, 0, 0, 0, time.UTC), nil}),
+			),
+		},
+		{
+			name: "timeshift moves timestamps",
+			expr: `timeshift($A, "1h")`,
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil, tp{time.Unix(0, 0), float64Pointer(1)}),
+				),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", nil, tp{time.Unix(3600, 0), float64Pointer(1)}),
+			),
+		},
+		{
+			name:     "timeshift with invalid duration should error",
+			expr:     `timeshift($A, "soon")`,
+			newErrIs: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(tt.expr)
+			tt.newErrIs(t, err)
+			if e != nil {
+				res, err := e.Execute("", tt.vars, tracing.InitializeTracerForTest())
+				tt.execErrIs(t, err)
+				if err == nil {
+					if diff := cmp.Diff(tt.results, res, data.FrameTestCompareOptions()...); diff != "" {
+						t.Errorf("Result mismatch (-want +got):\n%s", diff)
+					}
+				}
+			}
+		})
+	}
+}
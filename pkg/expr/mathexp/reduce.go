@@ -3,6 +3,9 @@ package mathexp
 import (
 	"fmt"
 	"math"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 )
@@ -20,11 +23,31 @@ const (
 	ReducerMax   ReducerID = "max"
 	ReducerCount ReducerID = "count"
 	ReducerLast  ReducerID = "last"
+
+	ReducerFirst        ReducerID = "first"
+	ReducerMedian       ReducerID = "median"
+	ReducerStdDev       ReducerID = "stddev"
+	ReducerVariance     ReducerID = "variance"
+	ReducerDiff         ReducerID = "diff"
+	ReducerPercentDiff  ReducerID = "percent_diff"
+	ReducerCountNonNull ReducerID = "count_non_null"
+	ReducerRange        ReducerID = "range"
+
+	ReducerP25 ReducerID = "p25"
+	ReducerP50 ReducerID = "p50"
+	ReducerP75 ReducerID = "p75"
+	ReducerP90 ReducerID = "p90"
+	ReducerP95 ReducerID = "p95"
+	ReducerP99 ReducerID = "p99"
 )
 
 // GetSupportedReduceFuncs returns collection of supported function names
 func GetSupportedReduceFuncs() []ReducerID {
-	return []ReducerID{ReducerSum, ReducerMean, ReducerMin, ReducerMax, ReducerCount, ReducerLast}
+	return []ReducerID{
+		ReducerSum, ReducerMean, ReducerMin, ReducerMax, ReducerCount, ReducerLast,
+		ReducerFirst, ReducerMedian, ReducerStdDev, ReducerVariance, ReducerDiff, ReducerPercentDiff, ReducerCountNonNull, ReducerRange,
+		ReducerP25, ReducerP50, ReducerP75, ReducerP90, ReducerP95, ReducerP99,
+	}
 }
 
 func Sum(fv *Float64Field) *float64 {
@@ -98,6 +121,132 @@ func Last(fv *Float64Field) *float64 {
 	return fv.GetValue(fv.Len() - 1)
 }
 
+func First(fv *Float64Field) *float64 {
+	var f float64
+	if fv.Len() == 0 {
+		f = math.NaN()
+		return &f
+	}
+	return fv.GetValue(0)
+}
+
+func Median(fv *Float64Field) *float64 {
+	return Percentile(50)(fv)
+}
+
+// Percentile returns a reducer that computes the p-th percentile of the values,
+// linearly interpolating between the closest ranks.
+func Percentile(p float64) ReducerFunc {
+	return func(fv *Float64Field) *float64 {
+		values, ok := sortedValues(fv)
+		if !ok || len(values) == 0 {
+			nan := math.NaN()
+			return &nan
+		}
+		rank := p / 100 * float64(len(values)-1)
+		lower := int(math.Floor(rank))
+		upper := int(math.Ceil(rank))
+		f := values[lower] + (values[upper]-values[lower])*(rank-float64(lower))
+		return &f
+	}
+}
+
+// Variance returns the population variance of the values.
+func Variance(fv *Float64Field) *float64 {
+	mean := Avg(fv)
+	if math.IsNaN(*mean) {
+		return mean
+	}
+	var sum float64
+	for i := 0; i < fv.Len(); i++ {
+		d := *fv.GetValue(i) - *mean
+		sum += d * d
+	}
+	f := sum / float64(fv.Len())
+	return &f
+}
+
+// StdDev returns the population standard deviation of the values.
+func StdDev(fv *Float64Field) *float64 {
+	f := math.Sqrt(*Variance(fv))
+	return &f
+}
+
+// Diff returns the difference between the last and the first value.
+func Diff(fv *Float64Field) *float64 {
+	return firstLast(fv, func(first, last float64) float64 {
+		return last - first
+	})
+}
+
+// PercentDiff returns the difference between the last and the first value as a percentage of the first value.
+func PercentDiff(fv *Float64Field) *float64 {
+	return firstLast(fv, func(first, last float64) float64 {
+		return (last - first) / math.Abs(first) * 100
+	})
+}
+
+// CountNonNull returns the number of values that are neither null nor NaN.
+func CountNonNull(fv *Float64Field) *float64 {
+	var f float64
+	for i := 0; i < fv.Len(); i++ {
+		v := fv.GetValue(i)
+		if v != nil && !math.IsNaN(*v) {
+			f++
+		}
+	}
+	return &f
+}
+
+// Range returns the difference between the maximum and the minimum value.
+func Range(fv *Float64Field) *float64 {
+	f := *Max(fv) - *Min(fv)
+	return &f
+}
+
+// firstLast applies fn to the first and last values. If either is null or NaN, or there are no values, NaN is returned.
+func firstLast(fv *Float64Field, fn func(first, last float64) float64) *float64 {
+	f := math.NaN()
+	if fv.Len() == 0 {
+		return &f
+	}
+	first, last := fv.GetValue(0), fv.GetValue(fv.Len()-1)
+	if first == nil || last == nil || math.IsNaN(*first) || math.IsNaN(*last) {
+		return &f
+	}
+	f = fn(*first, *last)
+	return &f
+}
+
+// sortedValues returns the values sorted in ascending order. It returns false if any value is null or NaN.
+func sortedValues(fv *Float64Field) ([]float64, bool) {
+	values := make([]float64, 0, fv.Len())
+	for i := 0; i < fv.Len(); i++ {
+		v := fv.GetValue(i)
+		if v == nil || math.IsNaN(*v) {
+			return nil, false
+		}
+		values = append(values, *v)
+	}
+	sort.Float64s(values)
+	return values, true
+}
+
+// parsePercentile returns the percentile of a reducer in the form pN, e.g. p95.
+func parsePercentile(rFunc ReducerID) (float64, bool) {
+	s, ok := strings.CutPrefix(string(rFunc), "p")
+	if !ok {
+		return 0, false
+	}
+	p, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(p) || p < 0 || p > 100 {
+		return 0, false
+	}
+	return p, true
+}
+
+// GetReduceFunc returns the reducer function for the given reducer.
+// Besides the listed percentile reducers, any percentile between p0 and p100 can be used, e.g. "p99.9".
 func GetReduceFunc(rFunc ReducerID) (ReducerFunc, error) {
 	switch rFunc {
 	case ReducerSum:
@@ -112,7 +261,26 @@ func GetReduceFunc(rFunc ReducerID) (ReducerFunc, error) {
 		return Count, nil
 	case ReducerLast:
 		return Last, nil
+	case ReducerFirst:
+		return First, nil
+	case ReducerMedian:
+		return Median, nil
+	case ReducerStdDev:
+		return StdDev, nil
+	case ReducerVariance:
+		return Variance, nil
+	case ReducerDiff:
+		return Diff, nil
+	case ReducerPercentDiff:
+		return PercentDiff, nil
+	case ReducerCountNonNull:
+		return CountNonNull, nil
+	case ReducerRange:
+		return Range, nil
 	default:
+		if p, ok := parsePercentile(rFunc); ok {
+			return Percentile(p), nil
+		}
 		return nil, fmt.Errorf("reduction %v not implemented", rFunc)
 	}
 }
@@ -204,6 +204,68 @@ func TestSeriesReduce(t *testing.T) {
 	}
 }
 
+var seriesFiveValues = Vars{
+	"A": resultValuesNoErr(
+		makeSeries("temp", nil,
+			tp{time.Unix(5, 0), float64Pointer(4)},
+			tp{time.Unix(10, 0), float64Pointer(1)},
+			tp{time.Unix(15, 0), float64Pointer(3)},
+			tp{time.Unix(20, 0), float64Pointer(2)},
+			tp{time.Unix(25, 0), float64Pointer(5)}),
+	),
+}
+
+func TestSeriesReduceStatistics(t *testing.T) {
+	var tests = []struct {
+		name    string
+		red     ReducerID
+		vars    Vars
+		results Results
+	}{
+		{name: "first", red: "first", vars: seriesFiveValues, results: resultValuesNoErr(makeNumber("", nil, float64Pointer(4)))},
+		{name: "median", red: "median", vars: seriesFiveValues, results: resultValuesNoErr(makeNumber("", nil, float64Pointer(3)))},
+		{name: "median of even number of values", red: "median", vars: aSeries, results: resultValuesNoErr(makeNumber("", nil, float64Pointer(1.5)))},
+		{name: "p25", red: "p25", vars: seriesFiveValues, results: resultValuesNoErr(makeNumber("", nil, float64Pointer(2)))},
+		{name: "p90 interpolates", red: "p90", vars: seriesFiveValues, results: resultValuesNoErr(makeNumber("", nil, float64Pointer(4.6)))},
+		{name: "p100", red: "p100", vars: seriesFiveValues, results: resultValuesNoErr(makeNumber("", nil, float64Pointer(5)))},
+		{name: "variance", red: "variance", vars: seriesFiveValues, results: resultValuesNoErr(makeNumber("", nil, float64Pointer(2)))},
+		{name: "stddev", red: "stddev", vars: seriesFiveValues, results: resultValuesNoErr(makeNumber("", nil, float64Pointer(math.Sqrt(2))))},
+		{name: "diff", red: "diff", vars: seriesFiveValues, results: resultValuesNoErr(makeNumber("", nil, float64Pointer(1)))},
+		{name: "percent_diff", red: "percent_diff", vars: seriesFiveValues, results: resultValuesNoErr(makeNumber("", nil, float64Pointer(25)))},
+		{name: "range", red: "range", vars: seriesFiveValues, results: resultValuesNoErr(makeNumber("", nil, float64Pointer(4)))},
+		{name: "count_non_null", red: "count_non_null", vars: seriesWithNil, results: resultValuesNoErr(makeNumber("", nil, float64Pointer(1)))},
+		{name: "median series with a nil value", red: "median", vars: seriesWithNil, results: resultValuesNoErr(makeNumber("", nil, NaN))},
+		{name: "stddev series with a nil value", red: "stddev", vars: seriesWithNil, results: resultValuesNoErr(makeNumber("", nil, NaN))},
+		{name: "diff series with a nil value", red: "diff", vars: seriesWithNil, results: resultValuesNoErr(makeNumber("", nil, NaN))},
+		{name: "p95 empty series", red: "p95", vars: seriesEmpty, results: resultValuesNoErr(makeNumber("", nil, NaN))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := Results{}
+			for _, series := range tt.vars["A"].Values {
+				ns, err := series.Value().(*Series).Reduce("", tt.red, nil)
+				require.NoError(t, err)
+				results.Values = append(results.Values, ns)
+			}
+			opt := cmp.Comparer(func(x, y float64) bool {
+				return (math.IsNaN(x) && math.IsNaN(y)) || math.Abs(x-y) < 1e-9
+			})
+			options := append([]cmp.Option{opt}, data.FrameTestCompareOptions()...)
+			if diff := cmp.Diff(tt.results, results, options...); diff != "" {
+				t.Errorf("Result mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("invalid percentiles will error", func(t *testing.T) {
+		for _, red := range []ReducerID{"p", "p101", "p-1", "pfoo", "pnan", "pNaN"} {
+			_, err := GetReduceFunc(red)
+			require.Error(t, err, red)
+		}
+	})
+}
+
 var seriesNonNumbers = Vars{
 	"A": resultValuesNoErr(
 		makeSeries("temp", nil,
@@ -20,10 +20,17 @@ const (
 
 	// Do not fill values (nill)
 	UpsamplerFillNA Upsampler = "fillna"
+
+	// Linear interpolation between the last seen and the next value
+	UpsamplerLinear Upsampler = "linear"
+
+	// Use the value closest in time, preferring the last seen value on ties
+	UpsamplerNearest Upsampler = "nearest"
 )
 
-// Resample turns the Series into a Number based on the given reduction function
-func (s Series) Resample(refID string, interval time.Duration, downsampler ReducerID, upsampler Upsampler, from, to time.Time) (Series, error) {
+// Resample turns the Series into a Number based on the given reduction function.
+// If maxGap is greater than zero, gaps between known points that span more than maxGap intervals are not filled by the upsampler.
+func (s Series) Resample(refID string, interval time.Duration, downsampler ReducerID, upsampler Upsampler, maxGap int, from, to time.Time) (Series, error) {
 	newSeriesLength := int(float64(to.Sub(from).Nanoseconds()) / float64(interval.Nanoseconds()))
 	if newSeriesLength <= 0 {
 		return s, fmt.Errorf("the series cannot be sampled further; the time range is shorter than the interval")
@@ -31,6 +38,8 @@ func (s Series) Resample(refID string, interval time.Duration, downsampler Reduc
 	resampled := NewSeries(refID, s.GetLabels(), newSeriesLength+1)
 	bookmark := 0
 	var lastSeen *float64
+	var lastSeenTime time.Time
+	seen := false
 	idx := 0
 	t := from
 	for !t.After(to) && idx <= newSeriesLength {
@@ -47,10 +56,18 @@ func (s Series) Resample(refID string, interval time.Duration, downsampler Reduc
 			bookmark++
 			sIdx++
 			lastSeen = v
+			lastSeenTime = st
+			seen = true
 			vals = append(vals, v)
 		}
 		var value *float64
 		if len(vals) == 0 { // upsampling
+			hasNext := sIdx < s.Len()
+			var nextTime time.Time
+			var next *float64
+			if hasNext {
+				nextTime, next = s.GetPoint(sIdx)
+			}
 			switch upsampler {
 			case UpsamplerPad:
 				if lastSeen != nil {
@@ -59,37 +76,42 @@ func (s Series) Resample(refID string, interval time.Duration, downsampler Reduc
 					value = nil
 				}
 			case UpsamplerBackfill:
-				if sIdx == s.Len() { // no vals left
+				if !hasNext { // no vals left
 					value = nil
 				} else {
-					_, value = s.GetPoint(sIdx)
+					value = next
 				}
 			case UpsamplerFillNA:
 				value = nil
+			case UpsamplerLinear:
+				if seen && hasNext && lastSeen != nil && next != nil {
+					ratio := float64(t.Sub(lastSeenTime)) / float64(nextTime.Sub(lastSeenTime))
+					f := *lastSeen + (*next-*lastSeen)*ratio
+					value = &f
+				}
+			case UpsamplerNearest:
+				switch {
+				case seen && (!hasNext || t.Sub(lastSeenTime) <= nextTime.Sub(t)):
+					value = lastSeen
+				case hasNext:
+					value = next
+				}
 			default:
 				return s, fmt.Errorf("upsampling %v not implemented", upsampler)
 			}
+			if value != nil && maxGap > 0 && !gapFillable(t, seen, lastSeenTime, hasNext, nextTime, time.Duration(maxGap)*interval) {
+				value = nil
+			}
 		} else if len(vals) == 1 {
 			value = vals[0]
 		} else { // downsampling
 			fVec := data.NewField("", s.GetLabels(), vals)
 			ff := Float64Field(*fVec)
-			var tmp *float64
-			switch downsampler {
-			case ReducerSum:
-				tmp = Sum(&ff)
-			case ReducerMean:
-				tmp = Avg(&ff)
-			case ReducerMin:
-				tmp = Min(&ff)
-			case ReducerMax:
-				tmp = Max(&ff)
-			case ReducerLast:
-				tmp = Last(&ff)
-			default:
+			reduceFunc, err := GetReduceFunc(downsampler)
+			if err != nil {
 				return s, fmt.Errorf("downsampling %v not implemented", downsampler)
 			}
-			value = tmp
+			value = reduceFunc(&ff)
 		}
 		resampled.SetPoint(idx, t, value)
 		t = t.Add(interval)
@@ -97,3 +119,18 @@ func (s Series) Resample(refID string, interval time.Duration, downsampler Reduc
 	}
 	return resampled, nil
 }
+
+// gapFillable returns true if the gap around t is at most maxGap long. The gap spans from the last seen point
+// to the next point. At the edges of the series, the distance from t to the only known neighbour is used.
+func gapFillable(t time.Time, seen bool, lastSeenTime time.Time, hasNext bool, nextTime time.Time, maxGap time.Duration) bool {
+	switch {
+	case seen && hasNext:
+		return nextTime.Sub(lastSeenTime) <= maxGap
+	case seen:
+		return t.Sub(lastSeenTime) <= maxGap
+	case hasNext:
+		return nextTime.Sub(t) <= maxGap
+	default:
+		return false
+	}
+}
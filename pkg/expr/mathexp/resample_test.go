@@ -15,6 +15,7 @@ func TestResampleSeries(t *testing.T) {
 		interval         time.Duration
 		downsampler      ReducerID
 		upsampler        Upsampler
+		maxGap           int
 		timeRange        backend.TimeRange
 		seriesToResample Series
 		series           Series
@@ -133,6 +134,34 @@ func TestResampleSeries(t *testing.T) {
 				time.Unix(15, 0), nil,
 			}),
 		},
+		{
+			name:        "resample series: downsampling (range / fillna)",
+			interval:    time.Second * 5,
+			downsampler: "range",
+			upsampler:   "fillna",
+			timeRange: backend.TimeRange{
+				From: time.Unix(0, 0),
+				To:   time.Unix(16, 0),
+			},
+			seriesToResample: makeSeries("", nil, tp{
+				time.Unix(2, 0), float64Pointer(2),
+			}, tp{
+				time.Unix(4, 0), float64Pointer(3),
+			}, tp{
+				time.Unix(7, 0), float64Pointer(1),
+			}, tp{
+				time.Unix(9, 0), float64Pointer(5),
+			}),
+			series: makeSeries("", nil, tp{
+				time.Unix(0, 0), nil,
+			}, tp{
+				time.Unix(5, 0), float64Pointer(1),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(4),
+			}, tp{
+				time.Unix(15, 0), nil,
+			}),
+		},
 		{
 			name:        "resample series: downsampling (sum / fillna)",
 			interval:    time.Second * 5,
@@ -277,10 +306,156 @@ func TestResampleSeries(t *testing.T) {
 				time.Unix(9, 0), float64Pointer(0),
 			}),
 		},
+		{
+			name:        "resample series: upsampling (linear)",
+			interval:    time.Second * 5,
+			downsampler: "mean",
+			upsampler:   "linear",
+			timeRange: backend.TimeRange{
+				From: time.Unix(0, 0),
+				To:   time.Unix(40, 0),
+			},
+			seriesToResample: makeSeries("", nil, tp{
+				time.Unix(0, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(10),
+			}, tp{
+				time.Unix(40, 0), float64Pointer(40),
+			}),
+			series: makeSeries("", nil, tp{
+				time.Unix(0, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(5, 0), float64Pointer(5),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(10),
+			}, tp{
+				time.Unix(15, 0), float64Pointer(15),
+			}, tp{
+				time.Unix(20, 0), float64Pointer(20),
+			}, tp{
+				time.Unix(25, 0), float64Pointer(25),
+			}, tp{
+				time.Unix(30, 0), float64Pointer(30),
+			}, tp{
+				time.Unix(35, 0), float64Pointer(35),
+			}, tp{
+				time.Unix(40, 0), float64Pointer(40),
+			}),
+		},
+		{
+			name:        "resample series: upsampling (nearest)",
+			interval:    time.Second * 5,
+			downsampler: "mean",
+			upsampler:   "nearest",
+			timeRange: backend.TimeRange{
+				From: time.Unix(0, 0),
+				To:   time.Unix(40, 0),
+			},
+			seriesToResample: makeSeries("", nil, tp{
+				time.Unix(0, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(10),
+			}, tp{
+				time.Unix(40, 0), float64Pointer(40),
+			}),
+			series: makeSeries("", nil, tp{
+				time.Unix(0, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(5, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(10),
+			}, tp{
+				time.Unix(15, 0), float64Pointer(10),
+			}, tp{
+				time.Unix(20, 0), float64Pointer(10),
+			}, tp{
+				time.Unix(25, 0), float64Pointer(10),
+			}, tp{
+				time.Unix(30, 0), float64Pointer(40),
+			}, tp{
+				time.Unix(35, 0), float64Pointer(40),
+			}, tp{
+				time.Unix(40, 0), float64Pointer(40),
+			}),
+		},
+		{
+			name:        "resample series: upsampling (pad / max gap)",
+			interval:    time.Second * 5,
+			downsampler: "mean",
+			upsampler:   "pad",
+			maxGap:      2,
+			timeRange: backend.TimeRange{
+				From: time.Unix(0, 0),
+				To:   time.Unix(40, 0),
+			},
+			seriesToResample: makeSeries("", nil, tp{
+				time.Unix(0, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(10),
+			}, tp{
+				time.Unix(40, 0), float64Pointer(40),
+			}),
+			series: makeSeries("", nil, tp{
+				time.Unix(0, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(5, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(10),
+			}, tp{
+				time.Unix(15, 0), nil,
+			}, tp{
+				time.Unix(20, 0), nil,
+			}, tp{
+				time.Unix(25, 0), nil,
+			}, tp{
+				time.Unix(30, 0), nil,
+			}, tp{
+				time.Unix(35, 0), nil,
+			}, tp{
+				time.Unix(40, 0), float64Pointer(40),
+			}),
+		},
+		{
+			name:        "resample series: upsampling (linear / max gap)",
+			interval:    time.Second * 5,
+			downsampler: "mean",
+			upsampler:   "linear",
+			maxGap:      2,
+			timeRange: backend.TimeRange{
+				From: time.Unix(0, 0),
+				To:   time.Unix(40, 0),
+			},
+			seriesToResample: makeSeries("", nil, tp{
+				time.Unix(0, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(10),
+			}, tp{
+				time.Unix(40, 0), float64Pointer(40),
+			}),
+			series: makeSeries("", nil, tp{
+				time.Unix(0, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(5, 0), float64Pointer(5),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(10),
+			}, tp{
+				time.Unix(15, 0), nil,
+			}, tp{
+				time.Unix(20, 0), nil,
+			}, tp{
+				time.Unix(25, 0), nil,
+			}, tp{
+				time.Unix(30, 0), nil,
+			}, tp{
+				time.Unix(35, 0), nil,
+			}, tp{
+				time.Unix(40, 0), float64Pointer(40),
+			}),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			series, err := tt.seriesToResample.Resample("", tt.interval, tt.downsampler, tt.upsampler, tt.timeRange.From, tt.timeRange.To)
+			series, err := tt.seriesToResample.Resample("", tt.interval, tt.downsampler, tt.upsampler, tt.maxGap, tt.timeRange.From, tt.timeRange.To)
 			if tt.series.Frame == nil {
 				require.Error(t, err)
 			} else {
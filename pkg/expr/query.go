@@ -27,8 +27,20 @@ const (
 	// Threshold
 	QueryTypeThreshold QueryType = "threshold"
 
-	// SQL query via DuckDB
+	// SQL query over the query results
 	QueryTypeSQL QueryType = "sql"
+
+	// Detect anomalies in query results
+	QueryTypeAnomaly QueryType = "anomaly"
+
+	// Forecast future values of query results
+	QueryTypeForecast QueryType = "forecast"
+
+	// Join query results into a single frame
+	QueryTypeJoin QueryType = "join"
+
+	// Concatenate query results into a single frame
+	QueryTypeUnion QueryType = "union"
 )
 
 type MathQuery struct {
@@ -60,6 +72,9 @@ type ResampleQuery struct {
 
 	// The upsample function
 	Upsampler mathexp.Upsampler `json:"upsampler"`
+
+	// Only fill gaps that span at most this many windows. Zero fills all gaps
+	MaxGap int `json:"maxGap,omitempty" jsonschema:"minimum=0"`
 }
 
 type ThresholdQuery struct {
@@ -74,6 +89,87 @@ type ClassicQuery struct {
 	Conditions []classic.ConditionJSON `json:"conditions"`
 }
 
+// QueryType = anomaly
+type AnomalyQuery struct {
+	// Reference to single query result
+	Expression string `json:"expression" jsonschema:"minLength=1,example=$A"`
+
+	// The detection algorithm
+	Algorithm AnomalyAlgorithm `json:"algorithm"`
+
+	// Width of the band in standard deviations (or scaled median absolute deviations). Defaults to 3
+	Sensitivity float64 `json:"sensitivity,omitempty" jsonschema:"minimum=0"`
+
+	// Trailing baseline window for the zscore and mad algorithms. The whole series is used when empty
+	Window string `json:"window,omitempty" jsonschema:"example=1h"`
+
+	// Length of the season for the seasonal algorithm
+	Period string `json:"period,omitempty" jsonschema:"example=1d,example=1w"`
+
+	// The returned series. Defaults to all
+	Output AnomalyOutput `json:"output,omitempty"`
+}
+
+// QueryType = forecast
+type ForecastQuery struct {
+	// Reference to single query result
+	Expression string `json:"expression" jsonschema:"minLength=1,example=$A"`
+
+	// The model fitted to the series
+	Model ForecastModel `json:"model"`
+
+	// How far after the last point to forecast
+	Horizon string `json:"horizon" jsonschema:"minLength=1,example=4h"`
+
+	// Trailing window of the series used to fit the model. The whole series is used when empty
+	Window string `json:"window,omitempty" jsonschema:"example=1d"`
+
+	// Length of the season for the holt_winters model. No seasonality is used when empty
+	Period string `json:"period,omitempty" jsonschema:"example=1d,example=1w"`
+
+	// Level smoothing factor of the holt_winters model. Defaults to 0.5
+	Alpha float64 `json:"alpha,omitempty" jsonschema:"minimum=0,maximum=1"`
+
+	// Trend smoothing factor of the holt_winters model. Defaults to 0.1
+	Beta float64 `json:"beta,omitempty" jsonschema:"minimum=0,maximum=1"`
+
+	// Seasonal smoothing factor of the holt_winters model. Defaults to 0.1
+	Gamma float64 `json:"gamma,omitempty" jsonschema:"minimum=0,maximum=1"`
+
+	// The value to reach, required by the time_to_threshold output
+	Threshold *float64 `json:"threshold,omitempty"`
+
+	// The returned result. Defaults to series
+	Output ForecastOutput `json:"output,omitempty"`
+}
+
+// QueryType = join
+type JoinQuery struct {
+	// References to the query results to join, in order
+	Expressions []string `json:"expressions" jsonschema:"minItems=2"`
+
+	// How the rows are matched. Defaults to labels
+	Mode JoinMode `json:"mode,omitempty"`
+
+	// The fields, or label keys, to match rows on. Required when joining by field
+	Fields []string `json:"fields,omitempty"`
+
+	// Which rows are kept. Defaults to inner
+	How JoinType `json:"how,omitempty"`
+
+	// The format of the result. Defaults to table
+	Format CombineFormat `json:"format,omitempty"`
+}
+
+// QueryType = union
+type UnionQuery struct {
+	// References to the query results to concatenate, in order
+	Expressions []string `json:"expressions" jsonschema:"minItems=2"`
+
+	// The format of the result. Defaults to table
+	Format CombineFormat `json:"format,omitempty"`
+}
+
 // SQLQuery requires the sqlExpression feature flag
 type SQLExpression struct {
 	Expression string `json:"expression" jsonschema:"minLength=1,example=SELECT * FROM A LIMIT 1"`
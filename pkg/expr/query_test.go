@@ -11,6 +11,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/expr/classic"
 	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/util"
 )
 
 func TestQueryTypeDefinitions(t *testing.T) {
@@ -27,6 +28,13 @@ func TestQueryTypeDefinitions(t *testing.T) {
 				reflect.TypeOf(ReduceModeDrop),       // pick an example value (not the root)
 				reflect.TypeOf(ThresholdIsAbove),
 				reflect.TypeOf(classic.ConditionOperatorAnd),
+				reflect.TypeOf(AnomalyAlgorithmZScore),
+				reflect.TypeOf(AnomalyOutputAll),
+				reflect.TypeOf(ForecastModelLinear),
+				reflect.TypeOf(ForecastOutputSeries),
+				reflect.TypeOf(JoinModeField),
+				reflect.TypeOf(JoinTypeInner),
+				reflect.TypeOf(CombineFormatTable),
 			},
 		})
 	require.NoError(t, err)
@@ -92,6 +100,66 @@ func TestQueryTypeDefinitions(t *testing.T) {
 				},
 			},
 		},
+		schemabuilder.QueryTypeInfo{
+			Discriminators: data.NewDiscriminators("type", QueryTypeAnomaly),
+			GoType:         reflect.TypeOf(&AnomalyQuery{}),
+			Examples: []data.QueryExample{
+				{
+					Name: "daily seasonal anomalies",
+					SaveModel: data.AsUnstructured(AnomalyQuery{
+						Expression: "$A",
+						Algorithm:  AnomalyAlgorithmSeasonal,
+						Period:     "1d",
+						Output:     AnomalyOutputFlag,
+					}),
+				},
+			},
+		},
+		schemabuilder.QueryTypeInfo{
+			Discriminators: data.NewDiscriminators("type", QueryTypeForecast),
+			GoType:         reflect.TypeOf(&ForecastQuery{}),
+			Examples: []data.QueryExample{
+				{
+					Name: "hours until the disk is full",
+					SaveModel: data.AsUnstructured(ForecastQuery{
+						Expression: "$A",
+						Model:      ForecastModelLinear,
+						Horizon:    "1d",
+						Window:     "6h",
+						Threshold:  util.Pointer(100.0),
+						Output:     ForecastOutputTimeToThreshold,
+					}),
+				},
+			},
+		},
+		schemabuilder.QueryTypeInfo{
+			Discriminators: data.NewDiscriminators("type", QueryTypeJoin),
+			GoType:         reflect.TypeOf(&JoinQuery{}),
+			Examples: []data.QueryExample{
+				{
+					Name: "add the team of each host from an inventory table",
+					SaveModel: data.AsUnstructured(JoinQuery{
+						Expressions: []string{"$A", "$B"},
+						Mode:        JoinModeField,
+						Fields:      []string{"host"},
+						How:         JoinTypeLeft,
+						Format:      CombineFormatLabeled,
+					}),
+				},
+			},
+		},
+		schemabuilder.QueryTypeInfo{
+			Discriminators: data.NewDiscriminators("type", QueryTypeUnion),
+			GoType:         reflect.TypeOf(&UnionQuery{}),
+			Examples: []data.QueryExample{
+				{
+					Name: "concatenate two tables",
+					SaveModel: data.AsUnstructured(UnionQuery{
+						Expressions: []string{"$A", "$B"},
+					}),
+				},
+			},
+		},
 		schemabuilder.QueryTypeInfo{
 			Discriminators: data.NewDiscriminators("type", QueryTypeClassic),
 			GoType:         reflect.TypeOf(&ClassicQuery{}),
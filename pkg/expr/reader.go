@@ -108,6 +108,7 @@ func (h *ExpressionQueryReader) ReadQuery(
 				referenceVar,
 				q.Downsampler,
 				q.Upsampler,
+				q.MaxGap,
 				AbsoluteTimeRange{
 					From: tr.GetFromAsTimeUTC(),
 					To:   tr.GetToAsTimeUTC(),
@@ -131,6 +132,52 @@ func (h *ExpressionQueryReader) ReadQuery(
 			eq.Command, err = NewSQLCommand(common.RefID, q.Expression)
 		}
 
+	case QueryTypeAnomaly:
+		q := &AnomalyQuery{}
+		err = iter.ReadVal(q)
+		if err == nil {
+			referenceVar, err = getReferenceVar(q.Expression, common.RefID)
+		}
+		if err == nil {
+			eq.Properties = q
+			eq.Command, err = NewAnomalyCommand(common.RefID, referenceVar, q.Algorithm, q.Sensitivity, q.Window, q.Period, q.Output)
+		}
+
+	case QueryTypeForecast:
+		q := &ForecastQuery{}
+		err = iter.ReadVal(q)
+		if err == nil {
+			referenceVar, err = getReferenceVar(q.Expression, common.RefID)
+		}
+		if err == nil {
+			eq.Properties = q
+			eq.Command, err = NewForecastCommand(common.RefID, referenceVar, q.Model, q.Horizon, q.Window, q.Period, q.Alpha, q.Beta, q.Gamma, q.Threshold, q.Output)
+		}
+
+	case QueryTypeJoin:
+		q := &JoinQuery{}
+		err = iter.ReadVal(q)
+		if err == nil {
+			var vars []string
+			vars, err = getReferenceVars(q.Expressions, common.RefID)
+			if err == nil {
+				eq.Properties = q
+				eq.Command, err = NewJoinCommand(common.RefID, vars, q.Mode, q.Fields, q.How, q.Format)
+			}
+		}
+
+	case QueryTypeUnion:
+		q := &UnionQuery{}
+		err = iter.ReadVal(q)
+		if err == nil {
+			var vars []string
+			vars, err = getReferenceVars(q.Expressions, common.RefID)
+			if err == nil {
+				eq.Properties = q
+				eq.Command, err = NewUnionCommand(common.RefID, vars, q.Format)
+			}
+		}
+
 	case QueryTypeThreshold:
 		q := &ThresholdQuery{}
 		err = iter.ReadVal(q)
@@ -0,0 +1,374 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"modernc.org/sqlite"
+)
+
+// sqliteLimitAttached is SQLITE_LIMIT_ATTACHED, the maximum number of attached databases.
+const sqliteLimitAttached = 7
+
+// timeLayout is the layout time values are stored with, which SQLite date and time functions understand.
+const timeLayout = "2006-01-02 15:04:05.999999999-07:00"
+
+// DB runs SQL queries over data frames with an in-process, in-memory SQLite database.
+// It does not depend on any external binary.
+type DB struct{}
+
+// NewInMemoryDB creates a new DB.
+func NewInMemoryDB() *DB {
+	return &DB{}
+}
+
+// QueryFramesInto loads the frames as tables named after their RefID, runs the query and
+// stores the result in f. Frames with the same RefID are appended into a single table, where
+// the labels of their fields are added as string columns.
+func (db *DB) QueryFramesInto(ctx context.Context, name string, query string, frames []*data.Frame, f *data.Frame) error {
+	sqlDB, err := sql.Open("sqlite", "file::memory:?_time_format=sqlite")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	// a single connection, every connection has its own in-memory database
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := sqlite.Limit(conn, sqliteLimitAttached, 0); err != nil {
+		return err
+	}
+
+	for _, t := range tablesFromFrames(frames) {
+		if err := t.load(ctx, conn); err != nil {
+			return fmt.Errorf("failed to load %s: %w", t.name, err)
+		}
+	}
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		return err
+	}
+
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	res, err := frameFromRows(name, rows)
+	if err != nil {
+		return err
+	}
+	*f = *res
+	return nil
+}
+
+type column struct {
+	name     string
+	declType string
+}
+
+type table struct {
+	name    string
+	columns []column
+	rows    [][]any
+}
+
+// tablesFromFrames groups the frames by RefID and flattens them into tables.
+func tablesFromFrames(frames []*data.Frame) []*table {
+	var tables []*table
+	byName := map[string]*table{}
+	for _, frame := range frames {
+		if len(frame.Fields) == 0 {
+			continue
+		}
+		t, ok := byName[frame.RefID]
+		if !ok {
+			t = &table{name: frame.RefID}
+			byName[frame.RefID] = t
+			tables = append(tables, t)
+		}
+		t.appendFrame(frame)
+	}
+	return tables
+}
+
+func (t *table) columnIndex(name, declType string) int {
+	for i, c := range t.columns {
+		if c.name == name {
+			return i
+		}
+	}
+	t.columns = append(t.columns, column{name: name, declType: declType})
+	for i := range t.rows {
+		t.rows[i] = append(t.rows[i], nil)
+	}
+	return len(t.columns) - 1
+}
+
+func (t *table) appendFrame(frame *data.Frame) {
+	type source struct {
+		index int
+		field *data.Field
+	}
+	sources := make([]source, 0, len(frame.Fields))
+	labels := map[int]string{}
+	for _, field := range frame.Fields {
+		sources = append(sources, source{index: t.columnIndex(field.Name, declType(field.Type())), field: field})
+		for k, v := range field.Labels {
+			labels[t.columnIndex(k, "TEXT")] = v
+		}
+	}
+
+	rowLen, _ := frame.RowLen()
+	for i := 0; i < rowLen; i++ {
+		row := make([]any, len(t.columns))
+		for _, s := range sources {
+			row[s.index] = sqlValue(s.field, i)
+		}
+		for idx, v := range labels {
+			row[idx] = v
+		}
+		t.rows = append(t.rows, row)
+	}
+}
+
+func (t *table) load(ctx context.Context, conn *sql.Conn) error {
+	defs := make([]string, len(t.columns))
+	params := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		defs[i] = quoteIdent(c.name) + " " + c.declType
+		params[i] = "?"
+	}
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s (%s)", quoteIdent(t.name), strings.Join(defs, ", "))); err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s VALUES (%s)", quoteIdent(t.name), strings.Join(params, ", ")))
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	for _, row := range t.rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			_ = stmt.Close()
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	_ = stmt.Close()
+	return tx.Commit()
+}
+
+func quoteIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func declType(ft data.FieldType) string {
+	switch {
+	case ft.Time():
+		return "TIMESTAMP"
+	case ft.Numeric():
+		switch ft.NonNullableType() {
+		case data.FieldTypeFloat32, data.FieldTypeFloat64:
+			return "REAL"
+		default:
+			return "INTEGER"
+		}
+	case ft.NonNullableType() == data.FieldTypeBool:
+		return "BOOLEAN"
+	default:
+		return "TEXT"
+	}
+}
+
+// sqlValue returns the value of the field at row i as a type supported by the sqlite driver.
+func sqlValue(field *data.Field, i int) any {
+	v, ok := field.ConcreteAt(i)
+	if !ok {
+		return nil
+	}
+	switch x := v.(type) {
+	case int8:
+		return int64(x)
+	case int16:
+		return int64(x)
+	case int32:
+		return int64(x)
+	case int64:
+		return x
+	case uint8:
+		return int64(x)
+	case uint16:
+		return int64(x)
+	case uint32:
+		return int64(x)
+	case uint64:
+		if x > math.MaxInt64 {
+			return float64(x)
+		}
+		return int64(x)
+	case float32:
+		return float64(x)
+	case float64:
+		return x
+	case bool:
+		return x
+	case string:
+		return x
+	case time.Time:
+		return x.UTC()
+	case json.RawMessage:
+		return string(x)
+	case data.EnumItemIndex:
+		return int64(x)
+	default:
+		return fmt.Sprintf("%v", x)
+	}
+}
+
+// frameFromRows reads the result rows into a frame. The type of each field is taken from the
+// values of its column: integers, reals, booleans, times or strings. Strings are read as times
+// if they all use the layout times are stored with, so expressions like max(time) stay times.
+func frameFromRows(name string, rows *sql.Rows) (*data.Frame, error) {
+	cols, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([][]any, len(cols))
+	for rows.Next() {
+		row := make([]any, len(cols))
+		dest := make([]any, len(cols))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i, v := range row {
+			values[i] = append(values[i], v)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	frame := data.NewFrame(name)
+	for i, c := range cols {
+		frame.Fields = append(frame.Fields, fieldFromValues(c.Name(), c.DatabaseTypeName(), values[i]))
+	}
+	return frame, nil
+}
+
+func fieldFromValues(name, declType string, values []any) *data.Field {
+	var hasInt, hasFloat, hasBool, hasTime, hasString, hasOther bool
+	for _, v := range values {
+		switch x := v.(type) {
+		case nil:
+		case int64:
+			hasInt = true
+		case float64:
+			hasFloat = true
+		case bool:
+			hasBool = true
+		case time.Time:
+			hasTime = true
+		case string:
+			if _, err := time.Parse(timeLayout, x); err == nil {
+				hasTime = true
+			} else {
+				hasString = true
+			}
+		default:
+			hasOther = true
+		}
+	}
+
+	switch {
+	case hasString || hasOther || (hasTime && (hasInt || hasFloat || hasBool)):
+		out := make([]*string, len(values))
+		for i, v := range values {
+			switch x := v.(type) {
+			case nil:
+			case string:
+				out[i] = &x
+			case []byte:
+				s := string(x)
+				out[i] = &s
+			case time.Time:
+				s := x.Format(timeLayout)
+				out[i] = &s
+			default:
+				s := fmt.Sprintf("%v", x)
+				out[i] = &s
+			}
+		}
+		return data.NewField(name, nil, out)
+	case hasTime:
+		out := make([]*time.Time, len(values))
+		for i, v := range values {
+			switch x := v.(type) {
+			case time.Time:
+				out[i] = &x
+			case string:
+				t, _ := time.Parse(timeLayout, x)
+				out[i] = &t
+			}
+		}
+		return data.NewField(name, nil, out)
+	case hasFloat:
+		out := make([]*float64, len(values))
+		for i, v := range values {
+			switch x := v.(type) {
+			case float64:
+				out[i] = &x
+			case int64:
+				f := float64(x)
+				out[i] = &f
+			}
+		}
+		return data.NewField(name, nil, out)
+	case hasInt && (declType == "BOOLEAN" || declType == "BOOL"):
+		out := make([]*bool, len(values))
+		for i, v := range values {
+			if x, ok := v.(int64); ok {
+				b := x != 0
+				out[i] = &b
+			}
+		}
+		return data.NewField(name, nil, out)
+	case hasInt:
+		out := make([]*int64, len(values))
+		for i, v := range values {
+			if x, ok := v.(int64); ok {
+				out[i] = &x
+			}
+		}
+		return data.NewField(name, nil, out)
+	case hasBool:
+		out := make([]*bool, len(values))
+		for i, v := range values {
+			if x, ok := v.(bool); ok {
+				out[i] = &x
+			}
+		}
+		return data.NewField(name, nil, out)
+	default:
+		return data.NewField(name, nil, make([]*float64, len(values)))
+	}
+}
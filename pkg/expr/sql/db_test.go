@@ -0,0 +1,86 @@
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryFramesInto(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cpu := data.NewFrame("",
+		data.NewField("time", nil, []time.Time{now, now.Add(time.Minute), now.Add(2 * time.Minute)}),
+		data.NewField("value", data.Labels{"host": "a"}, []float64{1, 3, 2}),
+	)
+	cpu.RefID = "A"
+	cpu2 := data.NewFrame("",
+		data.NewField("time", nil, []time.Time{now, now.Add(time.Minute)}),
+		data.NewField("value", data.Labels{"host": "b"}, []float64{10, 20}),
+	)
+	cpu2.RefID = "A"
+	hosts := data.NewFrame("",
+		data.NewField("host", nil, []string{"a", "b"}),
+		data.NewField("region", nil, []string{"eu", "us"}),
+		data.NewField("enabled", nil, []*bool{toPtr(true), nil}),
+	)
+	hosts.RefID = "B"
+	frames := []*data.Frame{cpu, cpu2, hosts}
+
+	query := func(t *testing.T, q string) *data.Frame {
+		t.Helper()
+		f := &data.Frame{}
+		require.NoError(t, NewInMemoryDB().QueryFramesInto(context.Background(), "C", q, frames, f))
+		return f
+	}
+
+	t.Run("where and order by", func(t *testing.T) {
+		f := query(t, `SELECT time, value, host FROM A WHERE value > 1 ORDER BY value`)
+		require.Equal(t, 4, f.Rows())
+		assert.Equal(t, data.FieldTypeNullableTime, f.Fields[0].Type())
+		assert.Equal(t, data.FieldTypeNullableFloat64, f.Fields[1].Type())
+		assert.Equal(t, data.FieldTypeNullableString, f.Fields[2].Type())
+		ts, _ := f.Fields[0].ConcreteAt(0)
+		assert.True(t, now.Add(2*time.Minute).Equal(ts.(time.Time)))
+	})
+
+	t.Run("group by with join", func(t *testing.T) {
+		f := query(t, `SELECT B.region, count(*) AS points, max(A.time) AS last FROM A JOIN B ON A.host = B.host GROUP BY B.region ORDER BY B.region`)
+		require.Equal(t, 2, f.Rows())
+		assert.Equal(t, data.FieldTypeNullableInt64, f.Fields[1].Type())
+		assert.Equal(t, data.FieldTypeNullableTime, f.Fields[2].Type())
+		points, _ := f.Fields[1].ConcreteAt(0)
+		assert.Equal(t, int64(3), points)
+		last, _ := f.Fields[2].ConcreteAt(1)
+		assert.True(t, now.Add(time.Minute).Equal(last.(time.Time)))
+	})
+
+	t.Run("window functions", func(t *testing.T) {
+		f := query(t, `SELECT host, value - lag(value) OVER (PARTITION BY host ORDER BY time) AS diff FROM A ORDER BY host, time`)
+		require.Equal(t, 5, f.Rows())
+		_, ok := f.Fields[1].ConcreteAt(0)
+		assert.False(t, ok)
+		diff, _ := f.Fields[1].ConcreteAt(1)
+		assert.Equal(t, 2.0, diff)
+	})
+
+	t.Run("booleans", func(t *testing.T) {
+		f := query(t, `SELECT enabled FROM B ORDER BY host`)
+		assert.Equal(t, data.FieldTypeNullableBool, f.Fields[0].Type())
+		v, _ := f.Fields[0].ConcreteAt(0)
+		assert.Equal(t, true, v)
+	})
+
+	t.Run("the database is read only", func(t *testing.T) {
+		f := &data.Frame{}
+		err := NewInMemoryDB().QueryFramesInto(context.Background(), "C", `DELETE FROM A`, frames, f)
+		require.Error(t, err)
+	})
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
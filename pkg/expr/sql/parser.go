@@ -1,90 +1,315 @@
 package sql
 
 import (
-	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/grafana/grafana/pkg/infra/log"
-	"github.com/jeremywohl/flatten"
-	"github.com/scottlepp/go-duck/duck"
-)
-
-const (
-	TABLE_NAME    = "table_name"
-	ERROR         = ".error"
-	ERROR_MESSAGE = ".error_message"
 )
 
 var logger = log.New("sql_expr")
 
-// TablesList returns a list of tables for the sql statement
+// TablesList returns a list of tables for the sql statement.
+// Names of common table expressions are not tables and are not returned.
+// An error is returned if the statement is not a single query.
 func TablesList(rawSQL string) ([]string, error) {
-	duckDB := duck.NewInMemoryDB()
-	rawSQL = strings.Replace(rawSQL, "'", "''", -1)
-	cmd := fmt.Sprintf("SELECT json_serialize_sql('%s')", rawSQL)
-	ret, err := duckDB.RunCommands([]string{cmd})
+	tokens, err := tokenize(rawSQL)
 	if err != nil {
-		logger.Error("error serializing sql", "error", err.Error(), "sql", rawSQL, "cmd", cmd)
-		return nil, fmt.Errorf("error serializing sql: %s", err.Error())
+		logger.Error("error tokenizing sql", "error", err.Error(), "sql", rawSQL)
+		return nil, fmt.Errorf("error in sql: %w", err)
 	}
 
-	ast := []map[string]any{}
-	err = json.Unmarshal([]byte(ret), &ast)
+	tables, err := tablesFromTokens(tokens)
 	if err != nil {
-		logger.Error("error converting json sql to ast", "error", err.Error(), "ret", ret)
-		return nil, fmt.Errorf("error converting json to ast: %s", err.Error())
+		logger.Error("error parsing sql", "error", err.Error(), "sql", rawSQL)
+		return nil, fmt.Errorf("error in sql: %w", err)
 	}
 
-	return tablesFromAST(ast)
+	logger.Debug("tables found in sql", "tables", tables)
+
+	return tables, nil
 }
 
-// tablesFromAST returns a list of tables from the ast
-func tablesFromAST(ast []map[string]any) ([]string, error) {
-	flat, err := flatten.Flatten(ast[0], "", flatten.DotStyle)
-	if err != nil {
-		logger.Error("error flattening ast", "error", err.Error(), "ast", ast)
-		return nil, fmt.Errorf("error flattening ast: %s", err.Error())
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenPunct
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+// keyword returns the upper case keyword of the token, or an empty string if the token is not a keyword.
+func (t token) keyword() string {
+	if t.kind != tokenIdent {
+		return ""
 	}
+	k := strings.ToUpper(t.value)
+	if _, ok := keywords[k]; ok {
+		return k
+	}
+	return ""
+}
+
+func (t token) is(punct string) bool {
+	return t.kind == tokenPunct && t.value == punct
+}
 
-	tables := []string{}
-	for k, v := range flat {
-		if strings.HasSuffix(k, ERROR) {
-			v, ok := v.(bool)
-			if ok && v {
-				logger.Error("error in sql", "error", k)
-				return nil, astError(k, flat)
+// isName returns true if the token can be used as a table name or alias.
+func (t token) isName() bool {
+	return t.kind == tokenQuotedIdent || (t.kind == tokenIdent && t.keyword() == "")
+}
+
+// keywords are the reserved words that can not be used as an unquoted alias.
+var keywords = map[string]struct{}{
+	"ALL": {}, "AND": {}, "AS": {}, "ASC": {}, "BETWEEN": {}, "BY": {}, "CASE": {}, "CROSS": {}, "DESC": {},
+	"DISTINCT": {}, "ELSE": {}, "END": {}, "EXCEPT": {}, "EXISTS": {}, "FROM": {}, "FULL": {}, "GROUP": {},
+	"HAVING": {}, "IN": {}, "INNER": {}, "INTERSECT": {}, "IS": {}, "JOIN": {}, "LATERAL": {}, "LEFT": {},
+	"LIKE": {}, "LIMIT": {}, "NATURAL": {}, "NOT": {}, "NULL": {}, "OFFSET": {}, "ON": {}, "OR": {},
+	"ORDER": {}, "OUTER": {}, "QUALIFY": {}, "RECURSIVE": {}, "RIGHT": {}, "SELECT": {}, "THEN": {},
+	"UNION": {}, "USING": {}, "VALUES": {}, "WHEN": {}, "WHERE": {}, "WINDOW": {}, "WITH": {},
+}
+
+// tokenize splits the sql statement into tokens, dropping whitespace and comments.
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	r := []rune(s)
+	for i := 0; i < len(r); {
+		c := r[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '-' && i+1 < len(r) && r[i+1] == '-':
+			for i < len(r) && r[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(r) && r[i+1] == '*':
+			end := i + 2
+			for end+1 < len(r) && (r[end] != '*' || r[end+1] != '/') {
+				end++
+			}
+			if end+1 >= len(r) {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i = end + 2
+		case c == '\'' || c == '"' || c == '`':
+			value, n, ok := quoted(r[i:], c)
+			if !ok {
+				return nil, fmt.Errorf("unterminated quoted string %s", string(r[i:]))
+			}
+			kind := tokenQuotedIdent
+			if c == '\'' {
+				kind = tokenString
+			}
+			tokens = append(tokens, token{kind: kind, value: value})
+			i += n
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(r) && (unicode.IsLetter(r[i]) || unicode.IsDigit(r[i]) || r[i] == '_' || r[i] == '$') {
+				i++
 			}
+			tokens = append(tokens, token{kind: tokenIdent, value: string(r[start:i])})
+		case unicode.IsDigit(c):
+			start := i
+			for i < len(r) && (unicode.IsDigit(r[i]) || unicode.IsLetter(r[i]) || r[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: string(r[start:i])})
+		default:
+			tokens = append(tokens, token{kind: tokenPunct, value: string(c)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// quoted reads a string delimited by q at the start of r, where a doubled delimiter escapes it.
+// It returns the unquoted value and the number of runes read.
+func quoted(r []rune, q rune) (string, int, bool) {
+	var sb strings.Builder
+	for i := 1; i < len(r); i++ {
+		if r[i] != q {
+			sb.WriteRune(r[i])
+			continue
+		}
+		if i+1 < len(r) && r[i+1] == q {
+			sb.WriteRune(q)
+			i++
+			continue
 		}
-		if strings.Contains(k, TABLE_NAME) {
-			table, ok := v.(string)
-			if ok && !existsInList(table, tables) {
-				tables = append(tables, v.(string))
+		return sb.String(), i + 1, true
+	}
+	return "", 0, false
+}
+
+// scope is the state of a parenthesized level of the statement.
+type scope struct {
+	// query is true if the scope is a query rather than, for example, the arguments of a function.
+	query bool
+	// from is true while reading the FROM clause of the query.
+	from bool
+}
+
+// tablesFromTokens returns the sorted names of the tables referenced in FROM and JOIN clauses.
+func tablesFromTokens(tokens []token) ([]string, error) {
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty statement")
+	}
+	if k := tokens[0].keyword(); k != "SELECT" && k != "WITH" && k != "VALUES" && !tokens[0].is("(") {
+		return nil, fmt.Errorf("only SELECT statements are supported, got %s", tokens[0].value)
+	}
+
+	ctes := map[string]struct{}{}
+	found := map[string]struct{}{}
+	scopes := []scope{{query: true}}
+	expectTable := false
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		top := &scopes[len(scopes)-1]
+
+		if expectTable && t.isName() {
+			expectTable = false
+			// table valued functions are not tables
+			if i+1 < len(tokens) && tokens[i+1].is("(") {
+				continue
+			}
+			name := t.value
+			for i+2 < len(tokens) && tokens[i+1].is(".") && tokens[i+2].isName() {
+				name = tokens[i+2].value
+				i += 2
 			}
+			found[name] = struct{}{}
+
+			// optional alias, which can not be followed by another name
+			if i+1 < len(tokens) && tokens[i+1].keyword() == "AS" {
+				i++
+			}
+			if i+1 < len(tokens) && tokens[i+1].isName() {
+				i++
+			}
+			if i+1 < len(tokens) && tokens[i+1].isName() {
+				return nil, fmt.Errorf("unexpected %q after table %s", tokens[i+1].value, name)
+			}
+			continue
 		}
+
+		switch {
+		case t.is("("):
+			// a parenthesized FROM item can contain tables or joins directly
+			scopes = append(scopes, scope{query: expectTable, from: expectTable})
+			continue
+		case t.is(")"):
+			if len(scopes) == 1 {
+				return nil, fmt.Errorf("unexpected )")
+			}
+			scopes = scopes[:len(scopes)-1]
+		case t.is(","):
+			expectTable = top.query && top.from
+			continue
+		case t.is(";"):
+			if len(scopes) != 1 {
+				return nil, fmt.Errorf("unexpected ;")
+			}
+			for _, rest := range tokens[i+1:] {
+				if !rest.is(";") {
+					return nil, fmt.Errorf("only a single statement is supported")
+				}
+			}
+		}
+
+		switch t.keyword() {
+		case "LATERAL":
+			continue
+		case "SELECT", "VALUES":
+			top.query = true
+			top.from = false
+		case "WITH":
+			top.query = true
+			for _, name := range cteNames(tokens[i+1:]) {
+				ctes[strings.ToLower(name)] = struct{}{}
+			}
+		case "FROM":
+			if top.query {
+				top.from = true
+				expectTable = true
+				continue
+			}
+		case "JOIN":
+			if top.query {
+				top.from = true
+				expectTable = true
+				continue
+			}
+		case "WHERE", "GROUP", "HAVING", "WINDOW", "QUALIFY", "ORDER", "LIMIT", "OFFSET", "UNION", "INTERSECT", "EXCEPT":
+			top.from = false
+		}
+		expectTable = false
 	}
-	sort.Strings(tables)
 
-	logger.Debug("tables found in sql", "tables", tables)
+	if len(scopes) != 1 {
+		return nil, fmt.Errorf("missing )")
+	}
 
+	tables := make([]string, 0, len(found))
+	for name := range found {
+		if _, ok := ctes[strings.ToLower(name)]; !ok {
+			tables = append(tables, name)
+		}
+	}
+	sort.Strings(tables)
 	return tables, nil
 }
 
-func astError(k string, flat map[string]any) error {
-	key := strings.Replace(k, ERROR, "", 1)
-	message, ok := flat[key+ERROR_MESSAGE]
-	if !ok {
-		message = "unknown error in sql"
+// cteNames returns the names of the common table expressions defined by the WITH clause the tokens follow.
+func cteNames(tokens []token) []string {
+	var names []string
+	i := 0
+	if i < len(tokens) && tokens[i].keyword() == "RECURSIVE" {
+		i++
 	}
-	return fmt.Errorf("error in sql: %s", message)
+	for i < len(tokens) && tokens[i].isName() {
+		names = append(names, tokens[i].value)
+		i++
+		// optional column list and the AS (...) body
+		for i < len(tokens) && !tokens[i].is(",") {
+			if tokens[i].is("(") {
+				i = skipParens(tokens, i)
+				if i < len(tokens) && tokens[i].keyword() == "AS" {
+					continue
+				}
+				if i >= len(tokens) || !tokens[i].is(",") {
+					return names
+				}
+				break
+			}
+			i++
+		}
+		i++
+	}
+	return names
 }
 
-func existsInList(table string, list []string) bool {
-	for _, t := range list {
-		if t == table {
-			return true
+// skipParens returns the index of the token following the parenthesis that closes the one at i.
+func skipParens(tokens []token, i int) int {
+	depth := 0
+	for ; i < len(tokens); i++ {
+		if tokens[i].is("(") {
+			depth++
+		} else if tokens[i].is(")") {
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
 		}
 	}
-	return false
+	return i
 }
@@ -1,12 +1,20 @@
 package expr
 
 import (
+	"context"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/util"
 )
 
 func TestNewCommand(t *testing.T) {
-	t.Skip()
 	cmd, err := NewSQLCommand("a", "select a from foo, bar")
 	if err != nil && strings.Contains(err.Error(), "feature is not enabled") {
 		return
@@ -25,3 +33,53 @@ func TestNewCommand(t *testing.T) {
 		return
 	}
 }
+
+func TestSQLCommandExecute(t *testing.T) {
+	series := func(host string, values ...float64) mathexp.Series {
+		s := mathexp.NewSeries("A", data.Labels{"host": host}, len(values))
+		for i, v := range values {
+			s.SetPoint(i, time.Unix(int64(i*60), 0), util.Pointer(v))
+		}
+		return s
+	}
+	vars := mathexp.Vars{
+		"A": {Values: mathexp.Values{series("a", 1, 2, 3), series("b", 10, 20, 30)}},
+	}
+
+	t.Run("series are queried as a single table with labels as columns", func(t *testing.T) {
+		cmd, err := NewSQLCommand("B", `SELECT host, sum(A) AS total FROM A GROUP BY host ORDER BY host`)
+		require.NoError(t, err)
+		require.Equal(t, []string{"A"}, cmd.NeedsVars())
+
+		res, err := cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.NoError(t, res.Error)
+		require.Len(t, res.Values, 1)
+
+		frame := res.Values[0].AsDataFrame()
+		require.Equal(t, "B", frame.RefID)
+		require.Equal(t, 2, frame.Rows())
+		host, _ := frame.Fields[0].ConcreteAt(1)
+		total, _ := frame.Fields[1].ConcreteAt(1)
+		require.Equal(t, "b", host)
+		require.Equal(t, 60.0, total)
+	})
+
+	t.Run("no rows returns no data", func(t *testing.T) {
+		cmd, err := NewSQLCommand("B", `SELECT * FROM A WHERE A > 100`)
+		require.NoError(t, err)
+
+		res, err := cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.True(t, res.IsNoData())
+	})
+
+	t.Run("errors are returned in the results", func(t *testing.T) {
+		cmd, err := NewSQLCommand("B", `SELECT missing FROM A`)
+		require.NoError(t, err)
+
+		res, err := cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.ErrorContains(t, res.Error, "missing")
+	})
+}
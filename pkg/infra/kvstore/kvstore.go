@@ -2,6 +2,7 @@ package kvstore
 
 import (
 	"context"
+	"time"
 
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/infra/log"
@@ -14,8 +15,9 @@ const (
 
 func ProvideService(sqlStore db.DB) KVStore {
 	return &kvStoreSQL{
-		sqlStore: sqlStore,
-		log:      log.New("infra.kvstore.sql"),
+		sqlStore:      sqlStore,
+		log:           log.New("infra.kvstore.sql"),
+		watchInterval: defaultWatchInterval,
 	}
 }
 
@@ -26,6 +28,17 @@ type KVStore interface {
 	Del(ctx context.Context, orgId int64, namespace string, key string) error
 	Keys(ctx context.Context, orgId int64, namespace string, keyPrefix string) ([]Key, error)
 	GetAll(ctx context.Context, orgId int64, namespace string) (map[int64]map[string]string, error)
+	// SetWithTTL sets an item that expires after ttl. Expired items are not returned and are deleted by DeleteExpired.
+	SetWithTTL(ctx context.Context, orgId int64, namespace string, key string, value string, ttl time.Duration) error
+	// GetWithVersion returns an item along with its version.
+	GetWithVersion(ctx context.Context, orgId int64, namespace string, key string) (VersionedValue, bool, error)
+	// CompareAndSwap sets an item only if its current version is version, where version 0 means that the item must not exist.
+	// It returns the new version and whether the item was set. A ttl of 0 means that the item never expires.
+	CompareAndSwap(ctx context.Context, orgId int64, namespace string, key string, version int64, value string, ttl time.Duration) (int64, bool, error)
+	// Watch streams the changes of the items whose key starts with keyPrefix until ctx is done.
+	Watch(ctx context.Context, orgId int64, namespace string, keyPrefix string) (<-chan Event, error)
+	// DeleteExpired deletes the expired items of all organizations and namespaces, and returns how many were deleted.
+	DeleteExpired(ctx context.Context) (int64, error)
 }
 
 // WithNamespace returns a kvstore wrapper with fixed orgId and namespace.
@@ -64,3 +77,22 @@ func (kv *NamespacedKVStore) Keys(ctx context.Context, keyPrefix string) ([]Key,
 func (kv *NamespacedKVStore) GetAll(ctx context.Context) (map[int64]map[string]string, error) {
 	return kv.kvStore.GetAll(ctx, kv.orgId, kv.namespace)
 }
+
+func (kv *NamespacedKVStore) SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	return kv.kvStore.SetWithTTL(ctx, kv.orgId, kv.namespace, key, value, ttl)
+}
+
+func (kv *NamespacedKVStore) GetWithVersion(ctx context.Context, key string) (VersionedValue, bool, error) {
+	return kv.kvStore.GetWithVersion(ctx, kv.orgId, kv.namespace, key)
+}
+
+// CompareAndSwap sets the value of key only if its current version is version, 0 meaning that the key must not exist.
+// It returns the new version and whether the value was set. A ttl of 0 means that the key never expires.
+func (kv *NamespacedKVStore) CompareAndSwap(ctx context.Context, key string, version int64, value string, ttl time.Duration) (int64, bool, error) {
+	return kv.kvStore.CompareAndSwap(ctx, kv.orgId, kv.namespace, key, version, value, ttl)
+}
+
+// Watch streams the changes of the keys starting with keyPrefix until ctx is done.
+func (kv *NamespacedKVStore) Watch(ctx context.Context, keyPrefix string) (<-chan Event, error) {
+	return kv.kvStore.Watch(ctx, kv.orgId, kv.namespace, keyPrefix)
+}
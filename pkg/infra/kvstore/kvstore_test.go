@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,8 +24,9 @@ func createTestableKVStore(t *testing.T) KVStore {
 	sqlStore := db.InitTestDB(t)
 
 	kv := &kvStoreSQL{
-		sqlStore: sqlStore,
-		log:      log.New("infra.kvstore.sql"),
+		sqlStore:      sqlStore,
+		log:           log.New("infra.kvstore.sql"),
+		watchInterval: 10 * time.Millisecond,
 	}
 
 	return kv
@@ -292,3 +294,167 @@ func TestGetItems(t *testing.T) {
 		}
 	})
 }
+
+func TestIntegrationKVStoreTTL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	kv := createTestableKVStore(t)
+	ctx := context.Background()
+
+	require.NoError(t, kv.SetWithTTL(ctx, 1, "ttl", "short", "value", 50*time.Millisecond))
+	require.NoError(t, kv.SetWithTTL(ctx, 1, "ttl", "long", "value", time.Hour))
+	require.NoError(t, kv.Set(ctx, 1, "ttl", "forever", "value"))
+	require.Error(t, kv.SetWithTTL(ctx, 1, "ttl", "invalid", "value", 0))
+
+	_, ok, err := kv.Get(ctx, 1, "ttl", "short")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	time.Sleep(100 * time.Millisecond)
+
+	t.Run("expired keys are not returned", func(t *testing.T) {
+		_, ok, err := kv.Get(ctx, 1, "ttl", "short")
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		keys, err := kv.Keys(ctx, 1, "ttl", "")
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+
+		items, err := kv.GetAll(ctx, 1, "ttl")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"long": "value", "forever": "value"}, items[1])
+	})
+
+	t.Run("setting an expired key again makes it visible", func(t *testing.T) {
+		require.NoError(t, kv.Set(ctx, 1, "ttl", "short", "value"))
+		_, ok, err := kv.Get(ctx, 1, "ttl", "short")
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.NoError(t, kv.SetWithTTL(ctx, 1, "ttl", "short", "value", 50*time.Millisecond))
+		time.Sleep(100 * time.Millisecond)
+	})
+
+	t.Run("delete expired keys", func(t *testing.T) {
+		deleted, err := kv.DeleteExpired(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), deleted)
+
+		deleted, err = kv.DeleteExpired(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), deleted)
+	})
+}
+
+func TestIntegrationKVStoreCompareAndSwap(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	kv := WithNamespace(createTestableKVStore(t), 1, "cas")
+	ctx := context.Background()
+
+	_, ok, err := kv.GetWithVersion(ctx, "key")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	version, swapped, err := kv.CompareAndSwap(ctx, "key", 0, "first", 0)
+	require.NoError(t, err)
+	require.True(t, swapped)
+	require.Equal(t, int64(1), version)
+
+	t.Run("creating an existing key fails", func(t *testing.T) {
+		_, swapped, err := kv.CompareAndSwap(ctx, "key", 0, "other", 0)
+		require.NoError(t, err)
+		require.False(t, swapped)
+	})
+
+	t.Run("stale versions are rejected", func(t *testing.T) {
+		version, swapped, err := kv.CompareAndSwap(ctx, "key", 1, "second", 0)
+		require.NoError(t, err)
+		require.True(t, swapped)
+		require.Equal(t, int64(2), version)
+
+		_, swapped, err = kv.CompareAndSwap(ctx, "key", 1, "third", 0)
+		require.NoError(t, err)
+		require.False(t, swapped)
+
+		value, ok, err := kv.GetWithVersion(ctx, "key")
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, VersionedValue{Value: "second", Version: 2}, value)
+	})
+
+	t.Run("set increments the version", func(t *testing.T) {
+		require.NoError(t, kv.Set(ctx, "key", "third"))
+		value, _, err := kv.GetWithVersion(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, int64(3), value.Version)
+	})
+
+	t.Run("expired keys can be created again", func(t *testing.T) {
+		_, swapped, err := kv.CompareAndSwap(ctx, "lease", 0, "holder-1", 50*time.Millisecond)
+		require.NoError(t, err)
+		require.True(t, swapped)
+
+		time.Sleep(100 * time.Millisecond)
+
+		_, swapped, err = kv.CompareAndSwap(ctx, "lease", 0, "holder-2", time.Hour)
+		require.NoError(t, err)
+		require.True(t, swapped)
+
+		value, _, err := kv.Get(ctx, "lease")
+		require.NoError(t, err)
+		require.Equal(t, "holder-2", value)
+	})
+}
+
+func TestIntegrationKVStoreWatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	kv := WithNamespace(createTestableKVStore(t), 1, "watch")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, kv.Set(ctx, "prefix/existing", "value"))
+
+	events, err := kv.Watch(ctx, "prefix/")
+	require.NoError(t, err)
+
+	next := func() Event {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for an event")
+			return Event{}
+		}
+	}
+
+	require.NoError(t, kv.Set(ctx, "other", "value"))
+	require.NoError(t, kv.Set(ctx, "prefix/new", "value"))
+	e := next()
+	assert.Equal(t, EventPut, e.Type)
+	assert.Equal(t, Key{OrgId: 1, Namespace: "watch", Key: "prefix/new"}, e.Key)
+	assert.Equal(t, "value", e.Value)
+	assert.Equal(t, int64(1), e.Version)
+
+	require.NoError(t, kv.Set(ctx, "prefix/existing", "changed"))
+	e = next()
+	assert.Equal(t, EventPut, e.Type)
+	assert.Equal(t, "prefix/existing", e.Key.Key)
+	assert.Equal(t, "changed", e.Value)
+	assert.Equal(t, int64(2), e.Version)
+
+	require.NoError(t, kv.Del(ctx, "prefix/existing"))
+	e = next()
+	assert.Equal(t, EventDelete, e.Type)
+	assert.Equal(t, "prefix/existing", e.Key.Key)
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, open := <-events
+		return !open
+	}, time.Second, 10*time.Millisecond)
+}
@@ -11,6 +11,10 @@ type Item struct {
 	Namespace *string
 	Key       *string
 	Value     string
+	// Version is incremented every time the item is written.
+	Version int64
+	// Expires is the unix time in milliseconds after which the item is expired, nil if it never expires.
+	Expires *int64
 
 	Created time.Time
 	Updated time.Time
@@ -29,3 +33,30 @@ type Key struct {
 func (i *Key) TableName() string {
 	return "kv_store"
 }
+
+func (i *Item) expired(now time.Time) bool {
+	return i.Expires != nil && *i.Expires <= now.UnixMilli()
+}
+
+// VersionedValue is a value stored in the k/v store along with its version.
+// Versions are incremented every time the value is written, the version of a key that does not exist is 0.
+type VersionedValue struct {
+	Value   string
+	Version int64
+}
+
+type EventType string
+
+const (
+	EventPut    EventType = "put"
+	EventDelete EventType = "delete"
+)
+
+// Event is a change of a key watched with Watch.
+type Event struct {
+	Type EventType
+	Key  Key
+	// Value and Version are the new value and version of the key, they are empty for deletions.
+	Value   string
+	Version int64
+}
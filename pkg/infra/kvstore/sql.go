@@ -13,6 +13,8 @@ import (
 type kvStoreSQL struct {
 	log      log.Logger
 	sqlStore db.DB
+	// watchInterval is how often Watch checks the items for changes
+	watchInterval time.Duration
 }
 
 // Get an item from the store
@@ -30,7 +32,7 @@ func (kv *kvStoreSQL) Get(ctx context.Context, orgId int64, namespace string, ke
 			kv.log.Debug("error getting kvstore value", "orgId", orgId, "namespace", namespace, "key", key, "err", err)
 			return err
 		}
-		if !has {
+		if !has || item.expired(time.Now()) {
 			kv.log.Debug("kvstore value not found", "orgId", orgId, "namespace", namespace, "key", key)
 			return nil
 		}
@@ -39,11 +41,60 @@ func (kv *kvStoreSQL) Get(ctx context.Context, orgId int64, namespace string, ke
 		return nil
 	})
 
+	if !itemFound {
+		return "", false, err
+	}
 	return item.Value, itemFound, err
 }
 
+// GetWithVersion gets an item from the store along with its version
+func (kv *kvStoreSQL) GetWithVersion(ctx context.Context, orgId int64, namespace string, key string) (VersionedValue, bool, error) {
+	item := Item{
+		OrgId:     &orgId,
+		Namespace: &namespace,
+		Key:       &key,
+	}
+	var itemFound bool
+
+	err := kv.sqlStore.WithDbSession(ctx, func(dbSession *db.Session) error {
+		has, err := dbSession.Get(&item)
+		if err != nil {
+			kv.log.Debug("error getting kvstore value", "orgId", orgId, "namespace", namespace, "key", key, "err", err)
+			return err
+		}
+		itemFound = has && !item.expired(time.Now())
+		return nil
+	})
+
+	if !itemFound {
+		return VersionedValue{}, false, err
+	}
+	return VersionedValue{Value: item.Value, Version: item.Version}, true, err
+}
+
 // Set an item in the store
 func (kv *kvStoreSQL) Set(ctx context.Context, orgId int64, namespace string, key string, value string) error {
+	return kv.set(ctx, orgId, namespace, key, value, 0)
+}
+
+// SetWithTTL sets an item in the store that expires after ttl
+func (kv *kvStoreSQL) SetWithTTL(ctx context.Context, orgId int64, namespace string, key string, value string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return fmt.Errorf("ttl must be positive, got %s", ttl)
+	}
+	return kv.set(ctx, orgId, namespace, key, value, ttl)
+}
+
+// expiresAt returns the expiry time of an item written at now with the given ttl, nil if it does not expire.
+func expiresAt(now time.Time, ttl time.Duration) *int64 {
+	if ttl <= 0 {
+		return nil
+	}
+	expires := now.Add(ttl).UnixMilli()
+	return &expires
+}
+
+func (kv *kvStoreSQL) set(ctx context.Context, orgId int64, namespace string, key string, value string, ttl time.Duration) error {
 	return kv.sqlStore.WithTransactionalDbSession(ctx, func(dbSession *db.Session) error {
 		item := Item{
 			OrgId:     &orgId,
@@ -57,16 +108,18 @@ func (kv *kvStoreSQL) Set(ctx context.Context, orgId int64, namespace string, ke
 			return err
 		}
 
-		if has && item.Value == value {
+		now := time.Now()
+		if has && item.Value == value && item.Expires == nil && ttl == 0 {
 			kv.log.Debug("kvstore value not changed", "orgId", orgId, "namespace", namespace, "key", key, "value", value)
 			return nil
 		}
 
 		item.Value = value
-		item.Updated = time.Now()
+		item.Updated = now
+		item.Expires = expiresAt(now, ttl)
 
 		if has {
-			_, err = dbSession.Exec("UPDATE kv_store SET value = ?, updated = ? WHERE id = ?", item.Value, item.Updated, item.Id)
+			_, err = dbSession.Exec("UPDATE kv_store SET value = ?, updated = ?, version = version + 1, expires = ? WHERE id = ?", item.Value, item.Updated, item.Expires, item.Id)
 			if err != nil {
 				kv.log.Debug("error updating kvstore value", "orgId", orgId, "namespace", namespace, "key", key, "value", value, "err", err)
 			} else {
@@ -76,6 +129,7 @@ func (kv *kvStoreSQL) Set(ctx context.Context, orgId int64, namespace string, ke
 		}
 
 		item.Created = item.Updated
+		item.Version = 1
 		_, err = dbSession.Insert(&item)
 		if err != nil {
 			kv.log.Debug("error inserting kvstore value", "orgId", orgId, "namespace", namespace, "key", key, "value", value, "err", err)
@@ -86,6 +140,71 @@ func (kv *kvStoreSQL) Set(ctx context.Context, orgId int64, namespace string, ke
 	})
 }
 
+// CompareAndSwap sets an item only if its current version is version, where 0 means that it must not exist.
+func (kv *kvStoreSQL) CompareAndSwap(ctx context.Context, orgId int64, namespace string, key string, version int64, value string, ttl time.Duration) (int64, bool, error) {
+	var newVersion int64
+	var swapped bool
+	err := kv.sqlStore.WithTransactionalDbSession(ctx, func(dbSession *db.Session) error {
+		item := Item{
+			OrgId:     &orgId,
+			Namespace: &namespace,
+			Key:       &key,
+		}
+
+		has, err := dbSession.Get(&item)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		var currentVersion int64
+		if has && !item.expired(now) {
+			currentVersion = item.Version
+		}
+		if currentVersion != version {
+			kv.log.Debug("kvstore version mismatch", "orgId", orgId, "namespace", namespace, "key", key, "version", version, "currentVersion", currentVersion)
+			return nil
+		}
+
+		item.Value = value
+		item.Updated = now
+		item.Expires = expiresAt(now, ttl)
+
+		if has {
+			// the version condition guards against concurrent writes between the read and the update
+			res, err := dbSession.Exec("UPDATE kv_store SET value = ?, updated = ?, version = ?, expires = ? WHERE id = ? AND version = ?",
+				item.Value, item.Updated, item.Version+1, item.Expires, item.Id, item.Version)
+			if err != nil {
+				return err
+			}
+			affected, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if affected == 1 {
+				newVersion, swapped = item.Version+1, true
+			}
+			return nil
+		}
+
+		item.Created = now
+		item.Version = 1
+		if _, err := dbSession.Insert(&item); err != nil {
+			return err
+		}
+		newVersion, swapped = item.Version, true
+		return nil
+	})
+	if err != nil {
+		// another writer inserted the item first
+		if kv.sqlStore.GetDialect().IsUniqueConstraintViolation(err) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return newVersion, swapped, nil
+}
+
 // Del deletes an item from the store.
 func (kv *kvStoreSQL) Del(ctx context.Context, orgId int64, namespace string, key string) error {
 	err := kv.sqlStore.WithDbSession(ctx, func(dbSession *db.Session) error {
@@ -105,6 +224,7 @@ func (kv *kvStoreSQL) Keys(ctx context.Context, orgId int64, namespace string, k
 		if orgId != AllOrganizations {
 			query.And("org_id = ?", orgId)
 		}
+		query.And("(expires IS NULL OR expires > ?)", time.Now().UnixMilli())
 		return query.Find(&keys)
 	})
 	return keys, err
@@ -120,6 +240,7 @@ func (kv *kvStoreSQL) GetAll(ctx context.Context, orgId int64, namespace string)
 		if orgId != AllOrganizations {
 			query.And("org_id = ?", orgId)
 		}
+		query.And("(expires IS NULL OR expires > ?)", time.Now().UnixMilli())
 
 		return query.Find(&results)
 	})
@@ -134,3 +255,42 @@ func (kv *kvStoreSQL) GetAll(ctx context.Context, orgId int64, namespace string)
 
 	return items, err
 }
+
+// Watch streams the changes of the items of a given namespace and keyPrefix. To watch all
+// organizations the constant 'kvstore.AllOrganizations' can be passed as orgId.
+func (kv *kvStoreSQL) Watch(ctx context.Context, orgId int64, namespace string, keyPrefix string) (<-chan Event, error) {
+	return pollWatch(ctx, kv.watchInterval, kv.log, func(ctx context.Context) (map[Key]VersionedValue, error) {
+		var results []Item
+		err := kv.sqlStore.WithDbSession(ctx, func(dbSession *db.Session) error {
+			query := dbSession.Where("namespace = ?", namespace).And(fmt.Sprintf("%s LIKE ?", kv.sqlStore.GetDialect().Quote("key")), keyPrefix+"%")
+			if orgId != AllOrganizations {
+				query.And("org_id = ?", orgId)
+			}
+			query.And("(expires IS NULL OR expires > ?)", time.Now().UnixMilli())
+			return query.Find(&results)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		items := make(map[Key]VersionedValue, len(results))
+		for _, r := range results {
+			items[Key{OrgId: *r.OrgId, Namespace: *r.Namespace, Key: *r.Key}] = VersionedValue{Value: r.Value, Version: r.Version}
+		}
+		return items, nil
+	})
+}
+
+// DeleteExpired deletes the expired items of all organizations and namespaces.
+func (kv *kvStoreSQL) DeleteExpired(ctx context.Context) (int64, error) {
+	var affected int64
+	err := kv.sqlStore.WithDbSession(ctx, func(dbSession *db.Session) error {
+		res, err := dbSession.Exec("DELETE FROM kv_store WHERE expires IS NOT NULL AND expires <= ?", time.Now().UnixMilli())
+		if err != nil {
+			return err
+		}
+		affected, err = res.RowsAffected()
+		return err
+	})
+	return affected, err
+}
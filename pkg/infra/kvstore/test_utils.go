@@ -4,16 +4,23 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 )
 
 // In memory kv store used for testing
 type FakeKVStore struct {
 	store    map[Key]string
+	versions map[Key]int64
+	expires  map[Key]time.Time
 	delError bool
 }
 
 func NewFakeKVStore() *FakeKVStore {
-	return &FakeKVStore{store: make(map[Key]string)}
+	return &FakeKVStore{
+		store:    make(map[Key]string),
+		versions: make(map[Key]int64),
+		expires:  make(map[Key]time.Time),
+	}
 }
 
 func (f *FakeKVStore) DeletionError(shouldErr bool) {
@@ -21,26 +28,98 @@ func (f *FakeKVStore) DeletionError(shouldErr bool) {
 }
 
 func (f *FakeKVStore) Get(ctx context.Context, orgId int64, namespace string, key string) (string, bool, error) {
+	f.expire()
 	value := f.store[buildKey(orgId, namespace, key)]
 	found := value != ""
 	return value, found, nil
 }
 
 func (f *FakeKVStore) Set(ctx context.Context, orgId int64, namespace string, key string, value string) error {
-	f.store[buildKey(orgId, namespace, key)] = value
+	f.set(buildKey(orgId, namespace, key), value, 0)
+	return nil
+}
+
+func (f *FakeKVStore) SetWithTTL(ctx context.Context, orgId int64, namespace string, key string, value string, ttl time.Duration) error {
+	f.set(buildKey(orgId, namespace, key), value, ttl)
 	return nil
 }
 
+func (f *FakeKVStore) set(k Key, value string, ttl time.Duration) {
+	f.store[k] = value
+	f.versions[k]++
+	delete(f.expires, k)
+	if ttl > 0 {
+		f.expires[k] = time.Now().Add(ttl)
+	}
+}
+
+func (f *FakeKVStore) GetWithVersion(ctx context.Context, orgId int64, namespace string, key string) (VersionedValue, bool, error) {
+	f.expire()
+	k := buildKey(orgId, namespace, key)
+	value, found := f.store[k]
+	if !found {
+		return VersionedValue{}, false, nil
+	}
+	return VersionedValue{Value: value, Version: f.versions[k]}, true, nil
+}
+
+func (f *FakeKVStore) CompareAndSwap(ctx context.Context, orgId int64, namespace string, key string, version int64, value string, ttl time.Duration) (int64, bool, error) {
+	f.expire()
+	k := buildKey(orgId, namespace, key)
+	var current int64
+	if _, found := f.store[k]; found {
+		current = f.versions[k]
+	}
+	if current != version {
+		return 0, false, nil
+	}
+	f.set(k, value, ttl)
+	return f.versions[k], true, nil
+}
+
+// Watch returns a channel that is closed when ctx is done, changes are not streamed.
+func (f *FakeKVStore) Watch(ctx context.Context, orgId int64, namespace string, keyPrefix string) (<-chan Event, error) {
+	events := make(chan Event)
+	go func() {
+		<-ctx.Done()
+		close(events)
+	}()
+	return events, nil
+}
+
+func (f *FakeKVStore) DeleteExpired(ctx context.Context) (int64, error) {
+	return f.expire(), nil
+}
+
+// expire deletes the expired items and returns how many were deleted.
+func (f *FakeKVStore) expire() int64 {
+	var deleted int64
+	now := time.Now()
+	for k, t := range f.expires {
+		if !t.After(now) {
+			delete(f.store, k)
+			delete(f.versions, k)
+			delete(f.expires, k)
+			deleted++
+		}
+	}
+	return deleted
+}
+
 func (f *FakeKVStore) Del(ctx context.Context, orgId int64, namespace string, key string) error {
 	if f.delError {
 		return errors.New("mocked del error")
 	}
-	delete(f.store, buildKey(orgId, namespace, key))
+	k := buildKey(orgId, namespace, key)
+	delete(f.store, k)
+	delete(f.versions, k)
+	delete(f.expires, k)
 	return nil
 }
 
 // List all keys with an optional filter. If default values are provided, filter is not applied.
 func (f *FakeKVStore) Keys(ctx context.Context, orgId int64, namespace string, keyPrefix string) ([]Key, error) {
+	f.expire()
 	res := make([]Key, 0)
 	for k := range f.store {
 		if orgId == AllOrganizations && namespace == "" && keyPrefix == "" {
@@ -53,6 +132,7 @@ func (f *FakeKVStore) Keys(ctx context.Context, orgId int64, namespace string, k
 }
 
 func (f *FakeKVStore) GetAll(ctx context.Context, orgId int64, namespace string) (map[int64]map[string]string, error) {
+	f.expire()
 	items := make(map[int64]map[string]string)
 	for k := range f.store {
 		orgId := k.OrgId
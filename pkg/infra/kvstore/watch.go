@@ -0,0 +1,91 @@
+package kvstore
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+// defaultWatchInterval is how often watched items are checked for changes.
+const defaultWatchInterval = time.Second
+
+// listFunc returns the current items under a watched prefix.
+type listFunc func(ctx context.Context) (map[Key]VersionedValue, error)
+
+// pollWatch streams the changes of the items returned by list, which is called every interval until ctx is done.
+// Polling the store, rather than notifying the writes made by this instance, also sees the changes made by
+// other instances sharing the database. Items that expire are streamed as deleted.
+func pollWatch(ctx context.Context, interval time.Duration, logger log.Logger, list listFunc) (<-chan Event, error) {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	prev, err := list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			cur, err := list(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Warn("Failed to list watched kvstore items", "error", err)
+				}
+				continue
+			}
+
+			for _, e := range diffItems(prev, cur) {
+				select {
+				case events <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+			prev = cur
+		}
+	}()
+
+	return events, nil
+}
+
+// diffItems returns the events that turn prev into cur, sorted by key.
+func diffItems(prev, cur map[Key]VersionedValue) []Event {
+	var events []Event
+	for k, v := range cur {
+		if p, ok := prev[k]; !ok || p.Version != v.Version || p.Value != v.Value {
+			events = append(events, Event{Type: EventPut, Key: k, Value: v.Value, Version: v.Version})
+		}
+	}
+	for k := range prev {
+		if _, ok := cur[k]; !ok {
+			events = append(events, Event{Type: EventDelete, Key: k})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		a, b := events[i].Key, events[j].Key
+		if a.OrgId != b.OrgId {
+			return a.OrgId < b.OrgId
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Key < b.Key
+	})
+	return events
+}
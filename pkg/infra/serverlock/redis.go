@@ -0,0 +1,195 @@
+package serverlock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// renewScript extends the expiry of a lock if it is still held with the given token.
+var renewScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("pexpire", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseScript deletes a lock if it is still held with the given token.
+var releaseScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0
+`)
+
+type fencingTokenKey struct{}
+
+// FencingToken returns the fencing token of the lock the function executed by the service holds.
+// Tokens of an action increase every time its lock is taken, so a storage written to by the function
+// can reject writes made with a token lower than the last one it saw. Only the redis backend sets it.
+func FencingToken(ctx context.Context) (int64, bool) {
+	token, ok := ctx.Value(fencingTokenKey{}).(int64)
+	return token, ok
+}
+
+// redisBackend stores the locks as redis keys that expire after maxInterval. Locks taken with acquire
+// are leases renewed in the background until they are released.
+type redisBackend struct {
+	client *redis.Client
+	prefix string
+	log    log.Logger
+
+	mu     sync.Mutex
+	leases map[string]*redisLease
+}
+
+type redisLease struct {
+	token string
+	stop  context.CancelFunc
+	done  chan struct{}
+}
+
+func newRedisBackend(cfg setting.ServerLockSettings, logger log.Logger) (*redisBackend, error) {
+	if cfg.RedisConnStr == "" {
+		return nil, errors.New("redis_connstr is required")
+	}
+	opts, err := remotecache.ParseRedisConnStr(cfg.RedisConnStr)
+	if err != nil {
+		return nil, err
+	}
+	return &redisBackend{
+		client: redis.NewClient(opts),
+		prefix: cfg.RedisPrefix,
+		log:    logger,
+		leases: map[string]*redisLease{},
+	}, nil
+}
+
+func (b *redisBackend) lockKey(actionName string) string {
+	return b.prefix + "lock:" + actionName
+}
+
+func (b *redisBackend) executionKey(actionName string) string {
+	return b.prefix + "execution:" + actionName
+}
+
+func (b *redisBackend) fencingKey(actionName string) string {
+	return b.prefix + "fencing:" + actionName
+}
+
+// nextToken returns a new fencing token for actionName.
+func (b *redisBackend) nextToken(ctx context.Context, actionName string) (int64, error) {
+	token, err := b.client.Incr(ctx, b.fencingKey(actionName)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get a fencing token: %w", err)
+	}
+	return token, nil
+}
+
+func (b *redisBackend) lockAndRecord(ctx context.Context, actionName string, maxInterval time.Duration) (context.Context, bool, error) {
+	token, err := b.nextToken(ctx, actionName)
+	if err != nil {
+		return ctx, false, err
+	}
+
+	// the key only exists while the last execution happened less than `maxInterval` ago
+	if maxInterval < time.Millisecond {
+		maxInterval = time.Millisecond
+	}
+	acquiredLock, err := b.client.SetNX(ctx, b.executionKey(actionName), token, maxInterval).Result()
+	if err != nil {
+		return ctx, false, fmt.Errorf("failed to acquire serverlock: %w", err)
+	}
+	return context.WithValue(ctx, fencingTokenKey{}, token), acquiredLock, nil
+}
+
+func (b *redisBackend) acquire(ctx context.Context, actionName string, maxInterval time.Duration) (context.Context, error) {
+	if maxInterval <= 0 {
+		return ctx, fmt.Errorf("maxInterval must be positive, got %s", maxInterval)
+	}
+
+	token, err := b.nextToken(ctx, actionName)
+	if err != nil {
+		return ctx, err
+	}
+	value := strconv.FormatInt(token, 10)
+
+	acquiredLock, err := b.client.SetNX(ctx, b.lockKey(actionName), value, maxInterval).Result()
+	if err != nil {
+		return ctx, fmt.Errorf("failed to acquire serverlock: %w", err)
+	}
+	if !acquiredLock {
+		return ctx, &ServerLockExistsError{actionName: actionName}
+	}
+
+	// the function is canceled if the lease is lost
+	lockCtx, cancel := context.WithCancel(context.WithValue(ctx, fencingTokenKey{}, token))
+	renewCtx, stop := context.WithCancel(context.Background())
+	lease := &redisLease{token: value, stop: stop, done: make(chan struct{})}
+
+	b.mu.Lock()
+	b.leases[actionName] = lease
+	b.mu.Unlock()
+
+	go func() {
+		defer close(lease.done)
+		defer cancel()
+		b.renew(renewCtx, actionName, value, maxInterval)
+	}()
+
+	return lockCtx, nil
+}
+
+// renew extends the lease of the lock every third of maxInterval until ctx is done or the lease is lost.
+func (b *redisBackend) renew(ctx context.Context, actionName, token string, maxInterval time.Duration) {
+	ticker := time.NewTicker(maxInterval / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			renewed, err := renewScript.Run(ctx, b.client, []string{b.lockKey(actionName)}, token, maxInterval.Milliseconds()).Int()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				b.log.Warn("Failed to renew the lock", "actionName", actionName, "error", err)
+				continue
+			}
+			if renewed == 0 {
+				b.log.Warn("Lost the lock before it was released", "actionName", actionName)
+				return
+			}
+		}
+	}
+}
+
+func (b *redisBackend) release(ctx context.Context, actionName string) error {
+	b.mu.Lock()
+	lease, ok := b.leases[actionName]
+	delete(b.leases, actionName)
+	b.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	lease.stop()
+	<-lease.done
+
+	if err := releaseScript.Run(ctx, b.client, []string{b.lockKey(actionName)}, lease.token).Err(); err != nil {
+		return fmt.Errorf("failed to release serverlock: %w", err)
+	}
+	return nil
+}
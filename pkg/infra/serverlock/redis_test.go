@@ -0,0 +1,140 @@
+package serverlock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func createTestableRedisServerLock(t *testing.T) (*ServerLockService, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	backend, err := newRedisBackend(setting.ServerLockSettings{
+		RedisConnStr: "addr=" + mr.Addr(),
+		RedisPrefix:  "serverlock:",
+	}, log.New("test-logger"))
+	require.NoError(t, err)
+
+	return &ServerLockService{
+		tracer:  tracing.InitializeTracerForTest(),
+		log:     log.New("test-logger"),
+		backend: backend,
+	}, mr
+}
+
+func TestProvideService(t *testing.T) {
+	t.Run("database is the default backend", func(t *testing.T) {
+		sl, err := ProvideService(nil, tracing.InitializeTracerForTest(), setting.NewCfg())
+		require.NoError(t, err)
+		assert.Nil(t, sl.backend)
+	})
+
+	t.Run("redis backend requires a connection string", func(t *testing.T) {
+		cfg := setting.NewCfg()
+		cfg.ServerLock.Backend = setting.ServerLockBackendRedis
+		_, err := ProvideService(nil, tracing.InitializeTracerForTest(), cfg)
+		require.ErrorContains(t, err, "redis_connstr")
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		cfg := setting.NewCfg()
+		cfg.ServerLock.Backend = "etcd"
+		_, err := ProvideService(nil, tracing.InitializeTracerForTest(), cfg)
+		require.ErrorContains(t, err, "unknown server lock backend")
+	})
+}
+
+func TestRedisLockAndExecute(t *testing.T) {
+	sl, _ := createTestableRedisServerLock(t)
+	ctx := context.Background()
+
+	calls := 0
+	var tokens []int64
+	fn := func(ctx context.Context) {
+		calls++
+		token, ok := FencingToken(ctx)
+		require.True(t, ok)
+		tokens = append(tokens, token)
+	}
+
+	require.NoError(t, sl.LockAndExecute(ctx, "test-operation", time.Hour, fn))
+	require.NoError(t, sl.LockAndExecute(ctx, "test-operation", time.Hour, fn))
+	assert.Equal(t, 1, calls)
+
+	require.NoError(t, sl.LockAndExecute(ctx, "other-operation", time.Hour, fn))
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []int64{1, 1}, tokens)
+}
+
+func TestRedisLockExecuteAndRelease(t *testing.T) {
+	sl, mr := createTestableRedisServerLock(t)
+	ctx := context.Background()
+
+	var tokens []int64
+	err := sl.LockExecuteAndRelease(ctx, "test-operation", time.Hour, func(ctx context.Context) {
+		token, _ := FencingToken(ctx)
+		tokens = append(tokens, token)
+
+		// the lock is held while the function executes
+		err := sl.LockExecuteAndRelease(ctx, "test-operation", time.Hour, func(context.Context) {})
+		var lockedErr *ServerLockExistsError
+		require.ErrorAs(t, err, &lockedErr)
+	})
+	require.NoError(t, err)
+	assert.False(t, mr.Exists("serverlock:lock:test-operation"))
+
+	err = sl.LockExecuteAndReleaseWithRetries(ctx, "test-operation", LockTimeConfig{
+		MaxInterval: time.Hour,
+		MinWait:     time.Millisecond,
+		MaxWait:     time.Millisecond,
+	}, func(ctx context.Context) {
+		token, _ := FencingToken(ctx)
+		tokens = append(tokens, token)
+	})
+	require.NoError(t, err)
+
+	// the failed attempt also took a token
+	assert.Equal(t, []int64{1, 3}, tokens)
+}
+
+func TestRedisLeaseRenewal(t *testing.T) {
+	sl, mr := createTestableRedisServerLock(t)
+	backend := sl.backend.(*redisBackend)
+	key := "serverlock:lock:test-operation"
+
+	lockCtx, err := backend.acquire(context.Background(), "test-operation", 300*time.Millisecond)
+	require.NoError(t, err)
+
+	t.Run("the lease is renewed while held", func(t *testing.T) {
+		mr.FastForward(200 * time.Millisecond)
+		require.Eventually(t, func() bool {
+			return mr.TTL(key) > 200*time.Millisecond
+		}, time.Second, 10*time.Millisecond)
+		require.NoError(t, lockCtx.Err())
+	})
+
+	t.Run("losing the lease cancels the context", func(t *testing.T) {
+		mr.Del(key)
+		require.Eventually(t, func() bool {
+			return lockCtx.Err() != nil
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("release does not delete a lock taken by another server", func(t *testing.T) {
+		require.NoError(t, mr.Set(key, "other"))
+		require.NoError(t, backend.release(context.Background(), "test-operation"))
+		assert.True(t, mr.Exists(key))
+	})
+}
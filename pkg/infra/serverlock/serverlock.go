@@ -15,14 +15,29 @@ import (
 	"github.com/grafana/grafana/pkg/infra/tracing"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
 	"github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
-func ProvideService(sqlStore db.DB, tracer tracing.Tracer) *ServerLockService {
-	return &ServerLockService{
+func ProvideService(sqlStore db.DB, tracer tracing.Tracer, cfg *setting.Cfg) (*ServerLockService, error) {
+	sl := &ServerLockService{
 		SQLStore: sqlStore,
 		tracer:   tracer,
 		log:      log.New("infra.lockservice"),
 	}
+
+	switch cfg.ServerLock.Backend {
+	case "", setting.ServerLockBackendDatabase:
+	case setting.ServerLockBackendRedis:
+		backend, err := newRedisBackend(cfg.ServerLock, sl.log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the redis server lock backend: %w", err)
+		}
+		sl.backend = backend
+	default:
+		return nil, fmt.Errorf("unknown server lock backend %q", cfg.ServerLock.Backend)
+	}
+
+	return sl, nil
 }
 
 // ServerLockService allows servers in HA mode to claim a lock and execute a function if the server was granted the lock
@@ -32,6 +47,60 @@ type ServerLockService struct {
 	SQLStore db.DB
 	tracer   tracing.Tracer
 	log      log.Logger
+	// backend stores the locks, the SQL database is used when it is nil
+	backend lockBackend
+}
+
+// lockBackend is where the locks of the service are stored.
+type lockBackend interface {
+	// lockAndRecord records an execution of actionName and returns true if there was none within maxInterval.
+	// The returned context is the one to execute the function with.
+	lockAndRecord(ctx context.Context, actionName string, maxInterval time.Duration) (context.Context, bool, error)
+	// acquire takes the lock of actionName, or returns a ServerLockExistsError if it is already taken and was
+	// taken or renewed less than maxInterval ago. The returned context is the one to execute the function with.
+	acquire(ctx context.Context, actionName string, maxInterval time.Duration) (context.Context, error)
+	// release releases the lock of actionName taken with acquire.
+	release(ctx context.Context, actionName string) error
+}
+
+func (sl *ServerLockService) lockBackend() lockBackend {
+	if sl.backend == nil {
+		return &sqlBackend{sl: sl}
+	}
+	return sl.backend
+}
+
+// sqlBackend stores the locks as rows of the server_lock table.
+type sqlBackend struct {
+	sl *ServerLockService
+}
+
+func (b *sqlBackend) lockAndRecord(ctx context.Context, actionName string, maxInterval time.Duration) (context.Context, bool, error) {
+	// gets or creates a lockable row
+	rowLock, err := b.sl.getOrCreate(ctx, actionName)
+	if err != nil {
+		return ctx, false, fmt.Errorf("failed to getOrCreate serverlock: %w", err)
+	}
+
+	// avoid execution if last lock happened less than `maxInterval` ago
+	if b.sl.isLockWithinInterval(rowLock, maxInterval) {
+		return ctx, false, nil
+	}
+
+	// try to get lock based on rowLock version
+	acquiredLock, err := b.sl.acquireLock(ctx, rowLock)
+	if err != nil {
+		return ctx, false, fmt.Errorf("failed to acquire serverlock: %w", err)
+	}
+	return ctx, acquiredLock, nil
+}
+
+func (b *sqlBackend) acquire(ctx context.Context, actionName string, maxInterval time.Duration) (context.Context, error) {
+	return ctx, b.sl.acquireForRelease(ctx, actionName, maxInterval)
+}
+
+func (b *sqlBackend) release(ctx context.Context, actionName string) error {
+	return b.sl.releaseLock(ctx, actionName)
 }
 
 // LockAndExecute try to create a lock for this server and only executes the
@@ -46,29 +115,15 @@ func (sl *ServerLockService) LockAndExecute(ctx context.Context, actionName stri
 	ctxLogger := sl.log.FromContext(ctx)
 	ctxLogger.Debug("Start LockAndExecute", "actionName", actionName)
 
-	// gets or creates a lockable row
-	rowLock, err := sl.getOrCreate(ctx, actionName)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, fmt.Sprintf("failed to getOrCreate serverlock: %v", err))
-		return err
-	}
-
-	// avoid execution if last lock happened less than `maxInterval` ago
-	if sl.isLockWithinInterval(rowLock, maxInterval) {
-		return nil
-	}
-
-	// try to get lock based on rowLock version
-	acquiredLock, err := sl.acquireLock(ctx, rowLock)
+	lockCtx, acquiredLock, err := sl.lockBackend().lockAndRecord(ctx, actionName, maxInterval)
 	if err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, fmt.Sprintf("failed to acquire serverlock: %v", err))
+		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
 
 	if acquiredLock {
-		sl.executeFunc(ctx, actionName, fn)
+		sl.executeFunc(lockCtx, actionName, fn)
 	}
 
 	ctxLogger.Debug("LockAndExecute finished", "actionName", actionName, "acquiredLock", acquiredLock, "duration", time.Since(start))
@@ -147,7 +202,7 @@ func (sl *ServerLockService) LockExecuteAndRelease(ctx context.Context, actionNa
 	ctxLogger := sl.log.FromContext(ctx)
 	ctxLogger.Debug("Start LockExecuteAndRelease", "actionName", actionName)
 
-	err := sl.acquireForRelease(ctx, actionName, maxInterval)
+	lockCtx, err := sl.lockBackend().acquire(ctx, actionName, maxInterval)
 	// could not get the lock, returning
 	if err != nil {
 		span.RecordError(err)
@@ -155,9 +210,9 @@ func (sl *ServerLockService) LockExecuteAndRelease(ctx context.Context, actionNa
 		return err
 	}
 
-	sl.executeFunc(ctx, actionName, fn)
+	sl.executeFunc(lockCtx, actionName, fn)
 
-	err = sl.releaseLock(ctx, actionName)
+	err = sl.lockBackend().release(ctx, actionName)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, fmt.Sprintf("failed to release serverlock: %v", err))
@@ -190,10 +245,12 @@ func (sl *ServerLockService) LockExecuteAndReleaseWithRetries(ctx context.Contex
 	ctxLogger.Debug("Start LockExecuteAndReleaseWithRetries", "actionName", actionName)
 
 	lockChecks := 0
+	var lockCtx context.Context
 
 	for {
 		lockChecks++
-		err := sl.acquireForRelease(ctx, actionName, timeConfig.MaxInterval)
+		var err error
+		lockCtx, err = sl.lockBackend().acquire(ctx, actionName, timeConfig.MaxInterval)
 		// could not get the lock
 		if err != nil {
 			var lockedErr *ServerLockExistsError
@@ -221,9 +278,9 @@ func (sl *ServerLockService) LockExecuteAndReleaseWithRetries(ctx context.Contex
 		break
 	}
 
-	sl.executeFunc(ctx, actionName, fn)
+	sl.executeFunc(lockCtx, actionName, fn)
 
-	if err := sl.releaseLock(ctx, actionName); err != nil {
+	if err := sl.lockBackend().release(ctx, actionName); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, fmt.Sprintf("failed to release serverlock: %v", err))
 		ctxLogger.Error("Failed to release the lock", "error", err)
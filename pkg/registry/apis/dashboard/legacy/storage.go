@@ -311,6 +311,11 @@ func (a *dashboardSqlAccess) History(ctx context.Context, req *resource.HistoryR
 	return list, err
 }
 
+// Search is handled by the resource server with the list iterator
+func (a *dashboardSqlAccess) Search(context.Context, *resource.SearchRequest) (*resource.SearchResponse, error) {
+	return nil, fmt.Errorf("not yet (search)")
+}
+
 // Used for efficient provisioning
 func (a *dashboardSqlAccess) Origin(context.Context, *resource.OriginRequest) (*resource.OriginResponse, error) {
 	return nil, fmt.Errorf("not yet (origin)")
@@ -159,6 +159,10 @@ func (b *DashboardsAPIBuilder) GetAPIGroupInfo(
 		b.legacy.server, // as client???
 		dashboard.DashboardResourceInfo.GroupResource(),
 	)
+	storage[dash.StoragePath("restore")] = apistore.NewRestoreConnector(
+		b.legacy.server, // as client???
+		dashboard.DashboardResourceInfo.GroupResource(),
+	)
 
 	// Dual writes if a RESTOptionsGetter is provided
 	if optsGetter != nil && dualWriteBuilder != nil {
@@ -2,8 +2,20 @@ package caching
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+	"github.com/grafana/grafana/pkg/services/contexthandler"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
 const (
@@ -13,8 +25,21 @@ const (
 	StatusBypass   = "BYPASS"
 	StatusError    = "ERROR"
 	StatusDisabled = "DISABLED"
+
+	// XCacheSkipHeader can be sent by clients to bypass the cache for a single request
+	XCacheSkipHeader = "X-Cache-Skip"
+
+	// queryCachingTTLKey is the data source JSON data option that overrides the default query TTL, in milliseconds.
+	// A negative value disables caching for the data source.
+	queryCachingTTLKey = "queryCachingTTL"
+
+	queryKeyPrefix    = "query-cache:"
+	resourceKeyPrefix = "resource-cache:"
 )
 
+// volatileQueryFields are query properties set by the frontend which do not change the result of a query
+var volatileQueryFields = []string{"requestId", "datasource", "datasourceId", "key", "hide", "utcOffsetSec", "queryCachingTTL"}
+
 type CacheQueryResponseFn func(context.Context, *backend.QueryDataResponse)
 type CacheResourceResponseFn func(context.Context, *backend.CallResourceResponse)
 
@@ -35,8 +60,12 @@ type CachedResourceDataResponse struct {
 	UpdateCacheFn CacheResourceResponseFn
 }
 
-func ProvideCachingService() *OSSCachingService {
-	return &OSSCachingService{}
+func ProvideCachingService(cfg *setting.Cfg, cache remotecache.CacheStorage) *OSSCachingService {
+	return &OSSCachingService{
+		settings: cfg.Caching,
+		cache:    cache,
+		log:      log.New("caching"),
+	}
 }
 
 type CachingService interface {
@@ -49,16 +78,266 @@ type CachingService interface {
 	HandleResourceRequest(context.Context, *backend.CallResourceRequest) (bool, CachedResourceDataResponse)
 }
 
-// Implementation of interface - does nothing
+// OSSCachingService caches query and resource responses in the remote cache.
+// It does nothing unless caching is enabled in the [caching] configuration section.
 type OSSCachingService struct {
+	settings setting.CachingSettings
+	cache    remotecache.CacheStorage
+	log      log.Logger
 }
 
 func (s *OSSCachingService) HandleQueryRequest(ctx context.Context, req *backend.QueryDataRequest) (bool, CachedQueryDataResponse) {
-	return false, CachedQueryDataResponse{}
+	if !s.settings.Enabled || s.cache == nil || req == nil {
+		return false, CachedQueryDataResponse{}
+	}
+
+	ttl := s.queryTTL(req.PluginContext.DataSourceInstanceSettings)
+	if ttl <= 0 {
+		setCacheStatus(ctx, StatusDisabled)
+		return false, CachedQueryDataResponse{}
+	}
+
+	if skipCache(ctx) || req.GetHTTPHeader(backend.OAuthIdentityTokenHeaderName) != "" {
+		setCacheStatus(ctx, StatusBypass)
+		return false, CachedQueryDataResponse{}
+	}
+
+	key, err := queryCacheKey(req, ttl)
+	if err != nil {
+		s.log.FromContext(ctx).Warn("Failed to build query cache key", "error", err)
+		setCacheStatus(ctx, StatusError)
+		return false, CachedQueryDataResponse{}
+	}
+
+	updateFn := func(ctx context.Context, resp *backend.QueryDataResponse) {
+		if resp == nil || hasErrors(resp) {
+			return
+		}
+		b, err := json.Marshal(resp)
+		if err != nil {
+			s.log.FromContext(ctx).Warn("Failed to encode query response", "error", err)
+			return
+		}
+		s.set(ctx, key, b, ttl)
+	}
+
+	b, err := s.cache.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, remotecache.ErrCacheItemNotFound) {
+			s.log.FromContext(ctx).Warn("Failed to read query response from cache", "error", err)
+			setCacheStatus(ctx, StatusError)
+			return false, CachedQueryDataResponse{UpdateCacheFn: updateFn}
+		}
+		setCacheStatus(ctx, StatusMiss)
+		return false, CachedQueryDataResponse{UpdateCacheFn: updateFn}
+	}
+
+	resp := &backend.QueryDataResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		s.log.FromContext(ctx).Warn("Failed to decode cached query response", "error", err)
+		setCacheStatus(ctx, StatusError)
+		return false, CachedQueryDataResponse{UpdateCacheFn: updateFn}
+	}
+
+	setCacheStatus(ctx, StatusHit)
+	return true, CachedQueryDataResponse{Response: resp}
 }
 
 func (s *OSSCachingService) HandleResourceRequest(ctx context.Context, req *backend.CallResourceRequest) (bool, CachedResourceDataResponse) {
-	return false, CachedResourceDataResponse{}
+	if !s.settings.Enabled || s.cache == nil || req == nil || s.settings.ResourcesTTL <= 0 {
+		return false, CachedResourceDataResponse{}
+	}
+
+	// Only idempotent requests can be served from the cache
+	if req.Method != http.MethodGet {
+		return false, CachedResourceDataResponse{}
+	}
+
+	if skipCache(ctx) || len(req.Headers[backend.OAuthIdentityTokenHeaderName]) > 0 {
+		setCacheStatus(ctx, StatusBypass)
+		return false, CachedResourceDataResponse{}
+	}
+
+	key, err := resourceCacheKey(req)
+	if err != nil {
+		s.log.FromContext(ctx).Warn("Failed to build resource cache key", "error", err)
+		setCacheStatus(ctx, StatusError)
+		return false, CachedResourceDataResponse{}
+	}
+
+	// Streamed responses are not cached: if the plugin sends more than one response, the cached entry is dropped.
+	responses := 0
+	updateFn := func(ctx context.Context, resp *backend.CallResourceResponse) {
+		responses++
+		if responses > 1 {
+			if err := s.cache.Delete(ctx, key); err != nil && !errors.Is(err, remotecache.ErrCacheItemNotFound) {
+				s.log.FromContext(ctx).Warn("Failed to delete resource response from cache", "error", err)
+			}
+			return
+		}
+		if resp == nil || resp.Status < http.StatusOK || resp.Status >= http.StatusMultipleChoices {
+			return
+		}
+		b, err := json.Marshal(resp)
+		if err != nil {
+			s.log.FromContext(ctx).Warn("Failed to encode resource response", "error", err)
+			return
+		}
+		s.set(ctx, key, b, s.settings.ResourcesTTL)
+	}
+
+	b, err := s.cache.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, remotecache.ErrCacheItemNotFound) {
+			s.log.FromContext(ctx).Warn("Failed to read resource response from cache", "error", err)
+			setCacheStatus(ctx, StatusError)
+			return false, CachedResourceDataResponse{UpdateCacheFn: updateFn}
+		}
+		setCacheStatus(ctx, StatusMiss)
+		return false, CachedResourceDataResponse{UpdateCacheFn: updateFn}
+	}
+
+	resp := &backend.CallResourceResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		s.log.FromContext(ctx).Warn("Failed to decode cached resource response", "error", err)
+		setCacheStatus(ctx, StatusError)
+		return false, CachedResourceDataResponse{UpdateCacheFn: updateFn}
+	}
+
+	setCacheStatus(ctx, StatusHit)
+	return true, CachedResourceDataResponse{Response: resp}
+}
+
+func (s *OSSCachingService) set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	if s.settings.MaxValueSize > 0 && len(value) > s.settings.MaxValueSize {
+		s.log.FromContext(ctx).Debug("Response too large to be cached", "size", len(value), "maxSize", s.settings.MaxValueSize)
+		return
+	}
+	if err := s.cache.Set(ctx, key, value, ttl); err != nil {
+		s.log.FromContext(ctx).Warn("Failed to write response to cache", "error", err)
+	}
+}
+
+// queryTTL returns the cache TTL for the given data source, falling back to the configured default.
+func (s *OSSCachingService) queryTTL(ds *backend.DataSourceInstanceSettings) time.Duration {
+	if ds == nil {
+		return 0
+	}
+	var jsonData map[string]any
+	if len(ds.JSONData) > 0 {
+		if err := json.Unmarshal(ds.JSONData, &jsonData); err != nil {
+			return s.settings.TTL
+		}
+	}
+	switch v := jsonData[queryCachingTTLKey].(type) {
+	case float64:
+		if v != 0 {
+			return time.Duration(v) * time.Millisecond
+		}
+	case string:
+		if ms, err := strconv.ParseInt(v, 10, 64); err == nil && ms != 0 {
+			return time.Duration(ms) * time.Millisecond
+		}
+	}
+	return s.settings.TTL
+}
+
+type normalizedQuery struct {
+	RefID         string         `json:"refId"`
+	QueryType     string         `json:"queryType"`
+	MaxDataPoints int64          `json:"maxDataPoints"`
+	Interval      time.Duration  `json:"interval"`
+	From          int64          `json:"from"`
+	To            int64          `json:"to"`
+	Model         map[string]any `json:"model"`
+}
+
+// queryCacheKey builds a key from the data source and the normalized queries.
+// The time range is truncated to the TTL so that relative ranges such as "now-6h"
+// map to the same key for the lifetime of the cached entry.
+func queryCacheKey(req *backend.QueryDataRequest, ttl time.Duration) (string, error) {
+	queries := make([]normalizedQuery, 0, len(req.Queries))
+	for _, q := range req.Queries {
+		model := map[string]any{}
+		if len(q.JSON) > 0 {
+			if err := json.Unmarshal(q.JSON, &model); err != nil {
+				return "", err
+			}
+		}
+		for _, f := range volatileQueryFields {
+			delete(model, f)
+		}
+		queries = append(queries, normalizedQuery{
+			RefID:         q.RefID,
+			QueryType:     q.QueryType,
+			MaxDataPoints: q.MaxDataPoints,
+			Interval:      q.Interval,
+			From:          q.TimeRange.From.Truncate(ttl).UnixMilli(),
+			To:            q.TimeRange.To.Truncate(ttl).UnixMilli(),
+			Model:         model,
+		})
+	}
+
+	return hashKey(queryKeyPrefix, req.PluginContext, queries)
+}
+
+func resourceCacheKey(req *backend.CallResourceRequest) (string, error) {
+	return hashKey(resourceKeyPrefix, req.PluginContext, struct {
+		Path string `json:"path"`
+		URL  string `json:"url"`
+	}{Path: req.Path, URL: req.URL})
+}
+
+func hashKey(prefix string, pCtx backend.PluginContext, v any) (string, error) {
+	key := struct {
+		OrgID      int64  `json:"orgId"`
+		PluginID   string `json:"pluginId"`
+		UID        string `json:"uid,omitempty"`
+		Updated    int64  `json:"updated,omitempty"`
+		Parameters any    `json:"parameters"`
+	}{
+		OrgID:      pCtx.OrgID,
+		PluginID:   pCtx.PluginID,
+		Parameters: v,
+	}
+	// Including the update time invalidates cached entries when the data source is modified
+	if ds := pCtx.DataSourceInstanceSettings; ds != nil {
+		key.UID = ds.UID
+		key.Updated = ds.Updated.UnixMilli()
+	}
+
+	// encoding/json sorts map keys, which makes the encoding of the query models stable
+	b, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return prefix + hex.EncodeToString(sum[:]), nil
+}
+
+func hasErrors(resp *backend.QueryDataResponse) bool {
+	for _, r := range resp.Responses {
+		if r.Error != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func skipCache(ctx context.Context) bool {
+	reqCtx := contexthandler.FromContext(ctx)
+	if reqCtx == nil || reqCtx.Req == nil {
+		return false
+	}
+	return reqCtx.Req.Header.Get(XCacheSkipHeader) == "true"
+}
+
+func setCacheStatus(ctx context.Context, status string) {
+	reqCtx := contexthandler.FromContext(ctx)
+	if reqCtx == nil || reqCtx.Resp == nil {
+		return
+	}
+	reqCtx.Resp.Header().Set(XCacheHeader, status)
 }
 
 var _ CachingService = &OSSCachingService{}
@@ -0,0 +1,211 @@
+package caching
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+	"github.com/grafana/grafana/pkg/services/contexthandler/ctxkey"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func TestHandleQueryRequest(t *testing.T) {
+	now := time.Date(2024, 8, 1, 12, 3, 0, 0, time.UTC)
+
+	newRequest := func(jsonData string, model string, from time.Time) *backend.QueryDataRequest {
+		return &backend.QueryDataRequest{
+			PluginContext: backend.PluginContext{
+				OrgID:    1,
+				PluginID: "prometheus",
+				DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{
+					UID:      "ds1",
+					JSONData: json.RawMessage(jsonData),
+				},
+			},
+			Queries: []backend.DataQuery{
+				{
+					RefID:     "A",
+					TimeRange: backend.TimeRange{From: from, To: from.Add(time.Hour)},
+					JSON:      json.RawMessage(model),
+				},
+			},
+		}
+	}
+
+	response := &backend.QueryDataResponse{
+		Responses: backend.Responses{
+			"A": backend.DataResponse{Frames: data.Frames{data.NewFrame("A", data.NewField("value", nil, []float64{1, 2}))}},
+		},
+	}
+
+	t.Run("does nothing when caching is disabled", func(t *testing.T) {
+		s := newTestService(false)
+		ctx, rec := newTestContext(t, "")
+
+		hit, cr := s.HandleQueryRequest(ctx, newRequest(`{}`, `{"expr":"up"}`, now))
+		assert.False(t, hit)
+		assert.Nil(t, cr.UpdateCacheFn)
+		assert.Empty(t, rec.Header().Get(XCacheHeader))
+	})
+
+	t.Run("returns a miss and then a hit once the response is cached", func(t *testing.T) {
+		s := newTestService(true)
+
+		ctx, rec := newTestContext(t, "")
+		hit, cr := s.HandleQueryRequest(ctx, newRequest(`{}`, `{"expr":"up","requestId":"1"}`, now))
+		require.False(t, hit)
+		require.NotNil(t, cr.UpdateCacheFn)
+		assert.Equal(t, StatusMiss, rec.Header().Get(XCacheHeader))
+		cr.UpdateCacheFn(ctx, response)
+
+		// Volatile fields and time ranges within the same TTL window map to the same key
+		ctx, rec = newTestContext(t, "")
+		hit, cr = s.HandleQueryRequest(ctx, newRequest(`{}`, `{"requestId":"2","expr":"up"}`, now.Add(time.Minute)))
+		require.True(t, hit)
+		assert.Equal(t, StatusHit, rec.Header().Get(XCacheHeader))
+		require.Contains(t, cr.Response.Responses, "A")
+		assert.Equal(t, 2, cr.Response.Responses["A"].Frames[0].Rows())
+	})
+
+	t.Run("different queries do not share cache entries", func(t *testing.T) {
+		s := newTestService(true)
+
+		ctx, _ := newTestContext(t, "")
+		_, cr := s.HandleQueryRequest(ctx, newRequest(`{}`, `{"expr":"up"}`, now))
+		cr.UpdateCacheFn(ctx, response)
+
+		hit, _ := s.HandleQueryRequest(ctx, newRequest(`{}`, `{"expr":"down"}`, now))
+		assert.False(t, hit)
+		hit, _ = s.HandleQueryRequest(ctx, newRequest(`{}`, `{"expr":"up"}`, now.Add(-time.Hour)))
+		assert.False(t, hit)
+	})
+
+	t.Run("responses with errors are not cached", func(t *testing.T) {
+		s := newTestService(true)
+
+		ctx, _ := newTestContext(t, "")
+		_, cr := s.HandleQueryRequest(ctx, newRequest(`{}`, `{"expr":"up"}`, now))
+		cr.UpdateCacheFn(ctx, &backend.QueryDataResponse{Responses: backend.Responses{"A": backend.ErrDataResponse(backend.StatusBadRequest, "bad")}})
+
+		hit, _ := s.HandleQueryRequest(ctx, newRequest(`{}`, `{"expr":"up"}`, now))
+		assert.False(t, hit)
+	})
+
+	t.Run("data sources can disable caching with a negative TTL", func(t *testing.T) {
+		s := newTestService(true)
+
+		ctx, rec := newTestContext(t, "")
+		hit, cr := s.HandleQueryRequest(ctx, newRequest(`{"queryCachingTTL":-1}`, `{"expr":"up"}`, now))
+		assert.False(t, hit)
+		assert.Nil(t, cr.UpdateCacheFn)
+		assert.Equal(t, StatusDisabled, rec.Header().Get(XCacheHeader))
+	})
+
+	t.Run("data sources can override the TTL", func(t *testing.T) {
+		s := newTestService(true)
+		assert.Equal(t, time.Minute, s.queryTTL(&backend.DataSourceInstanceSettings{JSONData: json.RawMessage(`{"queryCachingTTL":60000}`)}))
+		assert.Equal(t, 5*time.Minute, s.queryTTL(&backend.DataSourceInstanceSettings{JSONData: json.RawMessage(`{}`)}))
+	})
+
+	t.Run("the cache is bypassed when requested by the client", func(t *testing.T) {
+		s := newTestService(true)
+
+		ctx, rec := newTestContext(t, "true")
+		hit, cr := s.HandleQueryRequest(ctx, newRequest(`{}`, `{"expr":"up"}`, now))
+		assert.False(t, hit)
+		assert.Nil(t, cr.UpdateCacheFn)
+		assert.Equal(t, StatusBypass, rec.Header().Get(XCacheHeader))
+	})
+}
+
+func TestHandleResourceRequest(t *testing.T) {
+	newRequest := func(method string) *backend.CallResourceRequest {
+		return &backend.CallResourceRequest{
+			PluginContext: backend.PluginContext{
+				OrgID:                      1,
+				PluginID:                   "prometheus",
+				DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{UID: "ds1"},
+			},
+			Path:   "api/v1/labels",
+			Method: method,
+			URL:    "api/v1/labels?match=up",
+		}
+	}
+
+	t.Run("only caches GET requests", func(t *testing.T) {
+		s := newTestService(true)
+		ctx, _ := newTestContext(t, "")
+
+		hit, cr := s.HandleResourceRequest(ctx, newRequest(http.MethodPost))
+		assert.False(t, hit)
+		assert.Nil(t, cr.UpdateCacheFn)
+	})
+
+	t.Run("returns a hit once the response is cached", func(t *testing.T) {
+		s := newTestService(true)
+		ctx, _ := newTestContext(t, "")
+
+		hit, cr := s.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		require.False(t, hit)
+		cr.UpdateCacheFn(ctx, &backend.CallResourceResponse{Status: http.StatusOK, Body: []byte(`{"status":"success"}`)})
+
+		ctx, rec := newTestContext(t, "")
+		hit, cr = s.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		require.True(t, hit)
+		assert.Equal(t, StatusHit, rec.Header().Get(XCacheHeader))
+		assert.Equal(t, `{"status":"success"}`, string(cr.Response.Body))
+	})
+
+	t.Run("streamed responses are not cached", func(t *testing.T) {
+		s := newTestService(true)
+		ctx, _ := newTestContext(t, "")
+
+		_, cr := s.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		cr.UpdateCacheFn(ctx, &backend.CallResourceResponse{Status: http.StatusOK, Body: []byte("1")})
+		cr.UpdateCacheFn(ctx, &backend.CallResourceResponse{Status: http.StatusOK, Body: []byte("2")})
+
+		hit, _ := s.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		assert.False(t, hit)
+	})
+}
+
+func newTestService(enabled bool) *OSSCachingService {
+	cfg := setting.NewCfg()
+	cfg.Caching = setting.CachingSettings{
+		Enabled:      enabled,
+		TTL:          5 * time.Minute,
+		ResourcesTTL: time.Minute,
+		MaxValueSize: 1024 * 1024,
+	}
+	return ProvideCachingService(cfg, remotecache.NewFakeCacheStorage())
+}
+
+func newTestContext(t *testing.T, skipHeader string) (context.Context, *httptest.ResponseRecorder) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/api/ds/query", nil)
+	require.NoError(t, err)
+	if skipHeader != "" {
+		req.Header.Set(XCacheSkipHeader, skipHeader)
+	}
+
+	rec := httptest.NewRecorder()
+	reqCtx := &contextmodel.ReqContext{
+		Context: &web.Context{
+			Req:  req,
+			Resp: web.NewResponseWriter(req.Method, rec),
+		},
+	}
+	return ctxkey.Set(context.Background(), reqCtx), rec
+}
@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/serverlock"
 	"github.com/grafana/grafana/pkg/infra/tracing"
@@ -21,6 +22,8 @@ import (
 	"github.com/grafana/grafana/pkg/services/dashboardsnapshots"
 	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
 	"github.com/grafana/grafana/pkg/services/ngalert/image"
+	"github.com/grafana/grafana/pkg/services/ngalert/state/historian"
+	"github.com/grafana/grafana/pkg/services/publicdashboards"
 	"github.com/grafana/grafana/pkg/services/queryhistory"
 	"github.com/grafana/grafana/pkg/services/shorturls"
 	tempuser "github.com/grafana/grafana/pkg/services/temp_user"
@@ -41,12 +44,15 @@ type CleanUpService struct {
 	tempUserService           tempuser.Service
 	annotationCleaner         annotations.Cleaner
 	dashboardService          dashboards.DashboardService
+	kvStore                   kvstore.KVStore
+	publicDashboardService    publicdashboards.Service
 }
 
 func ProvideService(cfg *setting.Cfg, serverLockService *serverlock.ServerLockService,
 	shortURLService shorturls.Service, sqlstore db.DB, queryHistoryService queryhistory.Service,
 	dashboardVersionService dashver.Service, dashSnapSvc dashboardsnapshots.Service, deleteExpiredImageService *image.DeleteExpiredService,
-	tempUserService tempuser.Service, tracer tracing.Tracer, annotationCleaner annotations.Cleaner, dashboardService dashboards.DashboardService) *CleanUpService {
+	tempUserService tempuser.Service, tracer tracing.Tracer, annotationCleaner annotations.Cleaner, dashboardService dashboards.DashboardService,
+	kvStore kvstore.KVStore, publicDashboardService publicdashboards.Service) *CleanUpService {
 	s := &CleanUpService{
 		Cfg:                       cfg,
 		ServerLockService:         serverLockService,
@@ -61,6 +67,8 @@ func ProvideService(cfg *setting.Cfg, serverLockService *serverlock.ServerLockSe
 		tracer:                    tracer,
 		annotationCleaner:         annotationCleaner,
 		dashboardService:          dashboardService,
+		kvStore:                   kvStore,
+		publicDashboardService:    publicDashboardService,
 	}
 	return s
 }
@@ -107,6 +115,9 @@ func (srv *CleanUpService) clean(ctx context.Context) {
 		{"delete stale query history", srv.deleteStaleQueryHistory},
 		{"expire old email verifications", srv.expireOldVerifications},
 		{"cleanup trash dashboards", srv.cleanUpTrashDashboards},
+		{"delete expired kv store items", srv.deleteExpiredKVStoreItems},
+		{"delete expired alert state history", srv.deleteExpiredAlertStateHistory},
+		{"delete expired public dashboards", srv.deleteExpiredPublicDashboards},
 	}
 
 	logger := srv.log.FromContext(ctx)
@@ -206,6 +217,16 @@ func (srv *CleanUpService) deleteExpiredSnapshots(ctx context.Context) {
 	}
 }
 
+func (srv *CleanUpService) deleteExpiredPublicDashboards(ctx context.Context) {
+	logger := srv.log.FromContext(ctx)
+	deleted, err := srv.publicDashboardService.DeleteExpired(ctx)
+	if err != nil {
+		logger.Error("Failed to delete expired public dashboards", "error", err.Error())
+	} else {
+		logger.Debug("Deleted expired public dashboards", "rows affected", deleted)
+	}
+}
+
 func (srv *CleanUpService) deleteExpiredDashboardVersions(ctx context.Context) {
 	logger := srv.log.FromContext(ctx)
 	cmd := dashver.DeleteExpiredVersionsCommand{}
@@ -228,6 +249,19 @@ func (srv *CleanUpService) deleteExpiredImages(ctx context.Context) {
 	}
 }
 
+func (srv *CleanUpService) deleteExpiredAlertStateHistory(ctx context.Context) {
+	logger := srv.log.FromContext(ctx)
+	maxAge := srv.Cfg.UnifiedAlerting.StateHistory.SQLMaxAge
+	if !srv.Cfg.UnifiedAlerting.IsEnabled() || maxAge <= 0 {
+		return
+	}
+	if rowsAffected, err := historian.DeleteExpiredSQLHistory(ctx, srv.store, maxAge); err != nil {
+		logger.Error("Failed to delete expired alert state history", "error", err.Error())
+	} else {
+		logger.Debug("Deleted expired alert state history", "rows affected", rowsAffected)
+	}
+}
+
 func (srv *CleanUpService) expireOldUserInvites(ctx context.Context) {
 	logger := srv.log.FromContext(ctx)
 	maxInviteLifetime := srv.Cfg.UserInviteMaxLifetime
@@ -310,3 +344,13 @@ func (srv *CleanUpService) cleanUpTrashDashboards(ctx context.Context) {
 		logger.Debug("Cleaned up deleted dashboards", "dashboards affected", affected)
 	}
 }
+
+func (srv *CleanUpService) deleteExpiredKVStoreItems(ctx context.Context) {
+	logger := srv.log.FromContext(ctx)
+	rowsCount, err := srv.kvStore.DeleteExpired(ctx)
+	if err != nil {
+		logger.Error("Problem deleting expired kv store items", "error", err)
+	} else {
+		logger.Debug("Deleted expired kv store items", "rows affected", rowsCount)
+	}
+}
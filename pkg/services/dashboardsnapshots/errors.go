@@ -5,3 +5,5 @@ import (
 )
 
 var ErrBaseNotFound = errutil.NotFound("dashboardsnapshots.not-found", errutil.WithPublicMessage("Snapshot not found"))
+var ErrInvalidSnapshotTimeRange = errutil.BadRequest("dashboardsnapshots.invalid-time-range", errutil.WithPublicMessage("Invalid snapshot time range"))
+var ErrInvalidSnapshotVariables = errutil.BadRequest("dashboardsnapshots.invalid-variables", errutil.WithPublicMessage("Invalid snapshot variables"))
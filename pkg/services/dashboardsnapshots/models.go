@@ -69,6 +69,41 @@ type CreateDashboardSnapshotCommand struct {
 	DashboardEncrypted []byte `json:"-"`
 }
 
+// swagger:model
+type CreateServerSideSnapshotCommand struct {
+	// Snapshot name, defaults to the dashboard title.
+	// required:false
+	Name string `json:"name"`
+
+	// When the snapshot should expire in seconds. Default is never to expire.
+	// required:false
+	// default:0
+	Expires int64 `json:"expires"`
+
+	// Save the snapshot on an external server rather than locally.
+	// required:false
+	// default:false
+	External bool `json:"external"`
+
+	// Start of the snapshot time range, absolute or relative using Grafana time units.
+	// Defaults to the dashboard time range.
+	// required:false
+	// example: now-1h
+	From string `json:"from"`
+
+	// End of the snapshot time range, absolute or relative using Grafana time units.
+	// Defaults to the dashboard time range.
+	// required:false
+	// example: now
+	To string `json:"to"`
+
+	// Values of template variables by variable name, the current values of the
+	// dashboard are used for variables not set.
+	// required:false
+	// example: {"env": ["prod"]}
+	Variables map[string][]string `json:"variables"`
+}
+
 type DeleteDashboardSnapshotCommand struct {
 	DeleteKey string `json:"-"`
 }
@@ -0,0 +1,448 @@
+package dashboardsnapshots
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/libraryelements"
+	"github.com/grafana/grafana/pkg/services/libraryelements/model"
+	"github.com/grafana/grafana/pkg/services/publicdashboards/service/intervalv2"
+	"github.com/grafana/grafana/pkg/services/query"
+	"github.com/grafana/grafana/pkg/tsdb/grafanads"
+)
+
+// defaultSnapshotMaxDataPoints is used for panels without max data points, the
+// frontend uses the width of the panel instead.
+const defaultSnapshotMaxDataPoints = 1000
+
+const mixedDatasourceUID = "-- Mixed --"
+
+// snapshotDatasourceRef is the data source of snapshot panels, it returns the frames
+// of the snapshot query.
+var snapshotDatasourceRef = map[string]any{"type": "datasource", "uid": grafanads.DatasourceUID}
+
+// ServerSideSnapshotter creates snapshots of dashboards by executing the queries of
+// the panels on the server, so snapshots can be taken without opening the dashboard.
+type ServerSideSnapshotter struct {
+	queryService    query.Service
+	dataSources     datasources.DataSourceService
+	libraryElements libraryelements.Service
+	log             log.Logger
+
+	now func() time.Time
+}
+
+func NewServerSideSnapshotter(queryService query.Service, dataSources datasources.DataSourceService, libraryElements libraryelements.Service) *ServerSideSnapshotter {
+	return &ServerSideSnapshotter{
+		queryService:    queryService,
+		dataSources:     dataSources,
+		libraryElements: libraryElements,
+		log:             log.New("dashboard-snapshots"),
+		now:             time.Now,
+	}
+}
+
+// Snapshot returns a copy of the dashboard with the data of every panel embedded as
+// snapshot query, in the same format the frontend uses when sharing a snapshot. Failed
+// queries don't fail the snapshot, their errors are shown by the panels instead.
+func (s *ServerSideSnapshotter) Snapshot(ctx context.Context, user identity.Requester, dashboard *simplejson.Json, cmd *CreateServerSideSnapshotCommand) (*simplejson.Json, error) {
+	dash, err := copyJSON(dashboard)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := cmd.From, cmd.To
+	if from == "" {
+		from = dash.GetPath("time", "from").MustString("now-6h")
+	}
+	if to == "" {
+		to = dash.GetPath("time", "to").MustString("now")
+	}
+	timeRange := gtime.NewTimeRange(from, to)
+	timeRange.Now = s.now()
+	fromTime, err := timeRange.ParseFrom()
+	if err != nil {
+		return nil, ErrInvalidSnapshotTimeRange.Errorf("invalid from %q: %w", from, err)
+	}
+	toTime, err := timeRange.ParseTo()
+	if err != nil {
+		return nil, ErrInvalidSnapshotTimeRange.Errorf("invalid to %q: %w", to, err)
+	}
+	if !fromTime.Before(toTime) {
+		return nil, ErrInvalidSnapshotTimeRange.Errorf("from %q must be before to %q", from, to)
+	}
+
+	vars, err := dashboardVariables(dash, cmd.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &panelQuerier{
+		snapshotter: s,
+		user:        user,
+		timeRange:   backend.TimeRange{From: fromTime, To: toTime},
+		vars:        vars,
+	}
+	for _, panelObj := range dash.Get("panels").MustArray() {
+		panel := simplejson.NewFromAny(panelObj)
+		// Panels of collapsed rows are nested in the row.
+		if panel.Get("type").MustString() == "row" {
+			for _, rowPanelObj := range panel.Get("panels").MustArray() {
+				q.snapshotPanel(ctx, simplejson.NewFromAny(rowPanelObj))
+			}
+			continue
+		}
+		q.snapshotPanel(ctx, panel)
+	}
+
+	trimDashboardForSnapshot(dash, cmd.Name, fromTime, toTime)
+	return dash, nil
+}
+
+// panelQuerier executes the queries of the panels of a single snapshot.
+type panelQuerier struct {
+	snapshotter *ServerSideSnapshotter
+	user        identity.Requester
+	timeRange   backend.TimeRange
+	vars        templateVariables
+
+	defaultDatasource map[string]any
+	datasourceTypes   map[string]string
+}
+
+// snapshotPanel replaces the queries of the panel with a snapshot query holding
+// their results. Panels without queries are left as they are.
+func (q *panelQuerier) snapshotPanel(ctx context.Context, panel *simplejson.Json) {
+	if err := q.resolveLibraryPanel(ctx, panel); err != nil {
+		q.snapshotter.log.Warn("Failed to resolve library panel of snapshot", "error", err)
+		setSnapshotFrames(panel, []*data.Frame{errorFrame("A", err)})
+		return
+	}
+
+	targets := panel.Get("targets").MustArray()
+	if len(targets) == 0 {
+		return
+	}
+
+	maxDataPoints := panel.Get("maxDataPoints").MustInt64(0)
+	if maxDataPoints <= 0 {
+		maxDataPoints = defaultSnapshotMaxDataPoints
+	}
+	var minInterval time.Duration
+	if panelInterval := q.vars.replace(panel.Get("interval").MustString(), ""); panelInterval != "" {
+		minInterval, _ = gtime.ParseInterval(strings.TrimPrefix(panelInterval, ">"))
+	}
+	interval := intervalv2.NewCalculator().Calculate(q.timeRange, minInterval, maxDataPoints)
+
+	// Built-in variables are scoped to the panel as the interval depends on it.
+	vars := make(templateVariables, len(q.vars)+4)
+	for name, values := range q.vars {
+		vars[name] = values
+	}
+	vars["__interval"] = templateVariable{values: []string{interval.Text}}
+	vars["__interval_ms"] = templateVariable{values: []string{strconv.FormatInt(interval.Milliseconds(), 10)}}
+	vars["__from"] = templateVariable{values: []string{strconv.FormatInt(q.timeRange.From.UnixMilli(), 10)}}
+	vars["__to"] = templateVariable{values: []string{strconv.FormatInt(q.timeRange.To.UnixMilli(), 10)}}
+
+	panelDatasource := vars.interpolate(panel.Get("datasource").Interface(), "")
+	hasExpression := false
+	for _, targetObj := range targets {
+		target := simplejson.NewFromAny(targetObj)
+		if expr.NodeTypeFromDatasourceUID(datasourceUID(target.Get("datasource").Interface())) == expr.TypeCMDNode {
+			hasExpression = true
+		}
+	}
+
+	var queries []*simplejson.Json
+	frames := []*data.Frame{}
+	// Repeated panels are only repeated by the frontend, the snapshot has the data of
+	// all values of the variable in a single panel.
+	if repeat := panel.Get("repeat").MustString(); repeat != "" {
+		frames = append(frames, noticeFrame("A", data.NoticeSeverityWarning,
+			fmt.Sprintf("Panel is not repeated for the values of $%s in server side snapshots", repeat)))
+	}
+	for _, targetObj := range targets {
+		target := simplejson.NewFromAny(targetObj)
+		// Hidden queries are still needed by expressions, which remove them from results.
+		if !hasExpression && target.Get("hide").MustBool() {
+			continue
+		}
+		ref := vars.interpolate(target.Get("datasource").Interface(), "")
+		if ref == nil {
+			ref = panelDatasource
+		}
+		datasource, err := q.resolveDatasource(ctx, ref)
+		if err != nil {
+			frames = append(frames, errorFrame(target.Get("refId").MustString(), err))
+			continue
+		}
+		// Variables are formatted by default the way the data source of the query does.
+		target = simplejson.NewFromAny(vars.interpolate(target.Interface(), q.datasourceType(ctx, datasource)))
+		target.Set("datasource", datasource)
+		target.Set("intervalMs", interval.Milliseconds())
+		target.Set("maxDataPoints", maxDataPoints)
+		queries = append(queries, target)
+	}
+
+	if len(queries) > 0 {
+		frames = append(frames, q.query(ctx, queries)...)
+	}
+
+	setSnapshotFrames(panel, frames)
+}
+
+// setSnapshotFrames replaces the queries of the panel with a snapshot query
+// returning the frames.
+func setSnapshotFrames(panel *simplejson.Json, frames []*data.Frame) {
+	panel.Set("datasource", snapshotDatasourceRef)
+	panel.Set("targets", []any{
+		map[string]any{
+			"refId":      "A",
+			"datasource": snapshotDatasourceRef,
+			"queryType":  "snapshot",
+			"snapshot":   frames,
+		},
+	})
+}
+
+func (q *panelQuerier) query(ctx context.Context, queries []*simplejson.Json) []*data.Frame {
+	resp, err := q.snapshotter.queryService.QueryData(ctx, q.user, false, dtos.MetricRequest{
+		From:    strconv.FormatInt(q.timeRange.From.UnixMilli(), 10),
+		To:      strconv.FormatInt(q.timeRange.To.UnixMilli(), 10),
+		Queries: queries,
+	})
+	if err != nil {
+		q.snapshotter.log.Warn("Failed to query snapshot panel data", "error", err)
+		frames := make([]*data.Frame, 0, len(queries))
+		for _, query := range queries {
+			frames = append(frames, errorFrame(query.Get("refId").MustString("A"), err))
+		}
+		return frames
+	}
+
+	var frames []*data.Frame
+	for _, query := range queries {
+		refID := query.Get("refId").MustString("A")
+		dr, ok := resp.Responses[refID]
+		if !ok {
+			continue
+		}
+		if dr.Error != nil {
+			frames = append(frames, errorFrame(refID, dr.Error))
+			continue
+		}
+		for _, frame := range dr.Frames {
+			if frame.RefID == "" {
+				frame.RefID = refID
+			}
+			frames = append(frames, frame)
+		}
+	}
+	return frames
+}
+
+// resolveLibraryPanel replaces the library panel reference of a panel with the model
+// of the library panel, like the frontend does when sharing a snapshot, so the
+// snapshot doesn't change with the library panel.
+func (q *panelQuerier) resolveLibraryPanel(ctx context.Context, panel *simplejson.Json) error {
+	uid := panel.GetPath("libraryPanel", "uid").MustString()
+	if uid == "" {
+		return nil
+	}
+	element, err := q.snapshotter.libraryElements.GetElement(ctx, q.user, model.GetLibraryElementCommand{UID: uid})
+	if err != nil {
+		return fmt.Errorf("failed to get library panel %s: %w", uid, err)
+	}
+	libraryPanel, err := simplejson.NewJson(element.Model)
+	if err != nil {
+		return fmt.Errorf("failed to read library panel %s: %w", uid, err)
+	}
+	// The position of the panel belongs to the dashboard.
+	for key, value := range libraryPanel.MustMap() {
+		if key == "id" || key == "gridPos" {
+			continue
+		}
+		panel.Set(key, value)
+	}
+	panel.Del("libraryPanel")
+	return nil
+}
+
+// resolveDatasource returns the data source reference of a query, resolving the
+// default data source and data sources referenced by name as before 8.3.
+func (q *panelQuerier) resolveDatasource(ctx context.Context, ref any) (any, error) {
+	uid := datasourceUID(ref)
+	switch {
+	case uid == "" || uid == "default" || uid == mixedDatasourceUID:
+		return q.getDefaultDatasource(ctx)
+	case expr.NodeTypeFromDatasourceUID(uid) != expr.TypeDatasourceNode, uid == grafanads.DatasourceUID:
+		return map[string]any{"uid": uid}, nil
+	}
+	if _, ok := ref.(string); !ok {
+		return ref, nil
+	}
+	ds, err := q.snapshotter.dataSources.GetDataSource(ctx, &datasources.GetDataSourceQuery{Name: uid, OrgID: q.user.GetOrgID()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data source %s: %w", uid, err)
+	}
+	return map[string]any{"type": ds.Type, "uid": ds.UID}, nil
+}
+
+// datasourceType returns the type of a resolved data source reference, getting the
+// data source when the reference has no type. It is empty when the type is unknown.
+func (q *panelQuerier) datasourceType(ctx context.Context, ref any) string {
+	if m, ok := ref.(map[string]any); ok {
+		if dsType, _ := m["type"].(string); dsType != "" {
+			return dsType
+		}
+	}
+	uid := datasourceUID(ref)
+	if uid == "" || expr.NodeTypeFromDatasourceUID(uid) != expr.TypeDatasourceNode || uid == grafanads.DatasourceUID {
+		return ""
+	}
+	if dsType, ok := q.datasourceTypes[uid]; ok {
+		return dsType
+	}
+	var dsType string
+	ds, err := q.snapshotter.dataSources.GetDataSource(ctx, &datasources.GetDataSourceQuery{UID: uid, OrgID: q.user.GetOrgID()})
+	if err == nil {
+		dsType = ds.Type
+	}
+	if q.datasourceTypes == nil {
+		q.datasourceTypes = map[string]string{}
+	}
+	q.datasourceTypes[uid] = dsType
+	return dsType
+}
+
+func (q *panelQuerier) getDefaultDatasource(ctx context.Context) (map[string]any, error) {
+	if q.defaultDatasource != nil {
+		return q.defaultDatasource, nil
+	}
+	dataSources, err := q.snapshotter.dataSources.GetDataSources(ctx, &datasources.GetDataSourcesQuery{OrgID: q.user.GetOrgID()})
+	if err != nil {
+		return nil, err
+	}
+	for _, ds := range dataSources {
+		if ds.IsDefault {
+			q.defaultDatasource = map[string]any{"type": ds.Type, "uid": ds.UID}
+			return q.defaultDatasource, nil
+		}
+	}
+	return nil, errors.New("no default data source")
+}
+
+func datasourceUID(ref any) string {
+	switch v := ref.(type) {
+	case string:
+		return v
+	case map[string]any:
+		uid, _ := v["uid"].(string)
+		return uid
+	default:
+		return ""
+	}
+}
+
+// errorFrame returns an empty frame with the error as notice, so the panel of the
+// snapshot shows why it has no data.
+func errorFrame(refID string, err error) *data.Frame {
+	return noticeFrame(refID, data.NoticeSeverityError, err.Error())
+}
+
+func noticeFrame(refID string, severity data.NoticeSeverity, text string) *data.Frame {
+	frame := data.NewFrame("")
+	frame.RefID = refID
+	frame.SetMeta(&data.FrameMeta{
+		Notices: []data.Notice{{Severity: severity, Text: text}},
+	})
+	return frame
+}
+
+// trimDashboardForSnapshot removes everything a snapshot must not query or link to,
+// like the frontend does before saving a snapshot.
+func trimDashboardForSnapshot(dash *simplejson.Json, title string, from, to time.Time) {
+	if title != "" {
+		dash.Set("title", title)
+	}
+	dash.Set("time", map[string]any{
+		"from": from.UTC().Format(time.RFC3339Nano),
+		"to":   to.UTC().Format(time.RFC3339Nano),
+	})
+	dash.Set("links", []any{})
+
+	for _, panelObj := range dash.Get("panels").MustArray() {
+		panel := simplejson.NewFromAny(panelObj)
+		trimPanelLinks(panel)
+		for _, rowPanelObj := range panel.Get("panels").MustArray() {
+			trimPanelLinks(simplejson.NewFromAny(rowPanelObj))
+		}
+	}
+
+	if annotations, ok := dash.CheckGet("annotations"); ok {
+		list := []any{}
+		for _, annotationObj := range annotations.Get("list").MustArray() {
+			annotation := simplejson.NewFromAny(annotationObj)
+			if !annotation.Get("enable").MustBool() {
+				continue
+			}
+			list = append(list, map[string]any{
+				"name":         annotation.Get("name").Interface(),
+				"enable":       true,
+				"iconColor":    annotation.Get("iconColor").Interface(),
+				"type":         annotation.Get("type").Interface(),
+				"builtIn":      annotation.Get("builtIn").Interface(),
+				"hide":         annotation.Get("hide").Interface(),
+				"snapshotData": []any{},
+			})
+		}
+		annotations.Set("list", list)
+	}
+
+	for _, variableObj := range dash.GetPath("templating", "list").MustArray() {
+		variable := simplejson.NewFromAny(variableObj)
+		if _, ok := variable.CheckGet("query"); ok {
+			variable.Set("query", "")
+		}
+		if _, ok := variable.CheckGet("options"); ok {
+			options := []any{}
+			if current := variable.Get("current").MustMap(); len(current) > 0 {
+				options = append(options, current)
+			}
+			variable.Set("options", options)
+		}
+		if _, ok := variable.CheckGet("refresh"); ok {
+			// Never refresh.
+			variable.Set("refresh", 0)
+		}
+	}
+}
+
+func trimPanelLinks(panel *simplejson.Json) {
+	if _, ok := panel.CheckGet("links"); ok {
+		panel.Set("links", []any{})
+	}
+}
+
+func copyJSON(js *simplejson.Json) (*simplejson.Json, error) {
+	b, err := json.Marshal(js)
+	if err != nil {
+		return nil, err
+	}
+	return simplejson.NewJson(b)
+}
@@ -0,0 +1,377 @@
+package dashboardsnapshots
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	fakeDatasources "github.com/grafana/grafana/pkg/services/datasources/fakes"
+	"github.com/grafana/grafana/pkg/services/libraryelements"
+	"github.com/grafana/grafana/pkg/services/libraryelements/model"
+	"github.com/grafana/grafana/pkg/services/query"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+const testSnapshotDashboard = `{
+	"uid": "dash",
+	"title": "Servers",
+	"time": {"from": "now-1h", "to": "now"},
+	"links": [{"title": "docs", "url": "https://example.com"}],
+	"annotations": {"list": [
+		{"name": "Annotations & Alerts", "enable": true, "builtIn": 1, "datasource": {"type": "datasource", "uid": "grafana"}},
+		{"name": "Deploys", "enable": false, "datasource": {"uid": "loki"}}
+	]},
+	"templating": {"list": [
+		{"name": "env", "type": "custom", "query": "dev,prod", "multi": true, "refresh": 1,
+		 "current": {"text": "dev", "value": "dev"},
+		 "options": [{"text": "dev", "value": "dev"}, {"text": "prod", "value": "prod"}]},
+		{"name": "host", "type": "query", "query": "hosts()", "includeAll": true, "refresh": 1,
+		 "current": {"text": "All", "value": "$__all"},
+		 "options": [{"text": "All", "value": "$__all"}, {"text": "a", "value": "a"}, {"text": "b", "value": "b"}]}
+	]},
+	"panels": [
+		{"id": 1, "type": "timeseries", "datasource": {"type": "prometheus", "uid": "prom"},
+		 "links": [{"title": "details"}],
+		 "targets": [
+			{"refId": "A", "expr": "up{env=~\"${env:regex}\", host=~\"$host\"}"},
+			{"refId": "B", "expr": "hidden", "hide": true}
+		 ]},
+		{"id": 2, "type": "text"},
+		{"id": 3, "type": "row", "collapsed": true, "panels": [
+			{"id": 4, "type": "stat", "targets": [{"refId": "A", "rawSql": "SELECT $__interval_ms"}]}
+		]}
+	]
+}`
+
+func TestServerSideSnapshotter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	signedInUser := &user.SignedInUser{OrgID: 1}
+
+	setup := func(t *testing.T) (*ServerSideSnapshotter, *[]dtos.MetricRequest) {
+		t.Helper()
+		var requests []dtos.MetricRequest
+		queryService := &query.FakeQueryService{}
+		queryService.On("QueryData", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+			func(_ context.Context, _ identity.Requester, _ bool, req dtos.MetricRequest) *backend.QueryDataResponse {
+				requests = append(requests, req)
+				resp := backend.NewQueryDataResponse()
+				for _, q := range req.Queries {
+					refID := q.Get("refId").MustString()
+					if q.Get("datasource").Get("uid").MustString() == "broken" {
+						resp.Responses[refID] = backend.ErrDataResponse(backend.StatusBadRequest, "bad query")
+						continue
+					}
+					resp.Responses[refID] = backend.DataResponse{Frames: data.Frames{
+						data.NewFrame("", data.NewField("value", nil, []float64{1})),
+					}}
+				}
+				return resp
+			}, nil)
+
+		dataSources := &fakeDatasources.FakeDataSourceService{DataSources: []*datasources.DataSource{
+			{OrgID: 1, UID: "prom", Name: "Prometheus", Type: "prometheus"},
+			{OrgID: 1, UID: "mysql", Name: "MySQL", Type: "mysql", IsDefault: true},
+		}}
+		libraryElements := &fakeLibraryElements{elements: map[string]model.LibraryElementDTO{
+			"lib": {UID: "lib", Model: []byte(`{"id": 10, "type": "stat", "title": "Library", "gridPos": {"x": 10},
+				"datasource": {"type": "prometheus", "uid": "prom"}, "targets": [{"refId": "A", "expr": "library"}]}`)},
+		}}
+		s := NewServerSideSnapshotter(queryService, dataSources, libraryElements)
+		s.now = func() time.Time { return now }
+		return s, &requests
+	}
+
+	parseDashboard := func(t *testing.T) *simplejson.Json {
+		t.Helper()
+		dash, err := simplejson.NewJson([]byte(testSnapshotDashboard))
+		require.NoError(t, err)
+		return dash
+	}
+
+	t.Run("should query panels and embed results as snapshot queries", func(t *testing.T) {
+		s, requests := setup(t)
+		dashboard := parseDashboard(t)
+
+		snapshot, err := s.Snapshot(context.Background(), signedInUser, dashboard, &CreateServerSideSnapshotCommand{})
+		require.NoError(t, err)
+
+		require.Len(t, *requests, 2)
+		first := (*requests)[0]
+		assert.Equal(t, "1714561200000", first.From)
+		assert.Equal(t, "1714564800000", first.To)
+		require.Len(t, first.Queries, 1)
+		assert.Equal(t, `up{env=~"dev", host=~"(a|b)"}`, first.Queries[0].Get("expr").MustString())
+		assert.Equal(t, "prom", first.Queries[0].Get("datasource").Get("uid").MustString())
+		assert.Equal(t, int64(defaultSnapshotMaxDataPoints), first.Queries[0].Get("maxDataPoints").MustInt64())
+
+		// Panel without data source uses the default one.
+		second := (*requests)[1]
+		assert.Equal(t, "mysql", second.Queries[0].Get("datasource").Get("uid").MustString())
+		assert.Equal(t, "SELECT 5000", second.Queries[0].Get("rawSql").MustString())
+
+		panel := snapshot.Get("panels").GetIndex(0)
+		assert.Equal(t, "grafana", panel.Get("datasource").Get("uid").MustString())
+		target := panel.Get("targets").GetIndex(0)
+		assert.Equal(t, "snapshot", target.Get("queryType").MustString())
+		frames, ok := target.Get("snapshot").Interface().([]*data.Frame)
+		require.True(t, ok)
+		require.Len(t, frames, 1)
+		assert.Equal(t, "A", frames[0].RefID)
+		assert.Empty(t, panel.Get("links").MustArray())
+
+		// Panels without queries are unchanged.
+		assert.Equal(t, "text", snapshot.Get("panels").GetIndex(1).Get("type").MustString())
+		_, ok = snapshot.Get("panels").GetIndex(1).CheckGet("targets")
+		assert.False(t, ok)
+
+		// The original dashboard is not modified.
+		assert.Equal(t, "prom", dashboard.Get("panels").GetIndex(0).Get("datasource").Get("uid").MustString())
+	})
+
+	t.Run("should trim dashboard like the frontend", func(t *testing.T) {
+		s, _ := setup(t)
+
+		snapshot, err := s.Snapshot(context.Background(), signedInUser, parseDashboard(t), &CreateServerSideSnapshotCommand{Name: "Nightly"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Nightly", snapshot.Get("title").MustString())
+		assert.Equal(t, "2024-05-01T11:00:00Z", snapshot.GetPath("time", "from").MustString())
+		assert.Equal(t, "2024-05-01T12:00:00Z", snapshot.GetPath("time", "to").MustString())
+		assert.Empty(t, snapshot.Get("links").MustArray())
+
+		annotations := snapshot.GetPath("annotations", "list").MustArray()
+		require.Len(t, annotations, 1)
+		_, ok := simplejson.NewFromAny(annotations[0]).CheckGet("datasource")
+		assert.False(t, ok)
+
+		variable := snapshot.GetPath("templating", "list").GetIndex(0)
+		assert.Equal(t, "", variable.Get("query").MustString())
+		assert.Equal(t, 0, variable.Get("refresh").MustInt())
+		assert.Len(t, variable.Get("options").MustArray(), 1)
+	})
+
+	t.Run("should use time range and variables of command", func(t *testing.T) {
+		s, requests := setup(t)
+
+		snapshot, err := s.Snapshot(context.Background(), signedInUser, parseDashboard(t), &CreateServerSideSnapshotCommand{
+			From:      "now-2h",
+			To:        "now-1h",
+			Variables: map[string][]string{"env": {"dev", "prod"}, "host": {"a"}},
+		})
+		require.NoError(t, err)
+
+		first := (*requests)[0]
+		assert.Equal(t, "1714557600000", first.From)
+		assert.Equal(t, "1714561200000", first.To)
+		assert.Equal(t, `up{env=~"(dev|prod)", host=~"a"}`, first.Queries[0].Get("expr").MustString())
+		assert.Equal(t, []any{"dev", "prod"}, snapshot.GetPath("templating", "list").GetIndex(0).GetPath("current", "value").MustArray())
+	})
+
+	t.Run("should fail for invalid time range or variables", func(t *testing.T) {
+		s, _ := setup(t)
+
+		_, err := s.Snapshot(context.Background(), signedInUser, parseDashboard(t), &CreateServerSideSnapshotCommand{From: "now", To: "now-1h"})
+		require.ErrorIs(t, err, ErrInvalidSnapshotTimeRange)
+
+		_, err = s.Snapshot(context.Background(), signedInUser, parseDashboard(t), &CreateServerSideSnapshotCommand{From: "yesterday"})
+		require.ErrorIs(t, err, ErrInvalidSnapshotTimeRange)
+
+		_, err = s.Snapshot(context.Background(), signedInUser, parseDashboard(t), &CreateServerSideSnapshotCommand{
+			Variables: map[string][]string{"unknown": {"a"}},
+		})
+		require.ErrorIs(t, err, ErrInvalidSnapshotVariables)
+
+		_, err = s.Snapshot(context.Background(), signedInUser, parseDashboard(t), &CreateServerSideSnapshotCommand{
+			Variables: map[string][]string{"host": {"a", "b"}},
+		})
+		require.ErrorIs(t, err, ErrInvalidSnapshotVariables)
+
+		// All can't be resolved without the saved options of the variable.
+		dashboard := parseDashboard(t)
+		dashboard.GetPath("templating", "list").GetIndex(1).Set("options", []any{})
+		_, err = s.Snapshot(context.Background(), signedInUser, dashboard, &CreateServerSideSnapshotCommand{})
+		require.ErrorIs(t, err, ErrInvalidSnapshotVariables)
+	})
+
+	t.Run("should format variables for the data source of the query", func(t *testing.T) {
+		s, requests := setup(t)
+		dashboard := parseDashboard(t)
+		dashboard.GetPath("panels").GetIndex(0).Get("targets").GetIndex(0).Set("expr", "$env")
+		dashboard.GetPath("panels").GetIndex(2).Get("panels").GetIndex(0).Get("targets").GetIndex(0).Set("rawSql", "SELECT * WHERE env IN ($env)")
+
+		_, err := s.Snapshot(context.Background(), signedInUser, dashboard, &CreateServerSideSnapshotCommand{
+			Variables: map[string][]string{"env": {"dev", "prod"}},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, *requests, 2)
+		assert.Equal(t, "(dev|prod)", (*requests)[0].Queries[0].Get("expr").MustString())
+		assert.Equal(t, "SELECT * WHERE env IN ('dev','prod')", (*requests)[1].Queries[0].Get("rawSql").MustString())
+	})
+
+	t.Run("should use custom all value as it is", func(t *testing.T) {
+		s, requests := setup(t)
+		dashboard := parseDashboard(t)
+		dashboard.GetPath("templating", "list").GetIndex(1).Set("allValue", ".*")
+
+		_, err := s.Snapshot(context.Background(), signedInUser, dashboard, &CreateServerSideSnapshotCommand{})
+		require.NoError(t, err)
+
+		assert.Equal(t, `up{env=~"dev", host=~".*"}`, (*requests)[0].Queries[0].Get("expr").MustString())
+	})
+
+	t.Run("should embed query errors as notices", func(t *testing.T) {
+		s, _ := setup(t)
+		dashboard := parseDashboard(t)
+		dashboard.Get("panels").GetIndex(0).Set("datasource", map[string]any{"uid": "broken"})
+
+		snapshot, err := s.Snapshot(context.Background(), signedInUser, dashboard, &CreateServerSideSnapshotCommand{})
+		require.NoError(t, err)
+
+		frames := snapshot.Get("panels").GetIndex(0).Get("targets").GetIndex(0).Get("snapshot").Interface().([]*data.Frame)
+		require.Len(t, frames, 1)
+		require.Len(t, frames[0].Meta.Notices, 1)
+		assert.Equal(t, data.NoticeSeverityError, frames[0].Meta.Notices[0].Severity)
+		assert.Contains(t, frames[0].Meta.Notices[0].Text, "bad query")
+	})
+
+	t.Run("should snapshot library panels with their model", func(t *testing.T) {
+		s, requests := setup(t)
+		dashboard := parseDashboard(t)
+		dashboard.Set("panels", []any{
+			map[string]any{"id": 1, "gridPos": map[string]any{"x": 0}, "libraryPanel": map[string]any{"uid": "lib", "name": "Library"}},
+			map[string]any{"id": 2, "libraryPanel": map[string]any{"uid": "missing", "name": "Missing"}},
+		})
+
+		snapshot, err := s.Snapshot(context.Background(), signedInUser, dashboard, &CreateServerSideSnapshotCommand{})
+		require.NoError(t, err)
+
+		require.Len(t, *requests, 1)
+		assert.Equal(t, "library", (*requests)[0].Queries[0].Get("expr").MustString())
+
+		panel := snapshot.Get("panels").GetIndex(0)
+		assert.Equal(t, int64(1), panel.Get("id").MustInt64())
+		assert.Equal(t, int64(0), panel.GetPath("gridPos", "x").MustInt64(-1))
+		assert.Equal(t, "stat", panel.Get("type").MustString())
+		assert.Equal(t, "Library", panel.Get("title").MustString())
+		_, ok := panel.CheckGet("libraryPanel")
+		assert.False(t, ok)
+		frames := panel.Get("targets").GetIndex(0).Get("snapshot").Interface().([]*data.Frame)
+		require.Len(t, frames, 1)
+		assert.Equal(t, "A", frames[0].RefID)
+
+		frames = snapshot.Get("panels").GetIndex(1).Get("targets").GetIndex(0).Get("snapshot").Interface().([]*data.Frame)
+		require.Len(t, frames, 1)
+		require.Len(t, frames[0].Meta.Notices, 1)
+		assert.Equal(t, data.NoticeSeverityError, frames[0].Meta.Notices[0].Severity)
+		assert.Contains(t, frames[0].Meta.Notices[0].Text, "failed to get library panel missing")
+	})
+
+	t.Run("should add a notice to repeated panels", func(t *testing.T) {
+		s, _ := setup(t)
+		dashboard := parseDashboard(t)
+		dashboard.Get("panels").GetIndex(0).Set("repeat", "env")
+
+		snapshot, err := s.Snapshot(context.Background(), signedInUser, dashboard, &CreateServerSideSnapshotCommand{})
+		require.NoError(t, err)
+
+		frames := snapshot.Get("panels").GetIndex(0).Get("targets").GetIndex(0).Get("snapshot").Interface().([]*data.Frame)
+		require.Len(t, frames, 2)
+		require.Len(t, frames[0].Meta.Notices, 1)
+		assert.Equal(t, data.NoticeSeverityWarning, frames[0].Meta.Notices[0].Severity)
+		assert.Contains(t, frames[0].Meta.Notices[0].Text, "$env")
+		assert.Equal(t, "A", frames[1].RefID)
+	})
+}
+
+func TestServerSideSnapshotterQueryError(t *testing.T) {
+	queryService := &query.FakeQueryService{}
+	queryService.On("QueryData", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("query failed"))
+	dataSources := &fakeDatasources.FakeDataSourceService{DataSources: []*datasources.DataSource{
+		{OrgID: 1, UID: "prom", Name: "Prometheus", Type: "prometheus"},
+	}}
+	s := NewServerSideSnapshotter(queryService, dataSources, &fakeLibraryElements{})
+
+	dashboard, err := simplejson.NewJson([]byte(`{"panels": [
+		{"id": 1, "type": "timeseries", "datasource": {"type": "prometheus", "uid": "prom"},
+		 "targets": [{"refId": "A", "expr": "a"}, {"refId": "B", "expr": "b"}]}
+	]}`))
+	require.NoError(t, err)
+
+	snapshot, err := s.Snapshot(context.Background(), &user.SignedInUser{OrgID: 1}, dashboard, &CreateServerSideSnapshotCommand{})
+	require.NoError(t, err)
+
+	frames := snapshot.Get("panels").GetIndex(0).Get("targets").GetIndex(0).Get("snapshot").Interface().([]*data.Frame)
+	require.Len(t, frames, 2)
+	for i, refID := range []string{"A", "B"} {
+		assert.Equal(t, refID, frames[i].RefID)
+		require.Len(t, frames[i].Meta.Notices, 1)
+		assert.Equal(t, data.NoticeSeverityError, frames[i].Meta.Notices[0].Severity)
+		assert.Equal(t, "query failed", frames[i].Meta.Notices[0].Text)
+	}
+}
+
+type fakeLibraryElements struct {
+	libraryelements.Service
+	elements map[string]model.LibraryElementDTO
+}
+
+func (f *fakeLibraryElements) GetElement(_ context.Context, _ identity.Requester, cmd model.GetLibraryElementCommand) (model.LibraryElementDTO, error) {
+	element, ok := f.elements[cmd.UID]
+	if !ok {
+		return model.LibraryElementDTO{}, model.ErrLibraryElementNotFound
+	}
+	return element, nil
+}
+
+func TestFormatVariable(t *testing.T) {
+	testCases := []struct {
+		format   string
+		values   []string
+		expected string
+	}{
+		{format: "", values: []string{"a"}, expected: "a"},
+		{format: "", values: []string{"a", "b"}, expected: "{a,b}"},
+		{format: "csv", values: []string{"a", "b"}, expected: "a,b"},
+		{format: "pipe", values: []string{"a", "b"}, expected: "a|b"},
+		{format: "regex", values: []string{"a.b"}, expected: `a\.b`},
+		{format: "regex", values: []string{"a", "b"}, expected: "(a|b)"},
+		{format: "json", values: []string{"a", "b"}, expected: `["a","b"]`},
+		{format: "singlequote", values: []string{"a'b", "c"}, expected: `'a\'b','c'`},
+		{format: "sqlstring", values: []string{"a'b", "c"}, expected: `'a''b','c'`},
+		{format: "doublequote", values: []string{"a", "b"}, expected: `"a","b"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			assert.Equal(t, tc.expected, formatVariable(tc.values, tc.format))
+		})
+	}
+}
+
+func TestTemplateVariablesReplace(t *testing.T) {
+	vars := templateVariables{
+		"env":  {values: []string{"dev", "prod"}, multi: true},
+		"host": {values: []string{"a"}},
+		"all":  {values: []string{".*"}, multi: true, raw: true},
+	}
+
+	assert.Equal(t, "a {dev,prod}", vars.replace("$host $env", ""))
+	assert.Equal(t, "a dev,prod", vars.replace("[[host]] [[env:csv]]", ""))
+	assert.Equal(t, "a dev|prod", vars.replace("${host} ${env:pipe}", ""))
+	assert.Equal(t, "$unknown ${unknown}", vars.replace("$unknown ${unknown}", ""))
+
+	assert.Equal(t, "a (dev|prod)", vars.replace("$host $env", "prometheus"))
+	assert.Equal(t, "a 'dev','prod'", vars.replace("$host $env", "mysql"))
+	assert.Equal(t, ".* .*", vars.replace("$all ${all:csv}", "prometheus"))
+}
@@ -0,0 +1,242 @@
+package dashboardsnapshots
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+// variableRegex matches the $var, [[var]], [[var:format]], ${var} and ${var:format}
+// template variable syntaxes supported by the frontend.
+var variableRegex = regexp.MustCompile(`\$(\w+)|\[\[(\w+?)(?::(\w+))?\]\]|\$\{(\w+)(?:\.[^:}]+)?(?::([^}]+))?\}`)
+
+const allValue = "$__all"
+
+// templateVariable holds the values of a template variable.
+type templateVariable struct {
+	values []string
+	// multi is set for variables that can have several values. Some data sources
+	// quote or escape the values of these variables by default.
+	multi bool
+	// raw is set for the custom all value of a variable, which is never formatted.
+	raw bool
+}
+
+// templateVariables maps template variable names to their values.
+type templateVariables map[string]templateVariable
+
+// Data source types whose queries format multi-value variables as a regex or as a
+// quoted list by default, like their frontend counterparts do.
+var (
+	regexDatasourceTypes = map[string]bool{"prometheus": true, "loki": true}
+	sqlDatasourceTypes   = map[string]bool{"mysql": true, "mssql": true, "postgres": true, "grafana-postgresql-datasource": true}
+)
+
+// dashboardVariables resolves the values of the dashboard template variables, using the
+// values of overrides instead of the current ones when given. The current value of
+// overridden variables is updated in the dashboard, so the snapshot shows the values
+// it was taken with.
+func dashboardVariables(dashboard *simplejson.Json, overrides map[string][]string) (templateVariables, error) {
+	vars := templateVariables{}
+	for _, v := range dashboard.GetPath("templating", "list").MustArray() {
+		variable := simplejson.NewFromAny(v)
+		name := variable.Get("name").MustString()
+		if name == "" {
+			continue
+		}
+		varType := variable.Get("type").MustString()
+		if varType == "adhoc" {
+			continue
+		}
+
+		values, ok := overrides[name]
+		if ok {
+			if len(values) > 1 && !variable.Get("multi").MustBool() {
+				return nil, ErrInvalidSnapshotVariables.Errorf("variable %s does not allow multiple values", name)
+			}
+			var value any
+			if len(values) == 1 {
+				value = values[0]
+			} else {
+				multi := make([]any, 0, len(values))
+				for _, v := range values {
+					multi = append(multi, v)
+				}
+				value = multi
+			}
+			variable.Set("current", map[string]any{
+				"text":  strings.Join(values, " + "),
+				"value": value,
+			})
+		} else if varType == "constant" {
+			values = []string{variable.Get("query").MustString()}
+		} else {
+			values = jsonStrings(variable.GetPath("current", "value"))
+		}
+
+		tv := templateVariable{
+			values: values,
+			multi:  variable.Get("multi").MustBool() || variable.Get("includeAll").MustBool(),
+		}
+		if len(values) == 1 && values[0] == allValue {
+			if custom := variable.Get("allValue").MustString(); custom != "" {
+				tv.values = []string{custom}
+				tv.raw = true
+			} else {
+				tv.values = nil
+				for _, o := range variable.Get("options").MustArray() {
+					if value := jsonString(simplejson.NewFromAny(o).Get("value")); value != allValue {
+						tv.values = append(tv.values, value)
+					}
+				}
+				// The options of variables refreshed by the frontend are usually not saved.
+				if len(tv.values) == 0 {
+					return nil, ErrInvalidSnapshotVariables.Errorf("variable %s is set to All but has no saved options, select its values instead", name)
+				}
+			}
+		}
+		vars[name] = tv
+	}
+
+	for name := range overrides {
+		if _, ok := vars[name]; !ok {
+			return nil, ErrInvalidSnapshotVariables.Errorf("unknown variable %s", name)
+		}
+	}
+	return vars, nil
+}
+
+func jsonStrings(js *simplejson.Json) []string {
+	if arr, err := js.Array(); err == nil {
+		values := make([]string, 0, len(arr))
+		for _, v := range arr {
+			values = append(values, jsonString(simplejson.NewFromAny(v)))
+		}
+		return values
+	}
+	return []string{jsonString(js)}
+}
+
+func jsonString(js *simplejson.Json) string {
+	if s, err := js.String(); err == nil {
+		return s
+	}
+	if js.Interface() == nil {
+		return ""
+	}
+	b, err := js.Encode()
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// interpolate replaces the template variables in every string of the value, which is
+// a decoded JSON value, formatting them for the given data source type. Unknown
+// variables are left as they are.
+func (vars templateVariables) interpolate(value any, datasourceType string) any {
+	switch v := value.(type) {
+	case string:
+		return vars.replace(v, datasourceType)
+	case map[string]any:
+		for k, item := range v {
+			v[k] = vars.interpolate(item, datasourceType)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = vars.interpolate(item, datasourceType)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func (vars templateVariables) replace(target string, datasourceType string) string {
+	return variableRegex.ReplaceAllStringFunc(target, func(match string) string {
+		groups := variableRegex.FindStringSubmatch(match)
+		name, format := groups[1], ""
+		if groups[2] != "" {
+			name, format = groups[2], groups[3]
+		} else if groups[4] != "" {
+			name, format = groups[4], groups[5]
+		}
+		v, ok := vars[name]
+		if !ok {
+			return match
+		}
+		if v.raw {
+			return strings.Join(v.values, ",")
+		}
+		if format == "" {
+			return formatVariableDefault(v, datasourceType)
+		}
+		return formatVariable(v.values, format)
+	})
+}
+
+// formatVariableDefault formats a variable used without format the way the data source
+// does by default: as a regex for Prometheus and Loki, as a quoted list for SQL data
+// sources and as a glob for the others.
+func formatVariableDefault(v templateVariable, datasourceType string) string {
+	switch {
+	case v.multi && regexDatasourceTypes[datasourceType]:
+		return formatVariable(v.values, "regex")
+	case v.multi && sqlDatasourceTypes[datasourceType]:
+		return formatVariable(v.values, "sqlstring")
+	default:
+		return formatVariable(v.values, "")
+	}
+}
+
+// formatVariable formats the values of a variable like the frontend formats of the
+// same names do. Multiple values without a format are formatted as a glob.
+func formatVariable(values []string, format string) string {
+	switch format {
+	case "csv":
+		return strings.Join(values, ",")
+	case "pipe":
+		return strings.Join(values, "|")
+	case "regex":
+		quoted := make([]string, 0, len(values))
+		for _, v := range values {
+			quoted = append(quoted, regexp.QuoteMeta(v))
+		}
+		if len(quoted) == 1 {
+			return quoted[0]
+		}
+		return "(" + strings.Join(quoted, "|") + ")"
+	case "json":
+		b, _ := json.Marshal(values)
+		return string(b)
+	case "singlequote":
+		quoted := make([]string, 0, len(values))
+		for _, v := range values {
+			quoted = append(quoted, "'"+strings.ReplaceAll(v, "'", `\'`)+"'")
+		}
+		return strings.Join(quoted, ",")
+	case "sqlstring":
+		quoted := make([]string, 0, len(values))
+		for _, v := range values {
+			quoted = append(quoted, "'"+strings.ReplaceAll(v, "'", "''")+"'")
+		}
+		return strings.Join(quoted, ",")
+	case "doublequote":
+		quoted := make([]string, 0, len(values))
+		for _, v := range values {
+			quoted = append(quoted, `"`+strings.ReplaceAll(v, `"`, `\"`)+`"`)
+		}
+		return strings.Join(quoted, ",")
+	default:
+		if len(values) == 0 {
+			return ""
+		}
+		if len(values) == 1 {
+			return values[0]
+		}
+		return "{" + strings.Join(values, ",") + "}"
+	}
+}
@@ -560,7 +560,7 @@ const (
 	FlagPromQLScope = "promQLScope"
 
 	// FlagSqlExpressions
-	// Enables using SQL and DuckDB functions as Expressions.
+	// Enables using SQL functions as Expressions.
 	FlagSqlExpressions = "sqlExpressions"
 
 	// FlagNodeGraphDotLayout
@@ -164,6 +164,12 @@ func ProvideService(plugCtxProvider *plugincontext.Provider, cfg *setting.Cfg, r
 
 	g.ManagedStreamRunner = managedStreamRunner
 
+	if g.Cfg.LivePipelineEnabled {
+		if err := g.initPipeline(); err != nil {
+			return nil, err
+		}
+	}
+
 	g.contextGetter = liveplugin.NewContextGetter(g.PluginContextProvider, g.DataSourceCache)
 	pipelinedChannelLocalPublisher := liveplugin.NewChannelLocalPublisher(node, g.Pipeline)
 	numLocalSubscribersGetter := liveplugin.NewNumLocalSubscribersGetter(node)
@@ -439,6 +445,39 @@ func (g *GrafanaLive) getStreamPlugin(ctx context.Context, pluginID string) (bac
 	return nil, fmt.Errorf("%s plugin does not implement StreamHandler: %#v", pluginID, plugin)
 }
 
+// initPipeline initializes the pipeline which processes data pushed to channels
+// according to the channel rules of the configured storage.
+func (g *GrafanaLive) initPipeline() error {
+	var storage pipeline.Storage
+	switch g.Cfg.LivePipelineStorage {
+	case "database":
+		storage = pipeline.NewSQLStorage(g.SQLStore, g.SecretsService)
+	default:
+		storage = &pipeline.FileStorage{
+			DataPath:       g.Cfg.DataPath,
+			SecretsService: g.SecretsService,
+		}
+	}
+	g.pipelineStorage = storage
+
+	builder := &pipeline.StorageRuleBuilder{
+		Node:                 g.node,
+		ManagedStream:        g.ManagedStreamRunner,
+		FrameStorage:         pipeline.NewFrameStorage(),
+		Storage:              storage,
+		ChannelHandlerGetter: g,
+		SecretsService:       g.SecretsService,
+	}
+	channelRuleGetter := pipeline.NewCacheSegmentedTree(builder)
+	if notifier, ok := storage.(pipeline.ChangeNotifier); ok {
+		notifier.OnChange(channelRuleGetter.Refresh)
+	}
+
+	var err error
+	g.Pipeline, err = pipeline.New(channelRuleGetter)
+	return err
+}
+
 func (g *GrafanaLive) Run(ctx context.Context) error {
 	eGroup, eCtx := errgroup.WithContext(ctx)
 
@@ -456,6 +495,13 @@ func (g *GrafanaLive) Run(ctx context.Context) error {
 		}
 	})
 
+	if notifier, ok := g.pipelineStorage.(pipeline.ChangeNotifier); ok {
+		// Watch for channel rule changes made by other instances.
+		eGroup.Go(func() error {
+			return notifier.Run(eCtx)
+		})
+	}
+
 	if g.runStreamManager != nil {
 		// Only run stream manager if GrafanaLive properly initialized.
 		eGroup.Go(func() error {
@@ -1124,8 +1170,45 @@ func (g *GrafanaLive) HandlePipelineConvertTestHTTP(c *contextmodel.ReqContext)
 	if err != nil {
 		return response.Error(http.StatusBadRequest, "Error decoding request", err)
 	}
+	return g.convertDryRun(c, req.ChannelRules, req.Channel, []byte(req.Data))
+}
+
+type ChannelRuleTestRequest struct {
+	Channel string `json:"channel"`
+	Data    string `json:"data"`
+}
+
+// HandleChannelRulesTestHTTP converts a payload with the stored channel rule
+// matching the channel, without publishing the result.
+func (g *GrafanaLive) HandleChannelRulesTestHTTP(c *contextmodel.ReqContext) response.Response {
+	body, err := io.ReadAll(c.Req.Body)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Error reading body", err)
+	}
+	var req ChannelRuleTestRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "Error decoding request", err)
+	}
+	if req.Channel == "" {
+		return response.Error(http.StatusBadRequest, "Channel required", nil)
+	}
+	rules, err := g.pipelineStorage.ListChannelRules(c.Req.Context(), c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get channel rules", err)
+	}
+	for i := range rules {
+		// Outputs are not used by conversion, building them would start remote writers.
+		rules[i].Settings.DataOutputters = nil
+		rules[i].Settings.FrameOutputters = nil
+	}
+	return g.convertDryRun(c, rules, req.Channel, []byte(req.Data))
+}
+
+// convertDryRun converts data with the channel rule matching the channel.
+func (g *GrafanaLive) convertDryRun(c *contextmodel.ReqContext, rules []pipeline.ChannelRule, channel string, data []byte) response.Response {
 	storage := &DryRunRuleStorage{
-		ChannelRules: req.ChannelRules,
+		ChannelRules: rules,
 	}
 	builder := &pipeline.StorageRuleBuilder{
 		Node:                 g.node,
@@ -1139,7 +1222,7 @@ func (g *GrafanaLive) HandlePipelineConvertTestHTTP(c *contextmodel.ReqContext)
 	if err != nil {
 		return response.Error(http.StatusInternalServerError, "Error creating pipeline", err)
 	}
-	rule, ok, err := channelRuleGetter.Get(c.SignedInUser.GetOrgID(), req.Channel)
+	rule, ok, err := channelRuleGetter.Get(c.SignedInUser.GetOrgID(), channel)
 	if err != nil {
 		return response.Error(http.StatusInternalServerError, "Error getting channel rule", err)
 	}
@@ -1149,7 +1232,7 @@ func (g *GrafanaLive) HandlePipelineConvertTestHTTP(c *contextmodel.ReqContext)
 	if rule.Converter == nil {
 		return response.Error(http.StatusNotFound, "No converter found", nil)
 	}
-	channelFrames, err := pipe.DataToChannelFrames(c.Req.Context(), *rule, c.SignedInUser.GetOrgID(), req.Channel, []byte(req.Data))
+	channelFrames, err := pipe.DataToChannelFrames(c.Req.Context(), *rule, c.SignedInUser.GetOrgID(), channel, data)
 	if err != nil {
 		return response.Error(http.StatusInternalServerError, "Error converting data", err)
 	}
@@ -1158,6 +1241,18 @@ func (g *GrafanaLive) HandlePipelineConvertTestHTTP(c *contextmodel.ReqContext)
 	})
 }
 
+// pipelineStorageErrorStatus returns the status of a failed pipeline storage call.
+func pipelineStorageErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, pipeline.ErrChannelRuleNotFound), errors.Is(err, pipeline.ErrWriteConfigNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, pipeline.ErrVersionMismatch):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // HandleChannelRulesPostHTTP ...
 func (g *GrafanaLive) HandleChannelRulesPostHTTP(c *contextmodel.ReqContext) response.Response {
 	body, err := io.ReadAll(c.Req.Body)
@@ -1194,7 +1289,7 @@ func (g *GrafanaLive) HandleChannelRulesPutHTTP(c *contextmodel.ReqContext) resp
 	}
 	rule, err := g.pipelineStorage.UpdateChannelRule(c.Req.Context(), c.SignedInUser.GetOrgID(), cmd)
 	if err != nil {
-		return response.Error(http.StatusInternalServerError, "Failed to update channel rule", err)
+		return response.Error(pipelineStorageErrorStatus(err), "Failed to update channel rule", err)
 	}
 	return response.JSON(http.StatusOK, util.DynMap{
 		"rule": rule,
@@ -1217,7 +1312,7 @@ func (g *GrafanaLive) HandleChannelRulesDeleteHTTP(c *contextmodel.ReqContext) r
 	}
 	err = g.pipelineStorage.DeleteChannelRule(c.Req.Context(), c.SignedInUser.GetOrgID(), cmd)
 	if err != nil {
-		return response.Error(http.StatusInternalServerError, "Failed to delete channel rule", err)
+		return response.Error(pipelineStorageErrorStatus(err), "Failed to delete channel rule", err)
 	}
 	return response.JSON(http.StatusOK, util.DynMap{})
 }
@@ -1305,7 +1400,7 @@ func (g *GrafanaLive) HandleWriteConfigsPutHTTP(c *contextmodel.ReqContext) resp
 	}
 	result, err := g.pipelineStorage.UpdateWriteConfig(c.Req.Context(), c.SignedInUser.GetOrgID(), cmd)
 	if err != nil {
-		return response.Error(http.StatusInternalServerError, "Failed to update write config", err)
+		return response.Error(pipelineStorageErrorStatus(err), "Failed to update write config", err)
 	}
 	return response.JSON(http.StatusOK, util.DynMap{
 		"writeConfig": pipeline.WriteConfigToDto(result),
@@ -1328,7 +1423,7 @@ func (g *GrafanaLive) HandleWriteConfigsDeleteHTTP(c *contextmodel.ReqContext) r
 	}
 	err = g.pipelineStorage.DeleteWriteConfig(c.Req.Context(), c.SignedInUser.GetOrgID(), cmd)
 	if err != nil {
-		return response.Error(http.StatusInternalServerError, "Failed to delete write config", err)
+		return response.Error(pipelineStorageErrorStatus(err), "Failed to delete write config", err)
 	}
 	return response.JSON(http.StatusOK, util.DynMap{})
 }
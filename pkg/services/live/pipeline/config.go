@@ -32,14 +32,17 @@ type ChannelRule struct {
 	OrgId    int64               `json:"-"`
 	Pattern  string              `json:"pattern"`
 	Settings ChannelRuleSettings `json:"settings"`
+	Version  int64               `json:"version,omitempty"`
 }
 
 type ConverterConfig struct {
-	Type                      string                     `json:"type" ts_type:"Omit<keyof ConverterConfig, 'type'>"`
-	AutoJsonConverterConfig   *AutoJsonConverterConfig   `json:"jsonAuto,omitempty"`
-	ExactJsonConverterConfig  *ExactJsonConverterConfig  `json:"jsonExact,omitempty"`
-	AutoInfluxConverterConfig *AutoInfluxConverterConfig `json:"influxAuto,omitempty"`
-	JsonFrameConverterConfig  *JsonFrameConverterConfig  `json:"jsonFrame,omitempty"`
+	Type                                 string                                `json:"type" ts_type:"Omit<keyof ConverterConfig, 'type'>"`
+	AutoJsonConverterConfig              *AutoJsonConverterConfig              `json:"jsonAuto,omitempty"`
+	ExactJsonConverterConfig             *ExactJsonConverterConfig             `json:"jsonExact,omitempty"`
+	AutoInfluxConverterConfig            *AutoInfluxConverterConfig            `json:"influxAuto,omitempty"`
+	JsonFrameConverterConfig             *JsonFrameConverterConfig             `json:"jsonFrame,omitempty"`
+	PrometheusRemoteWriteConverterConfig *PrometheusRemoteWriteConverterConfig `json:"prometheusRemoteWrite,omitempty"`
+	OtlpMetricsConverterConfig           *OtlpMetricsConverterConfig           `json:"otlpMetrics,omitempty"`
 }
 
 type DropFieldsFrameProcessorConfig struct {
@@ -50,11 +53,37 @@ type KeepFieldsFrameProcessorConfig struct {
 	FieldNames []string `json:"fieldNames"`
 }
 
+type RenameFieldsFrameProcessorConfig struct {
+	// FieldNames maps field names to new names.
+	FieldNames map[string]string `json:"fieldNames,omitempty"`
+	// LabelNames maps label names of value fields to new names.
+	LabelNames map[string]string `json:"labelNames,omitempty"`
+	// Labels are set on all value fields.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type ComputedFieldFrameProcessorConfig struct {
+	FieldName string `json:"fieldName"`
+	// Expression is a math expression, fields are referenced like $field or ${field name}.
+	Expression string `json:"expression"`
+}
+
+type TumblingWindowFrameProcessorConfig struct {
+	WindowSeconds int64 `json:"windowSeconds"`
+	// Reducer is one of mean, min, max, sum, count or last, mean by default.
+	Reducer string `json:"reducer,omitempty"`
+	// FieldReducers overrides the reducer for some fields.
+	FieldReducers map[string]string `json:"fieldReducers,omitempty"`
+}
+
 type FrameProcessorConfig struct {
-	Type                      string                          `json:"type" ts_type:"Omit<keyof FrameProcessorConfig, 'type'>"`
-	DropFieldsProcessorConfig *DropFieldsFrameProcessorConfig `json:"dropFields,omitempty"`
-	KeepFieldsProcessorConfig *KeepFieldsFrameProcessorConfig `json:"keepFields,omitempty"`
-	MultipleProcessorConfig   *MultipleFrameProcessorConfig   `json:"multiple,omitempty"`
+	Type                          string                              `json:"type" ts_type:"Omit<keyof FrameProcessorConfig, 'type'>"`
+	DropFieldsProcessorConfig     *DropFieldsFrameProcessorConfig     `json:"dropFields,omitempty"`
+	KeepFieldsProcessorConfig     *KeepFieldsFrameProcessorConfig     `json:"keepFields,omitempty"`
+	MultipleProcessorConfig       *MultipleFrameProcessorConfig       `json:"multiple,omitempty"`
+	RenameFieldsProcessorConfig   *RenameFieldsFrameProcessorConfig   `json:"renameFields,omitempty"`
+	ComputedFieldProcessorConfig  *ComputedFieldFrameProcessorConfig  `json:"computedField,omitempty"`
+	TumblingWindowProcessorConfig *TumblingWindowFrameProcessorConfig `json:"tumblingWindow,omitempty"`
 }
 
 type MultipleFrameProcessorConfig struct {
@@ -152,4 +181,13 @@ type AutoInfluxConverterConfig struct {
 
 type JsonFrameConverterConfig struct{}
 
+type PrometheusRemoteWriteConverterConfig struct{}
+
+// OtlpMetricsConverterConfig ...
+type OtlpMetricsConverterConfig struct {
+	// ResourceAttributes lists resource attributes which are added to the labels
+	// of every sample. By default all resource attributes are added.
+	ResourceAttributes []string `json:"resourceAttributes,omitempty"`
+}
+
 type ManagedStreamOutputConfig struct{}
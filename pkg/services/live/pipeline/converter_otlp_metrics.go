@@ -0,0 +1,182 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+)
+
+// OtlpMetricsConverter decodes OTLP/HTTP metrics export requests (protobuf or JSON
+// encoded) and transforms them to several ChannelFrame objects where Channel is
+// constructed from original channel + / + <metric_name>. Each frame has labels,
+// time and value fields. Metric and attribute names are converted to Prometheus
+// compatible names. Histograms and summaries are split into _count, _sum and
+// _bucket (with le label) or quantile series like Prometheus does.
+type OtlpMetricsConverter struct {
+	config OtlpMetricsConverterConfig
+}
+
+// NewOtlpMetricsConverter creates new OtlpMetricsConverter.
+func NewOtlpMetricsConverter(config OtlpMetricsConverterConfig) *OtlpMetricsConverter {
+	return &OtlpMetricsConverter{config: config}
+}
+
+const ConverterTypeOtlpMetrics = "otlpMetrics"
+
+func (c *OtlpMetricsConverter) Type() string {
+	return ConverterTypeOtlpMetrics
+}
+
+func (c *OtlpMetricsConverter) Convert(_ context.Context, vars Vars, body []byte) ([]*ChannelFrame, error) {
+	req := pmetricotlp.NewExportRequest()
+	// OTLP/HTTP requests are either binary protobuf or JSON encoded, a JSON
+	// body is always an object.
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := req.UnmarshalJSON(trimmed); err != nil {
+			return nil, fmt.Errorf("error unmarshalling OTLP JSON metrics: %w", err)
+		}
+	} else if err := req.UnmarshalProto(body); err != nil {
+		return nil, fmt.Errorf("error unmarshalling OTLP protobuf metrics: %w", err)
+	}
+
+	samples := metricSamples{}
+	resourceMetrics := req.Metrics().ResourceMetrics()
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		rm := resourceMetrics.At(i)
+		resourceLabels := c.resourceLabels(rm.Resource().Attributes())
+		scopeMetrics := rm.ScopeMetrics()
+		for j := 0; j < scopeMetrics.Len(); j++ {
+			metrics := scopeMetrics.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				addOtlpMetric(samples, metrics.At(k), resourceLabels)
+			}
+		}
+	}
+	return samples.channelFrames(vars.Channel), nil
+}
+
+func (c *OtlpMetricsConverter) resourceLabels(attributes pcommon.Map) data.Labels {
+	if len(c.config.ResourceAttributes) == 0 {
+		return attributesToLabels(attributes, nil)
+	}
+	labels := data.Labels{}
+	for _, name := range c.config.ResourceAttributes {
+		if v, ok := attributes.Get(name); ok {
+			labels[sanitizeMetricName(name)] = v.AsString()
+		}
+	}
+	return labels
+}
+
+func addOtlpMetric(samples metricSamples, metric pmetric.Metric, resourceLabels data.Labels) {
+	name := metric.Name()
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		addNumberDataPoints(samples, name, metric.Gauge().DataPoints(), resourceLabels)
+	case pmetric.MetricTypeSum:
+		addNumberDataPoints(samples, name, metric.Sum().DataPoints(), resourceLabels)
+	case pmetric.MetricTypeHistogram:
+		points := metric.Histogram().DataPoints()
+		for i := 0; i < points.Len(); i++ {
+			p := points.At(i)
+			if p.Flags().NoRecordedValue() {
+				continue
+			}
+			labels := attributesToLabels(p.Attributes(), resourceLabels)
+			t := p.Timestamp().AsTime()
+			samples.add(name+"_count", labels, t, float64(p.Count()))
+			if p.HasSum() {
+				samples.add(name+"_sum", labels, t, p.Sum())
+			}
+			// Bucket counts of OTLP are not cumulative, while le buckets are.
+			var cumulative uint64
+			bounds := p.ExplicitBounds()
+			counts := p.BucketCounts()
+			for b := 0; b < counts.Len(); b++ {
+				cumulative += counts.At(b)
+				bound := math.Inf(1)
+				if b < bounds.Len() {
+					bound = bounds.At(b)
+				}
+				samples.add(name+"_bucket", withLabel(labels, "le", strconv.FormatFloat(bound, 'f', -1, 64)), t, float64(cumulative))
+			}
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		points := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < points.Len(); i++ {
+			p := points.At(i)
+			if p.Flags().NoRecordedValue() {
+				continue
+			}
+			labels := attributesToLabels(p.Attributes(), resourceLabels)
+			t := p.Timestamp().AsTime()
+			samples.add(name+"_count", labels, t, float64(p.Count()))
+			if p.HasSum() {
+				samples.add(name+"_sum", labels, t, p.Sum())
+			}
+		}
+	case pmetric.MetricTypeSummary:
+		points := metric.Summary().DataPoints()
+		for i := 0; i < points.Len(); i++ {
+			p := points.At(i)
+			if p.Flags().NoRecordedValue() {
+				continue
+			}
+			labels := attributesToLabels(p.Attributes(), resourceLabels)
+			t := p.Timestamp().AsTime()
+			samples.add(name+"_count", labels, t, float64(p.Count()))
+			samples.add(name+"_sum", labels, t, p.Sum())
+			quantiles := p.QuantileValues()
+			for q := 0; q < quantiles.Len(); q++ {
+				quantile := quantiles.At(q)
+				samples.add(name, withLabel(labels, "quantile", strconv.FormatFloat(quantile.Quantile(), 'f', -1, 64)), t, quantile.Value())
+			}
+		}
+	}
+}
+
+func addNumberDataPoints(samples metricSamples, name string, points pmetric.NumberDataPointSlice, resourceLabels data.Labels) {
+	for i := 0; i < points.Len(); i++ {
+		p := points.At(i)
+		if p.Flags().NoRecordedValue() {
+			continue
+		}
+		var value float64
+		switch p.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			value = float64(p.IntValue())
+		case pmetric.NumberDataPointValueTypeDouble:
+			value = p.DoubleValue()
+		default:
+			continue
+		}
+		samples.add(name, attributesToLabels(p.Attributes(), resourceLabels), p.Timestamp().AsTime(), value)
+	}
+}
+
+// attributesToLabels converts attributes to labels with Prometheus compatible names.
+// Attributes take precedence over the base labels.
+func attributesToLabels(attributes pcommon.Map, base data.Labels) data.Labels {
+	labels := make(data.Labels, len(base)+attributes.Len())
+	for k, v := range base {
+		labels[k] = v
+	}
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		labels[sanitizeMetricName(k)] = v.AsString()
+		return true
+	})
+	return labels
+}
+
+func withLabel(labels data.Labels, name, value string) data.Labels {
+	copied := labels.Copy()
+	copied[name] = value
+	return copied
+}
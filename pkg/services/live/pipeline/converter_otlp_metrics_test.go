@@ -0,0 +1,96 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+)
+
+func testOtlpMetrics() pmetric.Metrics {
+	ts := pcommon.NewTimestampFromTime(time.Unix(100, 0))
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	rm.Resource().Attributes().PutStr("host.name", "edge-1")
+	sm := rm.ScopeMetrics().AppendEmpty()
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("system.cpu.utilization")
+	p := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	p.SetTimestamp(ts)
+	p.SetDoubleValue(0.5)
+	p.Attributes().PutStr("cpu", "0")
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	p = sum.SetEmptySum().DataPoints().AppendEmpty()
+	p.SetTimestamp(ts)
+	p.SetIntValue(42)
+
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("latency")
+	hp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hp.SetTimestamp(ts)
+	hp.SetCount(3)
+	hp.SetSum(1.5)
+	hp.ExplicitBounds().FromRaw([]float64{0.1, 1})
+	hp.BucketCounts().FromRaw([]uint64{1, 1, 1})
+	return metrics
+}
+
+func TestOtlpMetricsConverter_Convert(t *testing.T) {
+	req := pmetricotlp.NewExportRequestFromMetrics(testOtlpMetrics())
+	protoBody, err := req.MarshalProto()
+	require.NoError(t, err)
+	jsonBody, err := req.MarshalJSON()
+	require.NoError(t, err)
+
+	for name, body := range map[string][]byte{"protobuf": protoBody, "json": jsonBody} {
+		t.Run(name, func(t *testing.T) {
+			converter := NewOtlpMetricsConverter(OtlpMetricsConverterConfig{})
+			channelFrames, err := converter.Convert(context.Background(), Vars{Channel: "stream/test/otlp"}, body)
+			require.NoError(t, err)
+
+			frames := map[string][]any{}
+			for _, cf := range channelFrames {
+				require.Equal(t, "stream/test/otlp/"+cf.Frame.Name, cf.Channel)
+				var rows []any
+				for i := 0; i < cf.Frame.Rows(); i++ {
+					require.Equal(t, time.Unix(100, 0).UTC(), cf.Frame.Fields[1].At(i).(time.Time).UTC())
+					rows = append(rows, cf.Frame.Fields[0].At(i), cf.Frame.Fields[2].At(i))
+				}
+				frames[cf.Frame.Name] = rows
+			}
+			require.Equal(t, map[string][]any{
+				"system_cpu_utilization": {"cpu=0, host_name=edge-1, service_name=checkout", 0.5},
+				"requests":               {"host_name=edge-1, service_name=checkout", 42.0},
+				"latency_count":          {"host_name=edge-1, service_name=checkout", 3.0},
+				"latency_sum":            {"host_name=edge-1, service_name=checkout", 1.5},
+				"latency_bucket": {
+					"host_name=edge-1, le=+Inf, service_name=checkout", 3.0,
+					"host_name=edge-1, le=0.1, service_name=checkout", 1.0,
+					"host_name=edge-1, le=1, service_name=checkout", 2.0,
+				},
+			}, frames)
+		})
+	}
+
+	t.Run("resource attributes can be selected", func(t *testing.T) {
+		converter := NewOtlpMetricsConverter(OtlpMetricsConverterConfig{ResourceAttributes: []string{"service.name"}})
+		channelFrames, err := converter.Convert(context.Background(), Vars{Channel: "stream/test/otlp"}, protoBody)
+		require.NoError(t, err)
+		require.Equal(t, "requests", channelFrames[3].Frame.Name)
+		require.Equal(t, "service_name=checkout", channelFrames[3].Frame.Fields[0].At(0))
+	})
+
+	t.Run("invalid payload is rejected", func(t *testing.T) {
+		converter := NewOtlpMetricsConverter(OtlpMetricsConverterConfig{})
+		_, err := converter.Convert(context.Background(), Vars{Channel: "stream/test/otlp"}, []byte("{invalid"))
+		require.Error(t, err)
+	})
+}
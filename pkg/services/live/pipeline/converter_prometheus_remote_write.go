@@ -0,0 +1,72 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// PrometheusRemoteWriteConverter decodes snappy compressed Prometheus remote write
+// protobuf payloads and transforms them to several ChannelFrame objects where
+// Channel is constructed from original channel + / + <metric_name>. Each frame
+// has labels, time and value fields.
+type PrometheusRemoteWriteConverter struct {
+	config PrometheusRemoteWriteConverterConfig
+}
+
+// NewPrometheusRemoteWriteConverter creates new PrometheusRemoteWriteConverter.
+func NewPrometheusRemoteWriteConverter(config PrometheusRemoteWriteConverterConfig) *PrometheusRemoteWriteConverter {
+	return &PrometheusRemoteWriteConverter{config: config}
+}
+
+const ConverterTypePrometheusRemoteWrite = "prometheusRemoteWrite"
+
+func (c *PrometheusRemoteWriteConverter) Type() string {
+	return ConverterTypePrometheusRemoteWrite
+}
+
+func (c *PrometheusRemoteWriteConverter) Convert(_ context.Context, vars Vars, body []byte) ([]*ChannelFrame, error) {
+	// The decoded length comes from the payload header, check it before snappy allocates it.
+	decodedLen, err := snappy.DecodedLen(body)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing remote write request: %w", err)
+	}
+	if decodedLen > MaxInputSize {
+		return nil, fmt.Errorf("decompressed remote write request of %d bytes exceeds %d bytes", decodedLen, MaxInputSize)
+	}
+	decoded, err := snappy.Decode(nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing remote write request: %w", err)
+	}
+	var req prompb.WriteRequest
+	if err := proto.Unmarshal(decoded, &req); err != nil {
+		return nil, fmt.Errorf("error unmarshalling remote write request: %w", err)
+	}
+
+	samples := metricSamples{}
+	for _, ts := range req.Timeseries {
+		var name string
+		labels := make(data.Labels, len(ts.Labels))
+		for _, l := range ts.Labels {
+			if l.Name == "__name__" {
+				name = l.Value
+				continue
+			}
+			labels[l.Name] = l.Value
+		}
+		for _, s := range ts.Samples {
+			// Staleness markers only signal the end of a series to Prometheus.
+			if value.IsStaleNaN(s.Value) {
+				continue
+			}
+			samples.add(name, labels, time.UnixMilli(s.Timestamp), s.Value)
+		}
+	}
+	return samples.channelFrames(vars.Channel), nil
+}
@@ -0,0 +1,70 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusRemoteWriteConverter_Convert(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{{Name: "__name__", Value: "cpu_usage"}, {Name: "host", Value: "b"}},
+				Samples: []prompb.Sample{
+					{Timestamp: 2000, Value: 4},
+					{Timestamp: 3000, Value: math.Float64frombits(value.StaleNaN)},
+				},
+			},
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "cpu_usage"}, {Name: "host", Value: "a"}},
+				Samples: []prompb.Sample{{Timestamp: 1000, Value: 1}, {Timestamp: 2000, Value: 2}},
+			},
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "job:requests:rate5m"}},
+				Samples: []prompb.Sample{{Timestamp: 1000, Value: 10}},
+			},
+		},
+	}
+	decoded, err := proto.Marshal(req)
+	require.NoError(t, err)
+
+	converter := NewPrometheusRemoteWriteConverter(PrometheusRemoteWriteConverterConfig{})
+	channelFrames, err := converter.Convert(context.Background(), Vars{Channel: "stream/test/prom"}, snappy.Encode(nil, decoded))
+	require.NoError(t, err)
+	require.Len(t, channelFrames, 2)
+
+	cpu := channelFrames[0]
+	require.Equal(t, "stream/test/prom/cpu_usage", cpu.Channel)
+	require.Equal(t, "cpu_usage", cpu.Frame.Name)
+	require.Equal(t, 3, cpu.Frame.Rows())
+	require.Equal(t, "host=a", cpu.Frame.Fields[0].At(0))
+	require.Equal(t, time.UnixMilli(1000), cpu.Frame.Fields[1].At(0))
+	require.Equal(t, 1.0, cpu.Frame.Fields[2].At(0))
+	require.Equal(t, "host=a", cpu.Frame.Fields[0].At(1))
+	require.Equal(t, "host=b", cpu.Frame.Fields[0].At(2))
+	require.Equal(t, 4.0, cpu.Frame.Fields[2].At(2))
+
+	require.Equal(t, "stream/test/prom/job_requests_rate5m", channelFrames[1].Channel)
+	require.Equal(t, "", channelFrames[1].Frame.Fields[0].At(0))
+
+	t.Run("uncompressed payload is rejected", func(t *testing.T) {
+		_, err := converter.Convert(context.Background(), Vars{Channel: "stream/test/prom"}, []byte("not snappy"))
+		require.Error(t, err)
+	})
+
+	t.Run("payload decoding beyond the input size is rejected", func(t *testing.T) {
+		// A snappy payload starts with its decoded length as a uvarint.
+		body := binary.AppendUvarint(nil, 1<<30)
+		_, err := converter.Convert(context.Background(), Vars{Channel: "stream/test/prom"}, append(body, 0))
+		require.ErrorContains(t, err, "exceeds")
+	})
+}
@@ -0,0 +1,55 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputedFieldFrameProcessor(t *testing.T) {
+	t.Run("computes a field for every row", func(t *testing.T) {
+		p, err := NewComputedFieldFrameProcessor(ComputedFieldFrameProcessorConfig{
+			FieldName:  "fahrenheit",
+			Expression: "${celsius value} * 1.8 + 32 + abs($offset)",
+		})
+		require.NoError(t, err)
+		offset := int64(-1)
+		frame := data.NewFrame("test",
+			data.NewField("celsius value", nil, []float64{0, 100}),
+			data.NewField("offset", nil, []*int64{&offset, nil}),
+		)
+		frame, err = p.ProcessFrame(context.Background(), Vars{}, frame)
+		require.NoError(t, err)
+		require.Len(t, frame.Fields, 3)
+		require.Equal(t, "fahrenheit", frame.Fields[2].Name)
+		require.Equal(t, 33.0, *frame.Fields[2].At(0).(*float64))
+		require.Nil(t, frame.Fields[2].At(1))
+	})
+
+	t.Run("replaces an existing field", func(t *testing.T) {
+		p, err := NewComputedFieldFrameProcessor(ComputedFieldFrameProcessorConfig{FieldName: "value", Expression: "$value / 100"})
+		require.NoError(t, err)
+		frame, err := p.ProcessFrame(context.Background(), Vars{}, data.NewFrame("test", data.NewField("value", nil, []float64{50})))
+		require.NoError(t, err)
+		require.Len(t, frame.Fields, 1)
+		require.Equal(t, 0.5, *frame.Fields[0].At(0).(*float64))
+	})
+
+	t.Run("fails for missing or non numeric fields", func(t *testing.T) {
+		p, err := NewComputedFieldFrameProcessor(ComputedFieldFrameProcessorConfig{FieldName: "result", Expression: "$value * 2"})
+		require.NoError(t, err)
+		_, err = p.ProcessFrame(context.Background(), Vars{}, data.NewFrame("test", data.NewField("other", nil, []float64{1})))
+		require.ErrorContains(t, err, "field not found: value")
+		_, err = p.ProcessFrame(context.Background(), Vars{}, data.NewFrame("test", data.NewField("value", nil, []string{"1"})))
+		require.ErrorContains(t, err, "not numeric")
+	})
+
+	t.Run("invalid expression is rejected", func(t *testing.T) {
+		_, err := NewComputedFieldFrameProcessor(ComputedFieldFrameProcessorConfig{FieldName: "result", Expression: "$value *"})
+		require.Error(t, err)
+		_, err = NewComputedFieldFrameProcessor(ComputedFieldFrameProcessorConfig{Expression: "$value"})
+		require.Error(t, err)
+	})
+}
@@ -26,6 +26,10 @@ func (p *MultipleFrameProcessor) ProcessFrame(ctx context.Context, vars Vars, fr
 			logger.Error("Error processing frame", "error", err)
 			return nil, err
 		}
+		if frame == nil {
+			// Processor consumed the frame, e.g. to aggregate it later.
+			return nil, nil
+		}
 	}
 	return frame, nil
 }
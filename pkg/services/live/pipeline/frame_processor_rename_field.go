@@ -0,0 +1,49 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// RenameFieldsFrameProcessor can rename fields of a data.Frame and rename
+// or set labels of its fields.
+type RenameFieldsFrameProcessor struct {
+	config RenameFieldsFrameProcessorConfig
+}
+
+func NewRenameFieldsFrameProcessor(config RenameFieldsFrameProcessorConfig) *RenameFieldsFrameProcessor {
+	return &RenameFieldsFrameProcessor{config: config}
+}
+
+const FrameProcessorTypeRenameFields = "renameFields"
+
+func (p *RenameFieldsFrameProcessor) Type() string {
+	return FrameProcessorTypeRenameFields
+}
+
+func (p *RenameFieldsFrameProcessor) ProcessFrame(_ context.Context, _ Vars, frame *data.Frame) (*data.Frame, error) {
+	for _, field := range frame.Fields {
+		if name, ok := p.config.FieldNames[field.Name]; ok {
+			field.Name = name
+		}
+		// Labels are only meaningful for value fields.
+		if field.Type().Time() || (len(p.config.LabelNames) == 0 && len(p.config.Labels) == 0) {
+			continue
+		}
+		labels := make(data.Labels, len(field.Labels)+len(p.config.Labels))
+		for k, v := range field.Labels {
+			if name, ok := p.config.LabelNames[k]; ok {
+				k = name
+			}
+			labels[k] = v
+		}
+		for k, v := range p.config.Labels {
+			labels[k] = v
+		}
+		if len(labels) > 0 {
+			field.Labels = labels
+		}
+	}
+	return frame, nil
+}
@@ -0,0 +1,28 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameFieldsFrameProcessor(t *testing.T) {
+	frame := data.NewFrame("test",
+		data.NewField("time", data.Labels{"host": "a"}, []time.Time{time.Unix(1, 0)}),
+		data.NewField("temp", data.Labels{"host": "a", "dc": "eu"}, []float64{20}),
+	)
+	p := NewRenameFieldsFrameProcessor(RenameFieldsFrameProcessorConfig{
+		FieldNames: map[string]string{"temp": "temperature"},
+		LabelNames: map[string]string{"host": "instance"},
+		Labels:     map[string]string{"dc": "us"},
+	})
+	frame, err := p.ProcessFrame(context.Background(), Vars{}, frame)
+	require.NoError(t, err)
+	require.Equal(t, "time", frame.Fields[0].Name)
+	require.Equal(t, data.Labels{"host": "a"}, frame.Fields[0].Labels)
+	require.Equal(t, "temperature", frame.Fields[1].Name)
+	require.Equal(t, data.Labels{"instance": "a", "dc": "us"}, frame.Fields[1].Labels)
+}
@@ -0,0 +1,327 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// Reducers supported by TumblingWindowFrameProcessor.
+const (
+	WindowReducerMean  = "mean"
+	WindowReducerMin   = "min"
+	WindowReducerMax   = "max"
+	WindowReducerSum   = "sum"
+	WindowReducerCount = "count"
+	WindowReducerLast  = "last"
+)
+
+var windowReducers = map[string]struct{}{
+	WindowReducerMean:  {},
+	WindowReducerMin:   {},
+	WindowReducerMax:   {},
+	WindowReducerSum:   {},
+	WindowReducerCount: {},
+	WindowReducerLast:  {},
+}
+
+// TumblingWindowFrameProcessor aggregates numeric fields of frames into fixed,
+// non-overlapping time windows to downsample high-frequency data. Rows are
+// assigned to windows by the time field of the frame (or the time they were
+// processed if frame has no time field). A window is emitted as a frame with
+// the window start time when a row of a later window arrives, or on the next
+// frame of the channel if the window did not receive rows for a whole window
+// length. Until then the processor returns no frame so processing stops.
+// Windows are tracked separately for every channel and, for labels column
+// frames, for every labels value. Rows older than the current window are dropped.
+// Channels that do not receive rows for windowEvictionWindows window lengths
+// are forgotten, along with their pending window.
+type TumblingWindowFrameProcessor struct {
+	mu      sync.Mutex
+	config  TumblingWindowFrameProcessorConfig
+	window  time.Duration
+	windows map[windowKey]*window
+	// evicted is the processing time of the last eviction of idle channels.
+	evicted time.Time
+
+	nowTimeFunc func() time.Time
+}
+
+// windowEvictionWindows is the number of window lengths after which the window of
+// a channel that does not receive rows anymore is dropped.
+const windowEvictionWindows = 10
+
+type windowKey struct {
+	orgID   int64
+	channel string
+}
+
+type window struct {
+	start  time.Time
+	groups []*windowGroup
+	fields []*data.Field // Aggregated fields in order of appearance, without values.
+	// updated is the processing time of the last row of the window.
+	updated time.Time
+	// emitted is set once the window was emitted because it expired. It is kept
+	// until a later window starts, so that its late rows are dropped.
+	emitted bool
+}
+
+type windowGroup struct {
+	labels     string
+	aggregates map[string]*windowAggregate
+}
+
+type windowAggregate struct {
+	count int64
+	sum   float64
+	min   float64
+	max   float64
+	last  float64
+}
+
+func NewTumblingWindowFrameProcessor(config TumblingWindowFrameProcessorConfig) (*TumblingWindowFrameProcessor, error) {
+	if config.WindowSeconds <= 0 {
+		return nil, errors.New("window seconds must be positive")
+	}
+	if config.Reducer == "" {
+		config.Reducer = WindowReducerMean
+	}
+	if _, ok := windowReducers[config.Reducer]; !ok {
+		return nil, fmt.Errorf("unknown reducer: %s", config.Reducer)
+	}
+	for name, reducer := range config.FieldReducers {
+		if _, ok := windowReducers[reducer]; !ok {
+			return nil, fmt.Errorf("unknown reducer for field %s: %s", name, reducer)
+		}
+	}
+	return &TumblingWindowFrameProcessor{
+		config:      config,
+		window:      time.Duration(config.WindowSeconds) * time.Second,
+		windows:     map[windowKey]*window{},
+		nowTimeFunc: time.Now,
+	}, nil
+}
+
+const FrameProcessorTypeTumblingWindow = "tumblingWindow"
+
+func (p *TumblingWindowFrameProcessor) Type() string {
+	return FrameProcessorTypeTumblingWindow
+}
+
+func (p *TumblingWindowFrameProcessor) ProcessFrame(_ context.Context, vars Vars, frame *data.Frame) (*data.Frame, error) {
+	rowLen, err := frame.RowLen()
+	if err != nil {
+		return nil, err
+	}
+
+	var timeField, labelsField *data.Field
+	var valueFields []*data.Field
+	for i, field := range frame.Fields {
+		switch {
+		case field.Type().Time() && timeField == nil:
+			timeField = field
+		case i == 0 && field.Name == "labels" && field.Type() == data.FieldTypeString:
+			labelsField = field
+		case field.Type().Numeric():
+			valueFields = append(valueFields, field)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.nowTimeFunc()
+	p.evictIdleWindows(now)
+
+	key := windowKey{orgID: vars.OrgID, channel: vars.Channel}
+	w := p.windows[key]
+	var out *data.Frame
+	if w != nil && !w.emitted && now.Sub(w.updated) >= p.window {
+		out = p.appendWindow(out, frame.Name, labelsField != nil, w)
+		w.emitted = true
+	}
+	for i := 0; i < rowLen; i++ {
+		t := now
+		if timeField != nil {
+			v, ok := timeField.ConcreteAt(i)
+			if !ok {
+				continue
+			}
+			t = v.(time.Time)
+		}
+		start := t.Truncate(p.window)
+		if w != nil && start.Before(w.start) {
+			logger.Debug("Dropping row of closed window", "channel", vars.Channel, "time", t)
+			continue
+		}
+		if w != nil && start.After(w.start) {
+			if !w.emitted {
+				out = p.appendWindow(out, frame.Name, labelsField != nil, w)
+			}
+			w = nil
+		}
+		if w == nil {
+			w = &window{start: start}
+		}
+		if w.emitted {
+			logger.Debug("Dropping row of expired window", "channel", vars.Channel, "time", t)
+			continue
+		}
+		w.updated = now
+		var labels string
+		if labelsField != nil {
+			if v, ok := labelsField.ConcreteAt(i); ok {
+				labels = v.(string)
+			}
+		}
+		g := w.group(labels)
+		for _, field := range valueFields {
+			value, err := field.NullableFloatAt(i)
+			if err != nil {
+				return nil, err
+			}
+			if value == nil || math.IsNaN(*value) {
+				continue
+			}
+			g.add(w.field(field), *value)
+		}
+	}
+	if w != nil {
+		p.windows[key] = w
+	}
+	return out, nil
+}
+
+// evictIdleWindows forgets the channels that did not receive rows for windowEvictionWindows
+// window lengths. It runs at most once per window length.
+func (p *TumblingWindowFrameProcessor) evictIdleWindows(now time.Time) {
+	if now.Sub(p.evicted) < p.window {
+		return
+	}
+	p.evicted = now
+	for key, w := range p.windows {
+		if now.Sub(w.updated) >= windowEvictionWindows*p.window {
+			logger.Debug("Dropping window of idle channel", "channel", key.channel, "start", w.start, "emitted", w.emitted)
+			delete(p.windows, key)
+		}
+	}
+}
+
+func (w *window) group(labels string) *windowGroup {
+	for _, g := range w.groups {
+		if g.labels == labels {
+			return g
+		}
+	}
+	g := &windowGroup{labels: labels, aggregates: map[string]*windowAggregate{}}
+	w.groups = append(w.groups, g)
+	return g
+}
+
+// field returns the name of the aggregated field, registering it in the window.
+func (w *window) field(field *data.Field) string {
+	for _, f := range w.fields {
+		if f.Name == field.Name {
+			return f.Name
+		}
+	}
+	f := data.NewField(field.Name, field.Labels, []*float64{})
+	f.Config = field.Config
+	w.fields = append(w.fields, f)
+	return f.Name
+}
+
+func (g *windowGroup) add(name string, value float64) {
+	a, ok := g.aggregates[name]
+	if !ok {
+		g.aggregates[name] = &windowAggregate{count: 1, sum: value, min: value, max: value, last: value}
+		return
+	}
+	a.count++
+	a.sum += value
+	a.min = math.Min(a.min, value)
+	a.max = math.Max(a.max, value)
+	a.last = value
+}
+
+func (a *windowAggregate) reduce(reducer string) float64 {
+	switch reducer {
+	case WindowReducerMin:
+		return a.min
+	case WindowReducerMax:
+		return a.max
+	case WindowReducerSum:
+		return a.sum
+	case WindowReducerCount:
+		return float64(a.count)
+	case WindowReducerLast:
+		return a.last
+	default:
+		return a.sum / float64(a.count)
+	}
+}
+
+// appendWindow appends a row for every group of the window to the frame, creating
+// it if it is nil. Fields first seen in a later window are added with null values
+// for the previous rows.
+func (p *TumblingWindowFrameProcessor) appendWindow(frame *data.Frame, name string, withLabels bool, w *window) *data.Frame {
+	if frame == nil {
+		frame = data.NewFrame(name)
+		if withLabels {
+			frame.Fields = append(frame.Fields, data.NewField("labels", nil, []string{}))
+		}
+		frame.Fields = append(frame.Fields, data.NewField("time", nil, []time.Time{}))
+	}
+	timeIndex := 0
+	if withLabels {
+		timeIndex = 1
+	}
+	rows := frame.Fields[0].Len()
+	for _, f := range w.fields {
+		if !hasValueField(frame.Fields[timeIndex+1:], f.Name) {
+			field := data.NewFieldFromFieldType(data.FieldTypeNullableFloat64, rows)
+			field.Name = f.Name
+			field.Labels = f.Labels
+			field.Config = f.Config
+			frame.Fields = append(frame.Fields, field)
+		}
+	}
+
+	for _, g := range w.groups {
+		if withLabels {
+			frame.Fields[0].Append(g.labels)
+		}
+		frame.Fields[timeIndex].Append(w.start)
+		for _, field := range frame.Fields[timeIndex+1:] {
+			var value *float64
+			if a, ok := g.aggregates[field.Name]; ok {
+				v := a.reduce(p.reducer(field.Name))
+				value = &v
+			}
+			field.Append(value)
+		}
+	}
+	return frame
+}
+
+func hasValueField(fields []*data.Field, name string) bool {
+	for _, f := range fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *TumblingWindowFrameProcessor) reducer(fieldName string) string {
+	if reducer, ok := p.config.FieldReducers[fieldName]; ok {
+		return reducer
+	}
+	return p.config.Reducer
+}
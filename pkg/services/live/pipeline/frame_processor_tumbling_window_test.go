@@ -0,0 +1,155 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTumblingWindowFrameProcessor(t *testing.T) {
+	vars := Vars{OrgID: 1, Channel: "stream/test/window"}
+
+	t.Run("emits aggregated windows when a later window starts", func(t *testing.T) {
+		p, err := NewTumblingWindowFrameProcessor(TumblingWindowFrameProcessorConfig{
+			WindowSeconds: 10,
+			FieldReducers: map[string]string{"requests": WindowReducerCount, "peak": WindowReducerMax},
+		})
+		require.NoError(t, err)
+
+		frame, err := p.ProcessFrame(context.Background(), vars, data.NewFrame("test",
+			data.NewField("time", nil, []time.Time{time.Unix(100, 0), time.Unix(105, 0)}),
+			data.NewField("value", nil, []float64{1, 3}),
+			data.NewField("requests", nil, []float64{1, 1}),
+			data.NewField("peak", nil, []float64{7, 5}),
+		))
+		require.NoError(t, err)
+		require.Nil(t, frame)
+
+		frame, err = p.ProcessFrame(context.Background(), vars, data.NewFrame("test",
+			data.NewField("time", nil, []time.Time{time.Unix(109, 0), time.Unix(112, 0), time.Unix(121, 0)}),
+			data.NewField("value", nil, []float64{5, 10, 20}),
+			data.NewField("requests", nil, []float64{1, 1, 1}),
+			data.NewField("peak", nil, []float64{1, 2, 3}),
+		))
+		require.NoError(t, err)
+		require.NotNil(t, frame)
+		require.Equal(t, "test", frame.Name)
+		require.Equal(t, 2, frame.Rows())
+		require.Equal(t, time.Unix(100, 0), frame.Fields[0].At(0))
+		require.Equal(t, 3.0, *frame.Fields[1].At(0).(*float64))
+		require.Equal(t, 3.0, *frame.Fields[2].At(0).(*float64))
+		require.Equal(t, 7.0, *frame.Fields[3].At(0).(*float64))
+		require.Equal(t, time.Unix(110, 0), frame.Fields[0].At(1))
+		require.Equal(t, 10.0, *frame.Fields[1].At(1).(*float64))
+
+		// Rows of emitted windows are dropped.
+		frame, err = p.ProcessFrame(context.Background(), vars, data.NewFrame("test",
+			data.NewField("time", nil, []time.Time{time.Unix(101, 0)}),
+			data.NewField("value", nil, []float64{100}),
+		))
+		require.NoError(t, err)
+		require.Nil(t, frame)
+	})
+
+	t.Run("aggregates labels column frames by labels", func(t *testing.T) {
+		p, err := NewTumblingWindowFrameProcessor(TumblingWindowFrameProcessorConfig{WindowSeconds: 60, Reducer: WindowReducerSum})
+		require.NoError(t, err)
+
+		_, err = p.ProcessFrame(context.Background(), vars, data.NewFrame("cpu",
+			data.NewField("labels", nil, []string{"host=a", "host=b", "host=a"}),
+			data.NewField("time", nil, []time.Time{time.Unix(0, 0), time.Unix(1, 0), time.Unix(2, 0)}),
+			data.NewField("value", nil, []float64{1, 2, 3}),
+		))
+		require.NoError(t, err)
+		frame, err := p.ProcessFrame(context.Background(), vars, data.NewFrame("cpu",
+			data.NewField("labels", nil, []string{"host=a"}),
+			data.NewField("time", nil, []time.Time{time.Unix(60, 0)}),
+			data.NewField("value", nil, []float64{1}),
+		))
+		require.NoError(t, err)
+		require.Equal(t, 2, frame.Rows())
+		require.Equal(t, "host=a", frame.Fields[0].At(0))
+		require.Equal(t, 4.0, *frame.Fields[2].At(0).(*float64))
+		require.Equal(t, "host=b", frame.Fields[0].At(1))
+		require.Equal(t, 2.0, *frame.Fields[2].At(1).(*float64))
+	})
+
+	t.Run("uses processing time without time field", func(t *testing.T) {
+		p, err := NewTumblingWindowFrameProcessor(TumblingWindowFrameProcessorConfig{WindowSeconds: 1, Reducer: WindowReducerLast})
+		require.NoError(t, err)
+		now := time.Unix(10, 0)
+		p.nowTimeFunc = func() time.Time { return now }
+
+		frame, err := p.ProcessFrame(context.Background(), vars, data.NewFrame("test", data.NewField("value", nil, []float64{1, 2})))
+		require.NoError(t, err)
+		require.Nil(t, frame)
+		now = now.Add(time.Second)
+		frame, err = p.ProcessFrame(context.Background(), vars, data.NewFrame("test", data.NewField("value", nil, []float64{3})))
+		require.NoError(t, err)
+		require.Equal(t, time.Unix(10, 0), frame.Fields[0].At(0))
+		require.Equal(t, 2.0, *frame.Fields[1].At(0).(*float64))
+	})
+
+	t.Run("emits expired windows on the next frame", func(t *testing.T) {
+		p, err := NewTumblingWindowFrameProcessor(TumblingWindowFrameProcessorConfig{WindowSeconds: 10, Reducer: WindowReducerSum})
+		require.NoError(t, err)
+		now := time.Unix(1000, 0)
+		p.nowTimeFunc = func() time.Time { return now }
+
+		frame, err := p.ProcessFrame(context.Background(), vars, data.NewFrame("test",
+			data.NewField("time", nil, []time.Time{time.Unix(100, 0)}),
+			data.NewField("value", nil, []float64{1}),
+		))
+		require.NoError(t, err)
+		require.Nil(t, frame)
+
+		// The window did not receive rows for a window length, it is emitted even though the row belongs to it.
+		now = now.Add(10 * time.Second)
+		frame, err = p.ProcessFrame(context.Background(), vars, data.NewFrame("test",
+			data.NewField("time", nil, []time.Time{time.Unix(105, 0), time.Unix(110, 0)}),
+			data.NewField("value", nil, []float64{2, 3}),
+		))
+		require.NoError(t, err)
+		require.Equal(t, 1, frame.Rows())
+		require.Equal(t, time.Unix(100, 0), frame.Fields[0].At(0))
+		require.Equal(t, 1.0, *frame.Fields[1].At(0).(*float64))
+
+		frame, err = p.ProcessFrame(context.Background(), vars, data.NewFrame("test",
+			data.NewField("time", nil, []time.Time{time.Unix(120, 0)}),
+			data.NewField("value", nil, []float64{4}),
+		))
+		require.NoError(t, err)
+		require.Equal(t, 1, frame.Rows())
+		require.Equal(t, time.Unix(110, 0), frame.Fields[0].At(0))
+		require.Equal(t, 3.0, *frame.Fields[1].At(0).(*float64))
+	})
+
+	t.Run("forgets idle channels", func(t *testing.T) {
+		p, err := NewTumblingWindowFrameProcessor(TumblingWindowFrameProcessorConfig{WindowSeconds: 1})
+		require.NoError(t, err)
+		now := time.Unix(1000, 0)
+		p.nowTimeFunc = func() time.Time { return now }
+
+		for _, channel := range []string{"stream/test/a", "stream/test/b"} {
+			_, err := p.ProcessFrame(context.Background(), Vars{OrgID: 1, Channel: channel}, data.NewFrame("test", data.NewField("value", nil, []float64{1})))
+			require.NoError(t, err)
+		}
+		require.Len(t, p.windows, 2)
+
+		now = now.Add(windowEvictionWindows * time.Second)
+		_, err = p.ProcessFrame(context.Background(), Vars{OrgID: 1, Channel: "stream/test/a"}, data.NewFrame("test", data.NewField("value", nil, []float64{1})))
+		require.NoError(t, err)
+		require.Len(t, p.windows, 1)
+		require.Contains(t, p.windows, windowKey{orgID: 1, channel: "stream/test/a"})
+	})
+
+	t.Run("invalid configuration is rejected", func(t *testing.T) {
+		_, err := NewTumblingWindowFrameProcessor(TumblingWindowFrameProcessorConfig{})
+		require.Error(t, err)
+		_, err = NewTumblingWindowFrameProcessor(TumblingWindowFrameProcessorConfig{WindowSeconds: 1, Reducer: "median"})
+		require.Error(t, err)
+	})
+}
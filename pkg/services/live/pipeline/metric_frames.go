@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// metricSample is a single sample of a metric series decoded from a metrics protocol.
+type metricSample struct {
+	labels data.Labels
+	time   time.Time
+	value  float64
+}
+
+// metricSamples collects samples by metric name.
+type metricSamples map[string][]metricSample
+
+func (s metricSamples) add(name string, labels data.Labels, t time.Time, value float64) {
+	name = sanitizeMetricName(name)
+	if name == "" {
+		return
+	}
+	s[name] = append(s[name], metricSample{labels: labels, time: t, value: value})
+}
+
+// channelFrames transforms the samples into one frame per metric in labels column
+// format (labels, time and value fields, the same format the influxAuto converter
+// produces with labels_column frame format). Channel of every frame is constructed
+// from original channel + / + <metric_name>.
+func (s metricSamples) channelFrames(channel string) []*ChannelFrame {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	channelFrames := make([]*ChannelFrame, 0, len(names))
+	for _, name := range names {
+		samples := s[name]
+		labelStrings := make([]string, len(samples))
+		for i, sample := range samples {
+			labelStrings[i] = sample.labels.String()
+		}
+		order := make([]int, len(samples))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			a, b := order[i], order[j]
+			if !samples[a].time.Equal(samples[b].time) {
+				return samples[a].time.Before(samples[b].time)
+			}
+			return labelStrings[a] < labelStrings[b]
+		})
+
+		labelsField := data.NewField("labels", nil, make([]string, 0, len(samples)))
+		timeField := data.NewField("time", nil, make([]time.Time, 0, len(samples)))
+		valueField := data.NewField("value", nil, make([]float64, 0, len(samples)))
+		for _, i := range order {
+			labelsField.Append(labelStrings[i])
+			timeField.Append(samples[i].time)
+			valueField.Append(samples[i].value)
+		}
+		channelFrames = append(channelFrames, &ChannelFrame{
+			Channel: channel + "/" + name,
+			Frame:   data.NewFrame(name, labelsField, timeField, valueField),
+		})
+	}
+	return channelFrames
+}
+
+// sanitizeMetricName replaces characters which are not allowed in Prometheus label
+// names and in channel paths with underscores, so OpenTelemetry names like
+// http.server.duration become http_server_duration. Colons of recording rule names
+// are replaced too since channel paths do not support them.
+func sanitizeMetricName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z':
+			b.WriteRune(r)
+		case i > 0 && r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
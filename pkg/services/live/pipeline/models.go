@@ -1,12 +1,21 @@
 package pipeline
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/grafana/grafana/pkg/services/live/pipeline/pattern"
 	"github.com/grafana/grafana/pkg/services/live/pipeline/tree"
 )
 
+var (
+	ErrChannelRuleNotFound = errors.New("rule not found")
+	ErrWriteConfigNotFound = errors.New("write config not found")
+	// ErrVersionMismatch is returned when updating a rule or write config which
+	// was changed since the version given in the update command.
+	ErrVersionMismatch = errors.New("version mismatch")
+)
+
 func (r ChannelRule) Valid() (bool, string) {
 	ok, reason := pattern.Valid(r.Pattern)
 	if !ok {
@@ -59,6 +68,7 @@ func WriteConfigToDto(b WriteConfig) WriteConfigDto {
 		UID:          b.UID,
 		Settings:     b.Settings,
 		SecureFields: secureFields,
+		Version:      b.Version,
 	}
 }
 
@@ -66,6 +76,7 @@ type WriteConfigDto struct {
 	UID          string          `json:"uid"`
 	Settings     WriteSettings   `json:"settings"`
 	SecureFields map[string]bool `json:"secureFields"`
+	Version      int64           `json:"version,omitempty"`
 }
 
 type WriteConfigGetCmd struct {
@@ -78,11 +89,13 @@ type WriteConfigCreateCmd struct {
 	SecureSettings map[string]string `json:"secureSettings"`
 }
 
-// TODO: add version field later.
 type WriteConfigUpdateCmd struct {
 	UID            string            `json:"uid"`
 	Settings       WriteSettings     `json:"settings"`
 	SecureSettings map[string]string `json:"secureSettings"`
+	// Version is an optional version of the write config the update is based on.
+	// Storages supporting versions reject the update if it was changed meanwhile.
+	Version int64 `json:"version,omitempty"`
 }
 
 type WriteConfigDeleteCmd struct {
@@ -94,6 +107,7 @@ type WriteConfig struct {
 	UID            string            `json:"uid"`
 	Settings       WriteSettings     `json:"settings"`
 	SecureSettings map[string][]byte `json:"secureSettings,omitempty"`
+	Version        int64             `json:"version,omitempty"`
 }
 
 func (r WriteConfig) Valid() (bool, string) {
@@ -154,6 +168,9 @@ type ChannelRuleCreateCmd struct {
 type ChannelRuleUpdateCmd struct {
 	Pattern  string              `json:"pattern"`
 	Settings ChannelRuleSettings `json:"settings"`
+	// Version is an optional version of the rule the update is based on.
+	// Storages supporting versions reject the update if it was changed meanwhile.
+	Version int64 `json:"version,omitempty"`
 }
 
 type ChannelRuleDeleteCmd struct {
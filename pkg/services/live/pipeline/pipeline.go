@@ -27,6 +27,9 @@ const (
 	id          = 1
 )
 
+// MaxInputSize is the maximum size in bytes of the data pushed to a channel, after decompression.
+const MaxInputSize = 10 * 1024 * 1024 // 10MB
+
 // tracerProvider returns an OpenTelemetry TracerProvider configured to use
 // the Jaeger exporter that will send spans to the provided url. The returned
 // TracerProvider will also use a Resource configured with all the information
@@ -66,6 +66,17 @@ var ConvertersRegistry = []EntityInfo{
 		Type:        ConverterTypeJsonFrame,
 		Description: "JSON-encoded Grafana data frame",
 	},
+	{
+		Type:        ConverterTypePrometheusRemoteWrite,
+		Description: "accept Prometheus remote write protobuf payloads",
+	},
+	{
+		Type:        ConverterTypeOtlpMetrics,
+		Description: "accept OTLP/HTTP metrics in protobuf or JSON encoding",
+		Example: OtlpMetricsConverterConfig{
+			ResourceAttributes: []string{"service.name", "service.instance.id"},
+		},
+	},
 }
 
 var FrameProcessorsRegistry = []EntityInfo{
@@ -79,6 +90,27 @@ var FrameProcessorsRegistry = []EntityInfo{
 		Description: "list the fields that should be removed",
 		Example:     DropFieldsFrameProcessorConfig{},
 	},
+	{
+		Type:        FrameProcessorTypeRenameFields,
+		Description: "rename fields and their labels",
+		Example:     RenameFieldsFrameProcessorConfig{},
+	},
+	{
+		Type:        FrameProcessorTypeComputedField,
+		Description: "add a field computed by a math expression",
+		Example: ComputedFieldFrameProcessorConfig{
+			FieldName:  "fahrenheit",
+			Expression: "$celsius * 1.8 + 32",
+		},
+	},
+	{
+		Type:        FrameProcessorTypeTumblingWindow,
+		Description: "aggregate values over fixed time windows",
+		Example: TumblingWindowFrameProcessorConfig{
+			WindowSeconds: 10,
+			Reducer:       WindowReducerMean,
+		},
+	},
 }
 
 var DataOutputsRegistry = []EntityInfo{
@@ -69,6 +69,16 @@ func (f *StorageRuleBuilder) extractConverter(config *ConverterConfig) (Converte
 			return nil, missingConfiguration
 		}
 		return NewAutoInfluxConverter(*config.AutoInfluxConverterConfig), nil
+	case ConverterTypePrometheusRemoteWrite:
+		if config.PrometheusRemoteWriteConverterConfig == nil {
+			config.PrometheusRemoteWriteConverterConfig = &PrometheusRemoteWriteConverterConfig{}
+		}
+		return NewPrometheusRemoteWriteConverter(*config.PrometheusRemoteWriteConverterConfig), nil
+	case ConverterTypeOtlpMetrics:
+		if config.OtlpMetricsConverterConfig == nil {
+			config.OtlpMetricsConverterConfig = &OtlpMetricsConverterConfig{}
+		}
+		return NewOtlpMetricsConverter(*config.OtlpMetricsConverterConfig), nil
 	default:
 		return nil, fmt.Errorf("unknown converter type: %s", config.Type)
 	}
@@ -90,6 +100,21 @@ func (f *StorageRuleBuilder) extractFrameProcessor(config *FrameProcessorConfig)
 			return nil, missingConfiguration
 		}
 		return NewKeepFieldsFrameProcessor(*config.KeepFieldsProcessorConfig), nil
+	case FrameProcessorTypeRenameFields:
+		if config.RenameFieldsProcessorConfig == nil {
+			return nil, missingConfiguration
+		}
+		return NewRenameFieldsFrameProcessor(*config.RenameFieldsProcessorConfig), nil
+	case FrameProcessorTypeComputedField:
+		if config.ComputedFieldProcessorConfig == nil {
+			return nil, missingConfiguration
+		}
+		return NewComputedFieldFrameProcessor(*config.ComputedFieldProcessorConfig)
+	case FrameProcessorTypeTumblingWindow:
+		if config.TumblingWindowProcessorConfig == nil {
+			return nil, missingConfiguration
+		}
+		return NewTumblingWindowFrameProcessor(*config.TumblingWindowProcessorConfig)
 	case FrameProcessorTypeMultiple:
 		if config.MultipleProcessorConfig == nil {
 			return nil, missingConfiguration
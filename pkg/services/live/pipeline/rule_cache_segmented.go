@@ -59,6 +59,20 @@ func (s *CacheSegmentedTree) fillOrg(orgID int64) error {
 	return nil
 }
 
+// Refresh rebuilds the cached rules of an org, if they were loaded before, when
+// its pipeline configuration changes.
+func (s *CacheSegmentedTree) Refresh(orgID int64) {
+	s.radixMu.RLock()
+	_, ok := s.radix[orgID]
+	s.radixMu.RUnlock()
+	if !ok {
+		return
+	}
+	if err := s.fillOrg(orgID); err != nil {
+		logger.Error("Error refreshing orgId", "error", err, "orgId", orgID)
+	}
+}
+
 func (s *CacheSegmentedTree) Get(orgID int64, channel string) (*LiveChannelRule, bool, error) {
 	s.radixMu.RLock()
 	_, ok := s.radix[orgID]
@@ -14,3 +14,12 @@ type Storage interface {
 	UpdateChannelRule(_ context.Context, orgID int64, cmd ChannelRuleUpdateCmd) (ChannelRule, error)
 	DeleteChannelRule(_ context.Context, orgID int64, cmd ChannelRuleDeleteCmd) error
 }
+
+// ChangeNotifier is implemented by Storage which can notify about changes of org
+// pipeline configuration, including changes made by other Grafana instances.
+type ChangeNotifier interface {
+	// OnChange registers a function called with ID of org whose configuration changed.
+	OnChange(fn func(orgID int64))
+	// Run watches for changes made by other instances until context is done.
+	Run(ctx context.Context) error
+}
@@ -0,0 +1,492 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/secrets"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// defaultChangePollInterval is how often SQLStorage checks for configuration
+// changes made by other Grafana instances.
+const defaultChangePollInterval = 5 * time.Second
+
+// SQLStorage keeps channel rules and write configs in the database so that they are
+// shared between Grafana instances. Every change increments a version of the org
+// pipeline configuration, SQLStorage polls these versions to notify about changes
+// made by other instances.
+type SQLStorage struct {
+	store          db.DB
+	secretsService secrets.Service
+
+	// PollInterval is how often versions are checked by Run.
+	PollInterval time.Duration
+
+	mu       sync.Mutex
+	versions map[int64]int64
+	handlers []func(orgID int64)
+}
+
+// NewSQLStorage creates new SQLStorage.
+func NewSQLStorage(store db.DB, secretsService secrets.Service) *SQLStorage {
+	return &SQLStorage{
+		store:          store,
+		secretsService: secretsService,
+		PollInterval:   defaultChangePollInterval,
+		versions:       map[int64]int64{},
+	}
+}
+
+type channelRuleEntry struct {
+	ID        int64  `xorm:"pk autoincr 'id'"`
+	OrgID     int64  `xorm:"org_id"`
+	Pattern   string `xorm:"pattern"`
+	Settings  string `xorm:"settings"`
+	Version   int64  `xorm:"'version'"`
+	CreatedAt int64  `xorm:"created_at"`
+	UpdatedAt int64  `xorm:"updated_at"`
+}
+
+func (channelRuleEntry) TableName() string {
+	return "live_channel_rule"
+}
+
+type writeConfigEntry struct {
+	ID             int64  `xorm:"pk autoincr 'id'"`
+	OrgID          int64  `xorm:"org_id"`
+	UID            string `xorm:"uid"`
+	Settings       string `xorm:"settings"`
+	SecureSettings string `xorm:"secure_settings"`
+	Version        int64  `xorm:"'version'"`
+	CreatedAt      int64  `xorm:"created_at"`
+	UpdatedAt      int64  `xorm:"updated_at"`
+}
+
+func (writeConfigEntry) TableName() string {
+	return "live_write_config"
+}
+
+type pipelineVersionEntry struct {
+	OrgID     int64 `xorm:"pk 'org_id'"`
+	Version   int64 `xorm:"'version'"`
+	UpdatedAt int64 `xorm:"updated_at"`
+}
+
+func (pipelineVersionEntry) TableName() string {
+	return "live_pipeline_version"
+}
+
+func (s *SQLStorage) ListWriteConfigs(ctx context.Context, orgID int64) ([]WriteConfig, error) {
+	var entries []writeConfigEntry
+	err := s.store.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("org_id = ?", orgID).Asc("uid").Find(&entries)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't list write configs: %w", err)
+	}
+	writeConfigs := make([]WriteConfig, 0, len(entries))
+	for _, entry := range entries {
+		writeConfig, err := entry.toWriteConfig()
+		if err != nil {
+			return nil, err
+		}
+		writeConfigs = append(writeConfigs, writeConfig)
+	}
+	return writeConfigs, nil
+}
+
+func (s *SQLStorage) GetWriteConfig(ctx context.Context, orgID int64, cmd WriteConfigGetCmd) (WriteConfig, bool, error) {
+	var entry writeConfigEntry
+	var ok bool
+	err := s.store.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		ok, err = sess.Where("org_id = ? AND uid = ?", orgID, cmd.UID).Get(&entry)
+		return err
+	})
+	if err != nil {
+		return WriteConfig{}, false, fmt.Errorf("can't get write config: %w", err)
+	}
+	if !ok {
+		return WriteConfig{}, false, nil
+	}
+	writeConfig, err := entry.toWriteConfig()
+	return writeConfig, err == nil, err
+}
+
+func (s *SQLStorage) CreateWriteConfig(ctx context.Context, orgID int64, cmd WriteConfigCreateCmd) (WriteConfig, error) {
+	if cmd.UID == "" {
+		cmd.UID = util.GenerateShortUID()
+	}
+	writeConfig, entry, err := s.newWriteConfigEntry(ctx, orgID, cmd.UID, cmd.Settings, cmd.SecureSettings)
+	if err != nil {
+		return WriteConfig{}, err
+	}
+	entry.Version = 1
+	entry.CreatedAt = entry.UpdatedAt
+
+	err = s.change(ctx, orgID, func(sess *db.Session) error {
+		exists, err := sess.Where("org_id = ? AND uid = ?", orgID, cmd.UID).Exist(&writeConfigEntry{})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("backend already exists in org: %s", cmd.UID)
+		}
+		_, err = sess.Insert(entry)
+		return err
+	})
+	if err != nil {
+		return WriteConfig{}, err
+	}
+	writeConfig.Version = entry.Version
+	return writeConfig, nil
+}
+
+func (s *SQLStorage) UpdateWriteConfig(ctx context.Context, orgID int64, cmd WriteConfigUpdateCmd) (WriteConfig, error) {
+	writeConfig, entry, err := s.newWriteConfigEntry(ctx, orgID, cmd.UID, cmd.Settings, cmd.SecureSettings)
+	if err != nil {
+		return WriteConfig{}, err
+	}
+
+	err = s.change(ctx, orgID, func(sess *db.Session) error {
+		var existing writeConfigEntry
+		ok, err := sess.Where("org_id = ? AND uid = ?", orgID, cmd.UID).Get(&existing)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// Same as FileStorage, updating a missing write config creates it.
+			entry.Version = 1
+			entry.CreatedAt = entry.UpdatedAt
+			_, err = sess.Insert(entry)
+			return err
+		}
+		if cmd.Version > 0 && cmd.Version != existing.Version {
+			return ErrVersionMismatch
+		}
+		entry.ID = existing.ID
+		entry.Version = existing.Version + 1
+		entry.CreatedAt = existing.CreatedAt
+		return updateVersioned(sess, existing.ID, existing.Version, entry)
+	})
+	if err != nil {
+		return WriteConfig{}, err
+	}
+	writeConfig.Version = entry.Version
+	return writeConfig, nil
+}
+
+func (s *SQLStorage) DeleteWriteConfig(ctx context.Context, orgID int64, cmd WriteConfigDeleteCmd) error {
+	return s.change(ctx, orgID, func(sess *db.Session) error {
+		deleted, err := sess.Where("org_id = ? AND uid = ?", orgID, cmd.UID).Delete(&writeConfigEntry{})
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return ErrWriteConfigNotFound
+		}
+		return nil
+	})
+}
+
+func (s *SQLStorage) ListChannelRules(ctx context.Context, orgID int64) ([]ChannelRule, error) {
+	var rules []ChannelRule
+	err := s.store.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		rules, err = listChannelRules(sess, orgID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't list channel rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (s *SQLStorage) CreateChannelRule(ctx context.Context, orgID int64, cmd ChannelRuleCreateCmd) (ChannelRule, error) {
+	rule, entry, err := newChannelRuleEntry(orgID, cmd.Pattern, cmd.Settings)
+	if err != nil {
+		return rule, err
+	}
+	entry.Version = 1
+	entry.CreatedAt = entry.UpdatedAt
+
+	err = s.change(ctx, orgID, func(sess *db.Session) error {
+		rules, err := listChannelRules(sess, orgID)
+		if err != nil {
+			return err
+		}
+		for _, existingRule := range rules {
+			if existingRule.Pattern == rule.Pattern {
+				return fmt.Errorf("pattern already exists in org: %s", rule.Pattern)
+			}
+		}
+		if ok, reason := checkRulesValid(orgID, append(rules, rule)); !ok {
+			return fmt.Errorf("invalid channel rule: %s", reason)
+		}
+		_, err = sess.Insert(entry)
+		return err
+	})
+	if err != nil {
+		return rule, err
+	}
+	rule.Version = entry.Version
+	return rule, nil
+}
+
+func (s *SQLStorage) UpdateChannelRule(ctx context.Context, orgID int64, cmd ChannelRuleUpdateCmd) (ChannelRule, error) {
+	rule, entry, err := newChannelRuleEntry(orgID, cmd.Pattern, cmd.Settings)
+	if err != nil {
+		return rule, err
+	}
+
+	err = s.change(ctx, orgID, func(sess *db.Session) error {
+		var existing channelRuleEntry
+		ok, err := sess.Where("org_id = ? AND pattern = ?", orgID, cmd.Pattern).Get(&existing)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// Same as FileStorage, updating a missing rule creates it.
+			rules, err := listChannelRules(sess, orgID)
+			if err != nil {
+				return err
+			}
+			if ok, reason := checkRulesValid(orgID, append(rules, rule)); !ok {
+				return fmt.Errorf("invalid channel rule: %s", reason)
+			}
+			entry.Version = 1
+			entry.CreatedAt = entry.UpdatedAt
+			_, err = sess.Insert(entry)
+			return err
+		}
+		if cmd.Version > 0 && cmd.Version != existing.Version {
+			return ErrVersionMismatch
+		}
+		entry.ID = existing.ID
+		entry.Version = existing.Version + 1
+		entry.CreatedAt = existing.CreatedAt
+		return updateVersioned(sess, existing.ID, existing.Version, entry)
+	})
+	if err != nil {
+		return rule, err
+	}
+	rule.Version = entry.Version
+	return rule, nil
+}
+
+func (s *SQLStorage) DeleteChannelRule(ctx context.Context, orgID int64, cmd ChannelRuleDeleteCmd) error {
+	return s.change(ctx, orgID, func(sess *db.Session) error {
+		deleted, err := sess.Where("org_id = ? AND pattern = ?", orgID, cmd.Pattern).Delete(&channelRuleEntry{})
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return ErrChannelRuleNotFound
+		}
+		return nil
+	})
+}
+
+// OnChange registers a function called with ID of org whose channel rules or write
+// configs changed, on this instance or on another instance sharing the database.
+func (s *SQLStorage) OnChange(fn func(orgID int64)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers = append(s.handlers, fn)
+}
+
+// Run polls the versions of org pipeline configurations to notify about changes
+// made by other instances.
+func (s *SQLStorage) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.pollChanges(ctx); err != nil {
+				logger.Error("Error polling pipeline configuration changes", "error", err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *SQLStorage) pollChanges(ctx context.Context) error {
+	var entries []pipelineVersionEntry
+	err := s.store.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Find(&entries)
+	})
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		s.setVersion(entry.OrgID, entry.Version)
+	}
+	return nil
+}
+
+// setVersion records the version of org pipeline configuration and notifies
+// handlers if it is newer than the known one.
+func (s *SQLStorage) setVersion(orgID int64, version int64) {
+	s.mu.Lock()
+	if s.versions[orgID] >= version {
+		s.mu.Unlock()
+		return
+	}
+	s.versions[orgID] = version
+	handlers := s.handlers
+	s.mu.Unlock()
+	for _, fn := range handlers {
+		fn(orgID)
+	}
+}
+
+// change runs fn in a transaction incrementing the version of org pipeline
+// configuration, and notifies about the change after it is committed.
+func (s *SQLStorage) change(ctx context.Context, orgID int64, fn func(sess *db.Session) error) error {
+	var version int64
+	err := s.store.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		if err := fn(sess); err != nil {
+			return err
+		}
+		var err error
+		version, err = incrementVersion(sess, orgID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.setVersion(orgID, version)
+	return nil
+}
+
+func incrementVersion(sess *db.Session, orgID int64) (int64, error) {
+	now := time.Now().Unix()
+	res, err := sess.Exec("UPDATE live_pipeline_version SET version = version + 1, updated_at = ? WHERE org_id = ?", now, orgID)
+	if err != nil {
+		return 0, err
+	}
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if updated == 0 {
+		if _, err := sess.Insert(&pipelineVersionEntry{OrgID: orgID, Version: 1, UpdatedAt: now}); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+	var entry pipelineVersionEntry
+	if _, err := sess.Where("org_id = ?", orgID).Get(&entry); err != nil {
+		return 0, err
+	}
+	return entry.Version, nil
+}
+
+// updateVersioned updates the entry with the given ID if it still has the expected
+// version, so concurrent updates from different instances can't overwrite each other.
+func updateVersioned(sess *db.Session, id int64, version int64, entry any) error {
+	updated, err := sess.Where("id = ? AND version = ?", id, version).AllCols().NoAutoCondition().Update(entry)
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+func listChannelRules(sess *db.Session, orgID int64) ([]ChannelRule, error) {
+	var entries []channelRuleEntry
+	if err := sess.Where("org_id = ?", orgID).Asc("pattern").Find(&entries); err != nil {
+		return nil, err
+	}
+	rules := make([]ChannelRule, 0, len(entries))
+	for _, entry := range entries {
+		rule := ChannelRule{
+			OrgId:   entry.OrgID,
+			Pattern: entry.Pattern,
+			Version: entry.Version,
+		}
+		if err := json.Unmarshal([]byte(entry.Settings), &rule.Settings); err != nil {
+			return nil, fmt.Errorf("can't unmarshal settings of channel rule %s: %w", entry.Pattern, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func newChannelRuleEntry(orgID int64, pattern string, settings ChannelRuleSettings) (ChannelRule, *channelRuleEntry, error) {
+	rule := ChannelRule{
+		OrgId:    orgID,
+		Pattern:  pattern,
+		Settings: settings,
+	}
+	if ok, reason := rule.Valid(); !ok {
+		return rule, nil, fmt.Errorf("invalid channel rule: %s", reason)
+	}
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return rule, nil, fmt.Errorf("can't marshal channel rule settings: %w", err)
+	}
+	return rule, &channelRuleEntry{
+		OrgID:     orgID,
+		Pattern:   pattern,
+		Settings:  string(settingsJSON),
+		UpdatedAt: time.Now().Unix(),
+	}, nil
+}
+
+func (s *SQLStorage) newWriteConfigEntry(ctx context.Context, orgID int64, uid string, settings WriteSettings, secureSettings map[string]string) (WriteConfig, *writeConfigEntry, error) {
+	encrypted, err := s.secretsService.EncryptJsonData(ctx, secureSettings, secrets.WithoutScope())
+	if err != nil {
+		return WriteConfig{}, nil, fmt.Errorf("error encrypting data: %w", err)
+	}
+	writeConfig := WriteConfig{
+		OrgId:          orgID,
+		UID:            uid,
+		Settings:       settings,
+		SecureSettings: encrypted,
+	}
+	if ok, reason := writeConfig.Valid(); !ok {
+		return WriteConfig{}, nil, fmt.Errorf("invalid write config: %s", reason)
+	}
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return WriteConfig{}, nil, fmt.Errorf("can't marshal write config settings: %w", err)
+	}
+	secureSettingsJSON, err := json.Marshal(encrypted)
+	if err != nil {
+		return WriteConfig{}, nil, fmt.Errorf("can't marshal write config secure settings: %w", err)
+	}
+	return writeConfig, &writeConfigEntry{
+		OrgID:          orgID,
+		UID:            uid,
+		Settings:       string(settingsJSON),
+		SecureSettings: string(secureSettingsJSON),
+		UpdatedAt:      time.Now().Unix(),
+	}, nil
+}
+
+func (e writeConfigEntry) toWriteConfig() (WriteConfig, error) {
+	writeConfig := WriteConfig{
+		OrgId:   e.OrgID,
+		UID:     e.UID,
+		Version: e.Version,
+	}
+	if err := json.Unmarshal([]byte(e.Settings), &writeConfig.Settings); err != nil {
+		return WriteConfig{}, fmt.Errorf("can't unmarshal settings of write config %s: %w", e.UID, err)
+	}
+	if e.SecureSettings != "" {
+		if err := json.Unmarshal([]byte(e.SecureSettings), &writeConfig.SecureSettings); err != nil {
+			return WriteConfig{}, fmt.Errorf("can't unmarshal secure settings of write config %s: %w", e.UID, err)
+		}
+	}
+	return writeConfig, nil
+}
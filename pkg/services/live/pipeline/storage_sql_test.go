@@ -0,0 +1,149 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+func TestIntegrationSQLStorage_ChannelRules(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	s := NewSQLStorage(db.InitTestDB(t), fakes.NewFakeSecretsService())
+
+	var changed []int64
+	s.OnChange(func(orgID int64) {
+		changed = append(changed, orgID)
+	})
+
+	rule, err := s.CreateChannelRule(ctx, 1, ChannelRuleCreateCmd{
+		Pattern: "stream/test/:metric",
+		Settings: ChannelRuleSettings{
+			Converter: &ConverterConfig{Type: ConverterTypeJsonAuto},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rule.Version)
+	require.Equal(t, []int64{1}, changed)
+
+	_, err = s.CreateChannelRule(ctx, 1, ChannelRuleCreateCmd{Pattern: "stream/test/:metric"})
+	require.Error(t, err)
+
+	// Rules of other orgs are not visible.
+	rules, err := s.ListChannelRules(ctx, 2)
+	require.NoError(t, err)
+	require.Empty(t, rules)
+
+	rule, err = s.UpdateChannelRule(ctx, 1, ChannelRuleUpdateCmd{
+		Pattern: "stream/test/:metric",
+		Settings: ChannelRuleSettings{
+			Converter: &ConverterConfig{Type: ConverterTypeInfluxAuto},
+		},
+		Version: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), rule.Version)
+
+	_, err = s.UpdateChannelRule(ctx, 1, ChannelRuleUpdateCmd{Pattern: "stream/test/:metric", Version: 1})
+	require.ErrorIs(t, err, ErrVersionMismatch)
+
+	rules, err = s.ListChannelRules(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	require.Equal(t, int64(1), rules[0].OrgId)
+	require.Equal(t, int64(2), rules[0].Version)
+	require.Equal(t, ConverterTypeInfluxAuto, rules[0].Settings.Converter.Type)
+
+	require.NoError(t, s.DeleteChannelRule(ctx, 1, ChannelRuleDeleteCmd{Pattern: "stream/test/:metric"}))
+	err = s.DeleteChannelRule(ctx, 1, ChannelRuleDeleteCmd{Pattern: "stream/test/:metric"})
+	require.ErrorIs(t, err, ErrChannelRuleNotFound)
+
+	rules, err = s.ListChannelRules(ctx, 1)
+	require.NoError(t, err)
+	require.Empty(t, rules)
+	require.Equal(t, []int64{1, 1, 1}, changed)
+}
+
+func TestIntegrationSQLStorage_WriteConfigs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	s := NewSQLStorage(db.InitTestDB(t), fakes.NewFakeSecretsService())
+
+	writeConfig, err := s.CreateWriteConfig(ctx, 1, WriteConfigCreateCmd{
+		UID: "test",
+		Settings: WriteSettings{
+			Endpoint:  "http://localhost:9090/api/v1/write",
+			BasicAuth: &BasicAuth{User: "admin"},
+		},
+		SecureSettings: map[string]string{"basicAuthPassword": "secret"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), writeConfig.Version)
+
+	writeConfig, ok, err := s.GetWriteConfig(ctx, 1, WriteConfigGetCmd{UID: "test"})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "http://localhost:9090/api/v1/write", writeConfig.Settings.Endpoint)
+	require.Contains(t, writeConfig.SecureSettings, "basicAuthPassword")
+
+	_, ok, err = s.GetWriteConfig(ctx, 2, WriteConfigGetCmd{UID: "test"})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	writeConfig, err = s.UpdateWriteConfig(ctx, 1, WriteConfigUpdateCmd{
+		UID:      "test",
+		Settings: WriteSettings{Endpoint: "http://localhost:9091/api/v1/write"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), writeConfig.Version)
+
+	_, err = s.UpdateWriteConfig(ctx, 1, WriteConfigUpdateCmd{
+		UID:      "test",
+		Settings: WriteSettings{Endpoint: "http://localhost:9092/api/v1/write"},
+		Version:  1,
+	})
+	require.ErrorIs(t, err, ErrVersionMismatch)
+
+	require.NoError(t, s.DeleteWriteConfig(ctx, 1, WriteConfigDeleteCmd{UID: "test"}))
+	err = s.DeleteWriteConfig(ctx, 1, WriteConfigDeleteCmd{UID: "test"})
+	require.ErrorIs(t, err, ErrWriteConfigNotFound)
+}
+
+func TestIntegrationSQLStorage_PollChanges(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	store := db.InitTestDB(t)
+	s1 := NewSQLStorage(store, fakes.NewFakeSecretsService())
+	s2 := NewSQLStorage(store, fakes.NewFakeSecretsService())
+
+	var changed []int64
+	s2.OnChange(func(orgID int64) {
+		changed = append(changed, orgID)
+	})
+
+	_, err := s1.CreateChannelRule(ctx, 2, ChannelRuleCreateCmd{Pattern: "stream/test/cpu"})
+	require.NoError(t, err)
+	require.Empty(t, changed)
+
+	require.NoError(t, s2.pollChanges(ctx))
+	require.Equal(t, []int64{2}, changed)
+
+	// Nothing changed since the last poll.
+	require.NoError(t, s2.pollChanges(ctx))
+	require.Equal(t, []int64{2}, changed)
+}
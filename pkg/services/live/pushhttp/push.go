@@ -1,8 +1,10 @@
 package pushhttp
 
 import (
+	"compress/gzip"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 
@@ -12,6 +14,7 @@ import (
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/live"
 	"github.com/grafana/grafana/pkg/services/live/convert"
+	"github.com/grafana/grafana/pkg/services/live/pipeline"
 	"github.com/grafana/grafana/pkg/services/live/pushurl"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/web"
@@ -21,6 +24,14 @@ var (
 	logger = log.New("live.push_http")
 )
 
+// maxPipelineBodySize is the maximum size in bytes of a pipeline push body, after decompression.
+const maxPipelineBodySize = pipeline.MaxInputSize
+
+var (
+	errInvalidPipelineBody  = errors.New("invalid body")
+	errPipelineBodyTooLarge = fmt.Errorf("body exceeds %d bytes", maxPipelineBodySize)
+)
+
 func ProvideService(cfg *setting.Cfg, live *live.GrafanaLive) *Gateway {
 	logger.Info("Live Push Gateway initialization")
 	g := &Gateway{
@@ -101,10 +112,17 @@ func (g *Gateway) Handle(ctx *contextmodel.ReqContext) {
 func (g *Gateway) HandlePipelinePush(ctx *contextmodel.ReqContext) {
 	channelID := web.Params(ctx.Req)["*"]
 
-	body, err := io.ReadAll(ctx.Req.Body)
+	body, err := readPipelineBody(ctx.Req)
 	if err != nil {
 		logger.Error("Error reading body", "error", err)
-		ctx.Resp.WriteHeader(http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, errInvalidPipelineBody):
+			ctx.Resp.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, errPipelineBodyTooLarge):
+			ctx.Resp.WriteHeader(http.StatusRequestEntityTooLarge)
+		default:
+			ctx.Resp.WriteHeader(http.StatusInternalServerError)
+		}
 		return
 	}
 	logger.Debug("Live channel push request",
@@ -131,3 +149,34 @@ func (g *Gateway) HandlePipelinePush(ctx *contextmodel.ReqContext) {
 
 	ctx.Resp.WriteHeader(http.StatusOK)
 }
+
+// readPipelineBody reads the body of a pipeline push request. Gzip encoded bodies,
+// which OTLP/HTTP exporters send by default, are decompressed. Other encodings like
+// snappy of Prometheus remote write are part of the payload format and are handled
+// by the channel rule converter. Bodies larger than maxPipelineBodySize are rejected.
+func readPipelineBody(req *http.Request) ([]byte, error) {
+	if req.Header.Get("Content-Encoding") != "gzip" {
+		return readLimited(req.Body)
+	}
+	reader, err := gzip.NewReader(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidPipelineBody, err)
+	}
+	defer func() { _ = reader.Close() }()
+	body, err := readLimited(reader)
+	if err != nil && !errors.Is(err, errPipelineBodyTooLarge) {
+		return nil, fmt.Errorf("%w: %w", errInvalidPipelineBody, err)
+	}
+	return body, err
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r, maxPipelineBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxPipelineBodySize {
+		return nil, errPipelineBodyTooLarge
+	}
+	return body, nil
+}
@@ -0,0 +1,64 @@
+package pushhttp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPipelineBody(t *testing.T) {
+	request := func(body []byte, encoding string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/live/pipeline/push/stream/test/x", bytes.NewReader(body))
+		if encoding != "" {
+			req.Header.Set("Content-Encoding", encoding)
+		}
+		return req
+	}
+	compress := func(t *testing.T, data []byte) []byte {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		_, err := w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+
+	t.Run("reads a plain body", func(t *testing.T) {
+		body, err := readPipelineBody(request([]byte("cpu value=1"), ""))
+		require.NoError(t, err)
+		require.Equal(t, "cpu value=1", string(body))
+	})
+
+	t.Run("decompresses a gzip body", func(t *testing.T) {
+		body, err := readPipelineBody(request(compress(t, []byte("cpu value=1")), "gzip"))
+		require.NoError(t, err)
+		require.Equal(t, "cpu value=1", string(body))
+	})
+
+	t.Run("rejects a malformed gzip body", func(t *testing.T) {
+		_, err := readPipelineBody(request([]byte("cpu value=1"), "gzip"))
+		require.ErrorIs(t, err, errInvalidPipelineBody)
+
+		truncated := compress(t, []byte("cpu value=1"))
+		_, err = readPipelineBody(request(truncated[:len(truncated)-4], "gzip"))
+		require.ErrorIs(t, err, errInvalidPipelineBody)
+	})
+
+	t.Run("rejects a body that decompresses beyond the limit", func(t *testing.T) {
+		_, err := readPipelineBody(request(compress(t, make([]byte, maxPipelineBodySize+1)), "gzip"))
+		require.ErrorIs(t, err, errPipelineBodyTooLarge)
+
+		body, err := readPipelineBody(request(compress(t, make([]byte, maxPipelineBodySize)), "gzip"))
+		require.NoError(t, err)
+		require.Len(t, body, maxPipelineBodySize)
+	})
+
+	t.Run("rejects a plain body beyond the limit", func(t *testing.T) {
+		_, err := readPipelineBody(request(make([]byte, maxPipelineBodySize+1), ""))
+		require.ErrorIs(t, err, errPipelineBodyTooLarge)
+	})
+}
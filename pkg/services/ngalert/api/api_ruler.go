@@ -560,6 +560,7 @@ func toGettableExtendedRuleNode(r ngmodels.AlertRule, provenanceRecords map[stri
 			IsPaused:             r.IsPaused,
 			NotificationSettings: AlertRuleNotificationSettingsFromNotificationSettings(r.NotificationSettings),
 			Record:               ApiRecordFromModelRecord(r.Record),
+			Dependencies:         ApiRuleDependenciesFromModelRuleDependencies(r.Dependencies),
 		},
 	}
 	forDuration := model.Duration(r.For)
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 
 type Historian interface {
 	Query(ctx context.Context, query models.HistoryQuery) (*data.Frame, error)
+	QueryStats(ctx context.Context, query models.HistoryStatsQuery) (*models.HistoryStats, error)
 }
 
 type HistorySrv struct {
@@ -32,12 +34,7 @@ func (srv *HistorySrv) RouteQueryStateHistory(c *contextmodel.ReqContext) respon
 	dashUID := c.Query("dashboardUID")
 	panelID := c.QueryInt64("panelID")
 
-	labels := make(map[string]string)
-	for k, v := range c.Req.URL.Query() {
-		if strings.HasPrefix(k, labelQueryPrefix) {
-			labels[k[len(labelQueryPrefix):]] = v[0]
-		}
-	}
+	labels := labelsFromQuery(c)
 
 	query := models.HistoryQuery{
 		RuleUID:      ruleUID,
@@ -56,3 +53,56 @@ func (srv *HistorySrv) RouteQueryStateHistory(c *contextmodel.ReqContext) respon
 	}
 	return response.JSON(http.StatusOK, frame)
 }
+
+func (srv *HistorySrv) RouteQueryStateHistoryStats(c *contextmodel.ReqContext) response.Response {
+	from := c.QueryInt64("from")
+	to := c.QueryInt64("to")
+	if from != 0 && to != 0 && from >= to {
+		return ErrResp(http.StatusBadRequest, errors.New("from must be before to"), "")
+	}
+	topK := c.QueryInt("topK")
+	if topK < 0 {
+		return ErrResp(http.StatusBadRequest, errors.New("topK must not be negative"), "")
+	}
+	flappingThreshold := c.QueryFloat64("flappingThreshold")
+	if flappingThreshold < 0 {
+		return ErrResp(http.StatusBadRequest, errors.New("flappingThreshold must not be negative"), "")
+	}
+
+	query := models.HistoryStatsQuery{
+		HistoryQuery: models.HistoryQuery{
+			RuleUID:      c.Query("ruleUID"),
+			OrgID:        c.SignedInUser.GetOrgID(),
+			DashboardUID: c.Query("dashboardUID"),
+			PanelID:      c.QueryInt64("panelID"),
+			SignedInUser: c.SignedInUser,
+			Limit:        c.QueryInt("limit"),
+			Labels:       labelsFromQuery(c),
+		},
+		GroupBy:           c.QueryStrings("groupBy"),
+		TopK:              topK,
+		FlappingThreshold: flappingThreshold,
+	}
+	if from != 0 {
+		query.From = time.Unix(from, 0)
+	}
+	if to != 0 {
+		query.To = time.Unix(to, 0)
+	}
+
+	stats, err := srv.hist.QueryStats(c.Req.Context(), query)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to aggregate state history", err)
+	}
+	return response.JSON(http.StatusOK, ApiStateHistoryStatsFromModelHistoryStats(stats))
+}
+
+func labelsFromQuery(c *contextmodel.ReqContext) map[string]string {
+	labels := make(map[string]string)
+	for k, v := range c.Req.URL.Query() {
+		if strings.HasPrefix(k, labelQueryPrefix) {
+			labels[k[len(labelQueryPrefix):]] = v[0]
+		}
+	}
+	return labels
+}
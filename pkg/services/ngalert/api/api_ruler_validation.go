@@ -3,6 +3,7 @@ package api
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -83,6 +84,11 @@ func validateRuleNode(
 		return nil, err
 	}
 
+	newAlertRule.Dependencies, err = validateDependencies(ruleNode.GrafanaManagedAlert.Dependencies, isRecordingRule)
+	if err != nil {
+		return nil, err
+	}
+
 	if ruleNode.ApiRuleNode != nil {
 		newAlertRule.Annotations = ruleNode.ApiRuleNode.Annotations
 		err = validateLabels(ruleNode.Labels)
@@ -324,9 +330,97 @@ func ValidateRuleGroup(
 
 		result = append(result, &ruleWithOptionals)
 	}
+
+	if err := validateGroupDependencies(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// validateDependencies validates the dependencies of a single rule and converts them to models.RuleDependency.
+func validateDependencies(deps []apimodels.RuleDependency, isRecordingRule bool) ([]ngmodels.RuleDependency, error) {
+	result := ModelRuleDependenciesFromApiRuleDependencies(deps)
+	seen := make(map[string]struct{}, len(result))
+	for _, d := range result {
+		if d.RuleUID == "" {
+			return nil, fmt.Errorf("%w: dependency must have a rule UID", ngmodels.ErrAlertRuleFailedValidation)
+		}
+		if _, ok := seen[d.RuleUID]; ok {
+			return nil, fmt.Errorf("%w: duplicate dependency on rule %s", ngmodels.ErrAlertRuleFailedValidation, d.RuleUID)
+		}
+		seen[d.RuleUID] = struct{}{}
+		if !d.Condition.IsValid() {
+			return nil, fmt.Errorf("%w: unknown dependency condition %q, must be one of %q or %q", ngmodels.ErrAlertRuleFailedValidation, d.Condition, ngmodels.DependencyConditionFiring, ngmodels.DependencyConditionNotFiring)
+		}
+		if isRecordingRule && d.Condition != ngmodels.DependencyConditionNone {
+			return nil, fmt.Errorf("%w: recording rules cannot have dependency conditions", ngmodels.ErrAlertRuleFailedValidation)
+		}
+	}
 	return result, nil
 }
 
+// validateGroupDependencies checks that rules only depend on other rules of the group, and that the dependencies do not form a cycle.
+func validateGroupDependencies(rules []*ngmodels.AlertRuleWithOptionals) error {
+	byUID := make(map[string]*ngmodels.AlertRuleWithOptionals, len(rules))
+	for _, rule := range rules {
+		if rule.UID != "" {
+			byUID[rule.UID] = rule
+		}
+	}
+
+	for idx, rule := range rules {
+		for _, d := range rule.Dependencies {
+			if d.RuleUID == rule.UID {
+				return fmt.Errorf("%w: rule [%d] cannot depend on itself", ngmodels.ErrAlertRuleFailedValidation, idx)
+			}
+			upstream, ok := byUID[d.RuleUID]
+			if !ok {
+				return fmt.Errorf("%w: rule [%d] depends on rule %s that is not in the rule group", ngmodels.ErrAlertRuleFailedValidation, idx, d.RuleUID)
+			}
+			if d.Condition != ngmodels.DependencyConditionNone && upstream.Type() == ngmodels.RuleTypeRecording {
+				return fmt.Errorf("%w: rule [%d] cannot depend on the state of recording rule %s", ngmodels.ErrAlertRuleFailedValidation, idx, d.RuleUID)
+			}
+		}
+	}
+
+	// depth-first search that keeps the path to report the cycle
+	const (
+		visiting = iota + 1
+		visited
+	)
+	status := make(map[string]int, len(byUID))
+	var path []string
+	var visit func(uid string) error
+	visit = func(uid string) error {
+		switch status[uid] {
+		case visited:
+			return nil
+		case visiting:
+			start := slices.Index(path, uid)
+			return fmt.Errorf("%w: rule dependencies form a cycle: %s", ngmodels.ErrAlertRuleFailedValidation, strings.Join(append(path[start:], uid), " -> "))
+		}
+		status[uid] = visiting
+		path = append(path, uid)
+		for _, d := range byUID[uid].Dependencies {
+			if err := visit(d.RuleUID); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		status[uid] = visited
+		return nil
+	}
+	for _, rule := range rules {
+		if rule.UID == "" {
+			continue
+		}
+		if err := visit(rule.UID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func validateNotificationSettings(n *apimodels.AlertRuleNotificationSettings) ([]ngmodels.NotificationSettings, error) {
 	s := ngmodels.NotificationSettings{
 		Receiver:          n.Receiver,
@@ -88,6 +88,17 @@ func validRule() apimodels.PostableExtendedRuleNode {
 	}
 }
 
+func validRecordingRule() apimodels.PostableExtendedRuleNode {
+	r := validRule()
+	r.GrafanaManagedAlert.Record = &apimodels.Record{Metric: "some_metric", From: "A"}
+	r.GrafanaManagedAlert.UID = ""
+	r.GrafanaManagedAlert.Condition = ""
+	r.GrafanaManagedAlert.NoDataState = ""
+	r.GrafanaManagedAlert.ExecErrState = ""
+	r.ApiRuleNode.For = nil
+	return r
+}
+
 func validGroup(cfg *setting.UnifiedAlertingSettings, rules ...apimodels.PostableExtendedRuleNode) apimodels.PostableRuleGroupConfig {
 	return apimodels.PostableRuleGroupConfig{
 		Name:     "TEST-ALERTS-" + util.GenerateShortUID(),
@@ -226,6 +237,37 @@ func TestValidateRuleGroup(t *testing.T) {
 		}
 	})
 
+	t.Run("should convert rule dependencies", func(t *testing.T) {
+		upstream := validRecordingRule()
+		upstream.GrafanaManagedAlert.UID = util.GenerateShortUID()
+		gate := validRule()
+		downstream := validRule()
+		downstream.GrafanaManagedAlert.Dependencies = []apimodels.RuleDependency{
+			{RuleUID: upstream.GrafanaManagedAlert.UID},
+			{RuleUID: gate.GrafanaManagedAlert.UID, Condition: string(models.DependencyConditionNotFiring)},
+		}
+		g := validGroup(cfg, downstream, gate, upstream)
+		alerts, err := ValidateRuleGroup(&g, orgId, folder.UID, *allowRecording(limits))
+		require.NoError(t, err)
+		require.Equal(t, []models.RuleDependency{
+			{RuleUID: upstream.GrafanaManagedAlert.UID},
+			{RuleUID: gate.GrafanaManagedAlert.UID, Condition: models.DependencyConditionNotFiring},
+		}, alerts[0].Dependencies)
+		require.Nil(t, alerts[1].Dependencies)
+	})
+
+	t.Run("should reject dependency on the state of a recording rule", func(t *testing.T) {
+		upstream := validRecordingRule()
+		upstream.GrafanaManagedAlert.UID = util.GenerateShortUID()
+		downstream := validRule()
+		downstream.GrafanaManagedAlert.Dependencies = []apimodels.RuleDependency{
+			{RuleUID: upstream.GrafanaManagedAlert.UID, Condition: string(models.DependencyConditionFiring)},
+		}
+		g := validGroup(cfg, downstream, upstream)
+		_, err := ValidateRuleGroup(&g, orgId, folder.UID, *allowRecording(limits))
+		require.ErrorContains(t, err, "cannot depend on the state of recording rule")
+	})
+
 	t.Run("should show the payload has isPaused field", func(t *testing.T) {
 		for _, rule := range rules {
 			isPaused := true
@@ -301,6 +343,53 @@ func TestValidateRuleGroupFailures(t *testing.T) {
 				require.Contains(t, err.Error(), apiModel.Rules[0].GrafanaManagedAlert.UID)
 			},
 		},
+		{
+			name: "fail if rule depends on a rule that is not in the group",
+			group: func() *apimodels.PostableRuleGroupConfig {
+				r1 := validRule()
+				r1.GrafanaManagedAlert.Dependencies = []apimodels.RuleDependency{{RuleUID: util.GenerateShortUID()}}
+				g := validGroup(cfg, r1, validRule())
+				return &g
+			},
+			assert: func(t *testing.T, apiModel *apimodels.PostableRuleGroupConfig, err error) {
+				require.ErrorContains(t, err, "is not in the rule group")
+			},
+		},
+		{
+			name: "fail if rule depends on itself",
+			group: func() *apimodels.PostableRuleGroupConfig {
+				r1 := validRule()
+				r1.GrafanaManagedAlert.Dependencies = []apimodels.RuleDependency{{RuleUID: r1.GrafanaManagedAlert.UID}}
+				g := validGroup(cfg, r1)
+				return &g
+			},
+		},
+		{
+			name: "fail if dependency condition is unknown",
+			group: func() *apimodels.PostableRuleGroupConfig {
+				r1 := validRule()
+				r2 := validRule()
+				r1.GrafanaManagedAlert.Dependencies = []apimodels.RuleDependency{{RuleUID: r2.GrafanaManagedAlert.UID, Condition: "pending"}}
+				g := validGroup(cfg, r1, r2)
+				return &g
+			},
+		},
+		{
+			name: "fail if dependencies form a cycle",
+			group: func() *apimodels.PostableRuleGroupConfig {
+				r1, r2, r3 := validRule(), validRule(), validRule()
+				r1.GrafanaManagedAlert.Dependencies = []apimodels.RuleDependency{{RuleUID: r2.GrafanaManagedAlert.UID}}
+				r2.GrafanaManagedAlert.Dependencies = []apimodels.RuleDependency{{RuleUID: r3.GrafanaManagedAlert.UID}}
+				r3.GrafanaManagedAlert.Dependencies = []apimodels.RuleDependency{{RuleUID: r1.GrafanaManagedAlert.UID, Condition: string(models.DependencyConditionNotFiring)}}
+				g := validGroup(cfg, r1, r2, r3)
+				return &g
+			},
+			assert: func(t *testing.T, apiModel *apimodels.PostableRuleGroupConfig, err error) {
+				require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+				require.ErrorContains(t, err, "cycle")
+				require.ErrorContains(t, err, apiModel.Rules[0].GrafanaManagedAlert.UID+" -> "+apiModel.Rules[1].GrafanaManagedAlert.UID)
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -29,6 +30,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/backtesting"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/setting"
@@ -39,6 +41,10 @@ type folderService interface {
 	GetNamespaceByUID(ctx context.Context, uid string, orgID int64, user identity.Requester) (*folder.Folder, error)
 }
 
+type ruleLister interface {
+	ListAlertRules(ctx context.Context, query *ngmodels.ListAlertRulesQuery) (ngmodels.RulesGroup, error)
+}
+
 type TestingApiSrv struct {
 	*AlertingProxy
 	DatasourceCache datasources.CacheService
@@ -51,6 +57,8 @@ type TestingApiSrv struct {
 	appUrl          *url.URL
 	tracer          tracing.Tracer
 	folderService   folderService
+	ruleStore       ruleLister
+	amConfigStore   AMConfigStore
 }
 
 // RouteTestGrafanaRuleConfig returns a list of potential alerts for a given rule configuration. This is intended to be
@@ -286,3 +294,139 @@ func (srv TestingApiSrv) BacktestAlertRule(c *contextmodel.ReqContext, cmd apimo
 	}
 	return response.JSON(http.StatusOK, body)
 }
+
+// BacktestRuleGroup backtests the rules of a rule group, or of all rule groups of a folder, and replays the alerts
+// they would have fired through the notification policy tree.
+func (srv TestingApiSrv) BacktestRuleGroup(c *contextmodel.ReqContext, cmd apimodels.BacktestRuleGroupConfig) response.Response {
+	if !srv.featureManager.IsEnabled(c.Req.Context(), featuremgmt.FlagAlertingBacktesting) {
+		return ErrResp(http.StatusNotFound, nil, "Backtesting API is not enabled")
+	}
+
+	if !cmd.From.Before(cmd.To) {
+		return ErrResp(http.StatusBadRequest, nil, "From must be less than To")
+	}
+
+	namespace, err := srv.folderService.GetNamespaceByUID(c.Req.Context(), cmd.NamespaceUID, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return toNamespaceErrorResponse(err)
+	}
+
+	query := ngmodels.ListAlertRulesQuery{
+		OrgID:         c.SignedInUser.GetOrgID(),
+		NamespaceUIDs: []string{namespace.UID},
+	}
+	if cmd.RuleGroup != "" {
+		query.RuleGroups = []string{cmd.RuleGroup}
+	}
+	rules, err := srv.ruleStore.ListAlertRules(c.Req.Context(), &query)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to get rules")
+	}
+	if len(rules) == 0 {
+		return ErrResp(http.StatusNotFound, nil, "no rules found in the rule group")
+	}
+
+	groups := make(map[ngmodels.AlertRuleGroupKey]ngmodels.RulesGroup)
+	for _, rule := range rules {
+		groups[rule.GetGroupKey()] = append(groups[rule.GetGroupKey()], rule)
+	}
+	for _, group := range groups {
+		if err := srv.authz.AuthorizeAccessToRuleGroup(c.Req.Context(), c.SignedInUser, group); err != nil {
+			return response.ErrOrFallback(http.StatusInternalServerError, "failed to authorize access to rule group", err)
+		}
+	}
+
+	policy := backtesting.NotificationPolicy{
+		Route:             cmd.Route,
+		MuteTimeIntervals: cmd.MuteTimeIntervals,
+		TimeIntervals:     cmd.TimeIntervals,
+	}
+	if policy.Route != nil {
+		if err := policy.Route.Validate(); err != nil {
+			return ErrResp(http.StatusBadRequest, err, "invalid notification policy tree")
+		}
+	} else {
+		policy, err = srv.currentNotificationPolicy(c.Req.Context(), c.SignedInUser.GetOrgID())
+		if err != nil {
+			return ErrResp(http.StatusInternalServerError, err, "")
+		}
+	}
+
+	extraLabels := data.Labels{}
+	if !srv.cfg.ReservedLabels.IsReservedLabelDisabled(models.FolderTitleLabel) {
+		extraLabels[models.FolderTitleLabel] = namespace.Fullpath
+	}
+
+	results := srv.backtesting.TestGroup(c.Req.Context(), c.SignedInUser, rules, extraLabels, cmd.From, cmd.To)
+
+	body := apimodels.BacktestRuleGroupResult{
+		Rules: make([]apimodels.BacktestRuleResult, 0, len(results)),
+	}
+	var alerts []backtesting.Alert
+	for _, result := range results {
+		ruleResult := apimodels.BacktestRuleResult{
+			UID:       result.Rule.UID,
+			Title:     result.Rule.Title,
+			RuleGroup: result.Rule.RuleGroup,
+		}
+		if result.Err != nil {
+			ruleResult.Error = result.Err.Error()
+		} else {
+			frame, err := data.FrameToJSON(result.Frame, data.IncludeAll)
+			if err != nil {
+				return ErrResp(http.StatusInternalServerError, err, "Failed to convert frame to JSON")
+			}
+			ruleResult.Result = frame
+			alerts = append(alerts, result.Alerts...)
+		}
+		body.Rules = append(body.Rules, ruleResult)
+	}
+
+	receivers, err := backtesting.SimulateNotifications(policy, alerts, cmd.From, cmd.To)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "Failed to simulate notifications")
+	}
+	body.Receivers = make([]apimodels.BacktestReceiverResult, 0, len(receivers))
+	for _, receiver := range receivers {
+		r := apimodels.BacktestReceiverResult{
+			Receiver: receiver.Receiver,
+			Groups:   make([]apimodels.BacktestGroupResult, 0, len(receiver.Groups)),
+		}
+		for _, group := range receiver.Groups {
+			g := apimodels.BacktestGroupResult{
+				GroupLabels:   group.GroupLabels,
+				Alerts:        group.Alerts,
+				Notifications: make([]apimodels.BacktestNotification, 0, len(group.Notifications)),
+				Muted:         group.Muted,
+			}
+			for _, n := range group.Notifications {
+				g.Notifications = append(g.Notifications, apimodels.BacktestNotification{
+					Time:     n.Time,
+					Firing:   n.Firing,
+					Resolved: n.Resolved,
+				})
+			}
+			r.Groups = append(r.Groups, g)
+		}
+		body.Receivers = append(body.Receivers, r)
+	}
+
+	return response.JSON(http.StatusOK, body)
+}
+
+// currentNotificationPolicy returns the notification policy tree of the latest Alertmanager configuration of the organization.
+func (srv TestingApiSrv) currentNotificationPolicy(ctx context.Context, orgID int64) (backtesting.NotificationPolicy, error) {
+	dbConfig, err := srv.amConfigStore.GetLatestAlertmanagerConfiguration(ctx, orgID)
+	if err != nil {
+		return backtesting.NotificationPolicy{}, fmt.Errorf("failed to get latest configuration: %w", err)
+	}
+	cfg, err := notifier.Load([]byte(dbConfig.AlertmanagerConfiguration))
+	if err != nil {
+		return backtesting.NotificationPolicy{}, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+	return backtesting.NotificationPolicy{
+		Route:             cfg.AlertmanagerConfig.Route,
+		MuteTimeIntervals: cfg.AlertmanagerConfig.MuteTimeIntervals,
+		TimeIntervals:     cfg.AlertmanagerConfig.TimeIntervals,
+	}, nil
+}
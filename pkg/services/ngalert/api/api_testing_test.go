@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"testing"
@@ -391,6 +392,60 @@ func TestRouteEvalQueries(t *testing.T) {
 	})
 }
 
+func TestBacktestRuleGroup(t *testing.T) {
+	rc := &contextmodel.ReqContext{
+		Context: &web.Context{
+			Req: &http.Request{},
+		},
+		SignedInUser: &user.SignedInUser{
+			OrgID: 1,
+		},
+	}
+	from := time.Now().Add(-time.Hour)
+	to := time.Now()
+	features := featuremgmt.WithFeatures(featuremgmt.FlagAlertingBacktesting)
+
+	t.Run("should return NotFound if backtesting is disabled", func(t *testing.T) {
+		srv := createTestingApiSrv(t, nil, nil, nil, featuremgmt.WithFeatures(), fakes2.NewRuleStore(t))
+		response := srv.BacktestRuleGroup(rc, definitions.BacktestRuleGroupConfig{From: from, To: to})
+		require.Equal(t, http.StatusNotFound, response.Status())
+	})
+
+	t.Run("should return BadRequest if time range is invalid", func(t *testing.T) {
+		srv := createTestingApiSrv(t, nil, nil, nil, features, fakes2.NewRuleStore(t))
+		response := srv.BacktestRuleGroup(rc, definitions.BacktestRuleGroupConfig{From: to, To: from})
+		require.Equal(t, http.StatusBadRequest, response.Status())
+	})
+
+	t.Run("should return NotFound if the group has no rules", func(t *testing.T) {
+		f := randFolder()
+		ruleStore := fakes2.NewRuleStore(t)
+		ruleStore.Folders[rc.OrgID] = []*folder.Folder{f}
+		srv := createTestingApiSrv(t, nil, nil, nil, features, ruleStore)
+
+		response := srv.BacktestRuleGroup(rc, definitions.BacktestRuleGroupConfig{From: from, To: to, NamespaceUID: f.UID, RuleGroup: "group"})
+		require.Equal(t, http.StatusNotFound, response.Status())
+	})
+
+	t.Run("should return Forbidden if user cannot query a data source of the group", func(t *testing.T) {
+		f := randFolder()
+		ruleStore := fakes2.NewRuleStore(t)
+		ruleStore.Folders[rc.OrgID] = []*folder.Folder{f}
+		gen := models.RuleGen
+		rules := gen.With(gen.WithOrgID(rc.OrgID), gen.WithNamespaceUID(f.UID), gen.WithGroupName("group")).GenerateManyRef(2)
+		ruleStore.PutRule(context.Background(), rules...)
+
+		ac := acMock.New().WithPermissions([]ac.Permission{
+			{Action: dashboards.ActionFoldersRead, Scope: dashboards.ScopeFoldersProvider.GetResourceScopeUID(f.UID)},
+			{Action: datasources.ActionQuery, Scope: datasources.ScopeProvider.GetResourceScopeUID(rules[0].Data[0].DatasourceUID)},
+		})
+		srv := createTestingApiSrv(t, nil, ac, nil, features, ruleStore)
+
+		response := srv.BacktestRuleGroup(rc, definitions.BacktestRuleGroupConfig{From: from, To: to, NamespaceUID: f.UID, RuleGroup: "group"})
+		require.Equal(t, http.StatusForbidden, response.Status())
+	})
+}
+
 func createTestingApiSrv(t *testing.T, ds *fakes.FakeCacheService, ac *acMock.Mock, evaluator eval.EvaluatorFactory, featureManager featuremgmt.FeatureToggles, ruleStore RuleStore) *TestingApiSrv {
 	if ac == nil {
 		ac = acMock.New()
@@ -404,5 +459,6 @@ func createTestingApiSrv(t *testing.T, ds *fakes.FakeCacheService, ac *acMock.Mo
 		tracer:          tracing.InitializeTracerForTest(),
 		featureManager:  featureManager,
 		folderService:   ruleStore,
+		ruleStore:       ruleStore,
 	}
 }
@@ -65,7 +65,8 @@ func (api *API) authorize(method, path string) web.Handler {
 		)
 
 	// Grafana rule state history paths
-	case http.MethodGet + "/api/v1/rules/history":
+	case http.MethodGet + "/api/v1/rules/history",
+		http.MethodGet + "/api/v1/rules/history/stats":
 		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
 
 	// Grafana receivers paths
@@ -96,6 +97,9 @@ func (api *API) authorize(method, path string) web.Handler {
 	case http.MethodPost + "/api/v1/rule/backtest":
 		// additional authorization is done in the request handler
 		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
+	case http.MethodPost + "/api/v1/rule/backtest/group":
+		// additional authorization is done in the request handler
+		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
 	case http.MethodPost + "/api/v1/eval":
 		// additional authorization is done in the request handler
 		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
@@ -15,6 +15,10 @@ import (
 
 // AlertRuleFromProvisionedAlertRule converts definitions.ProvisionedAlertRule to models.AlertRule
 func AlertRuleFromProvisionedAlertRule(a definitions.ProvisionedAlertRule) (models.AlertRule, error) {
+	dependencies, err := validateDependencies(a.Dependencies, a.Record != nil)
+	if err != nil {
+		return models.AlertRule{}, err
+	}
 	return models.AlertRule{
 		ID:                   a.ID,
 		UID:                  a.UID,
@@ -33,6 +37,7 @@ func AlertRuleFromProvisionedAlertRule(a definitions.ProvisionedAlertRule) (mode
 		IsPaused:             a.IsPaused,
 		NotificationSettings: NotificationSettingsFromAlertRuleNotificationSettings(a.NotificationSettings),
 		Record:               ModelRecordFromApiRecord(a.Record),
+		Dependencies:         dependencies,
 	}, nil
 }
 
@@ -57,6 +62,7 @@ func ProvisionedAlertRuleFromAlertRule(rule models.AlertRule, provenance models.
 		IsPaused:             rule.IsPaused,
 		NotificationSettings: AlertRuleNotificationSettingsFromNotificationSettings(rule.NotificationSettings),
 		Record:               ApiRecordFromModelRecord(rule.Record),
+		Dependencies:         ApiRuleDependenciesFromModelRuleDependencies(rule.Dependencies),
 	}
 }
 
@@ -111,12 +117,17 @@ func AlertRuleGroupFromApiAlertRuleGroup(a definitions.AlertRuleGroup) (models.A
 		FolderUID: a.FolderUID,
 		Interval:  a.Interval,
 	}
+	rules := make([]*models.AlertRuleWithOptionals, 0, len(a.Rules))
 	for i := range a.Rules {
 		converted, err := AlertRuleFromProvisionedAlertRule(a.Rules[i])
 		if err != nil {
 			return models.AlertRuleGroup{}, err
 		}
 		ruleGroup.Rules = append(ruleGroup.Rules, converted)
+		rules = append(rules, &models.AlertRuleWithOptionals{AlertRule: converted})
+	}
+	if err := validateGroupDependencies(rules); err != nil {
+		return models.AlertRuleGroup{}, err
 	}
 	return ruleGroup, nil
 }
@@ -192,6 +203,7 @@ func AlertRuleExportFromAlertRule(rule models.AlertRule) (definitions.AlertRuleE
 		IsPaused:             rule.IsPaused,
 		NotificationSettings: AlertRuleNotificationSettingsExportFromNotificationSettings(rule.NotificationSettings),
 		Record:               AlertRuleRecordExportFromRecord(rule.Record),
+		Dependencies:         AlertRuleDependencyExportsFromRuleDependencies(rule.Dependencies),
 	}
 	if rule.For.Seconds() > 0 {
 		result.ForString = util.Pointer(model.Duration(rule.For).String())
@@ -463,6 +475,7 @@ func AlertRuleRecordExportFromRecord(r *models.Record) *definitions.AlertRuleRec
 	return &definitions.AlertRuleRecordExport{
 		Metric: r.Metric,
 		From:   r.From,
+		Target: string(r.Target),
 	}
 }
 
@@ -473,6 +486,7 @@ func ModelRecordFromApiRecord(r *definitions.Record) *models.Record {
 	return &models.Record{
 		Metric: r.Metric,
 		From:   r.From,
+		Target: models.RecordTarget(r.Target),
 	}
 }
 
@@ -483,5 +497,108 @@ func ApiRecordFromModelRecord(r *models.Record) *definitions.Record {
 	return &definitions.Record{
 		Metric: r.Metric,
 		From:   r.From,
+		Target: string(r.Target),
+	}
+}
+
+func ModelRuleDependenciesFromApiRuleDependencies(deps []definitions.RuleDependency) []models.RuleDependency {
+	if len(deps) == 0 {
+		return nil
+	}
+	result := make([]models.RuleDependency, 0, len(deps))
+	for _, d := range deps {
+		result = append(result, models.RuleDependency{
+			RuleUID:   d.RuleUID,
+			Condition: models.DependencyCondition(d.Condition),
+		})
+	}
+	return result
+}
+
+func ApiRuleDependenciesFromModelRuleDependencies(deps []models.RuleDependency) []definitions.RuleDependency {
+	if len(deps) == 0 {
+		return nil
+	}
+	result := make([]definitions.RuleDependency, 0, len(deps))
+	for _, d := range deps {
+		result = append(result, definitions.RuleDependency{
+			RuleUID:   d.RuleUID,
+			Condition: string(d.Condition),
+		})
+	}
+	return result
+}
+
+// AlertRuleDependencyExportsFromRuleDependencies creates a collection of definitions.AlertRuleDependencyExport DTO from models.RuleDependency.
+func AlertRuleDependencyExportsFromRuleDependencies(deps []models.RuleDependency) []definitions.AlertRuleDependencyExport {
+	if len(deps) == 0 {
+		return nil
+	}
+	result := make([]definitions.AlertRuleDependencyExport, 0, len(deps))
+	for _, d := range deps {
+		result = append(result, definitions.AlertRuleDependencyExport{
+			RuleUID:   d.RuleUID,
+			Condition: string(d.Condition),
+		})
+	}
+	return result
+}
+
+func ApiStateHistoryStatsFromModelHistoryStats(stats *models.HistoryStats) definitions.StateHistoryStats {
+	result := definitions.StateHistoryStats{
+		From:        stats.From,
+		To:          stats.To,
+		Transitions: stats.Transitions,
+		Truncated:   stats.Truncated,
+		Rules:       make([]definitions.RuleStateHistoryStats, 0, len(stats.Rules)),
+		Groups:      make([]definitions.GroupStateHistoryStats, 0, len(stats.Groups)),
+		Flapping:    apiInstanceStateHistoryStatsFromModel(stats.Flapping),
+		TopNoisy:    apiInstanceStateHistoryStatsFromModel(stats.TopNoisy),
+	}
+	for _, r := range stats.Rules {
+		result.Rules = append(result.Rules, definitions.RuleStateHistoryStats{
+			StateTransitionStats: apiStateTransitionStatsFromModel(r.TransitionStats),
+			RuleUID:              r.RuleUID,
+			RuleTitle:            r.RuleTitle,
+			Instances:            r.Instances,
+			FlappingInstances:    r.FlappingInstances,
+		})
+	}
+	for _, g := range stats.Groups {
+		result.Groups = append(result.Groups, definitions.GroupStateHistoryStats{
+			StateTransitionStats: apiStateTransitionStatsFromModel(g.TransitionStats),
+			Labels:               g.Labels,
+			Instances:            g.Instances,
+		})
+	}
+	return result
+}
+
+func apiInstanceStateHistoryStatsFromModel(instances []models.InstanceHistoryStats) []definitions.InstanceStateHistoryStats {
+	result := make([]definitions.InstanceStateHistoryStats, 0, len(instances))
+	for _, i := range instances {
+		result = append(result, definitions.InstanceStateHistoryStats{
+			StateTransitionStats: apiStateTransitionStatsFromModel(i.TransitionStats),
+			RuleUID:              i.RuleUID,
+			RuleTitle:            i.RuleTitle,
+			Labels:               i.Labels,
+			Flapping:             i.Flapping,
+		})
+	}
+	return result
+}
+
+func apiStateTransitionStatsFromModel(stats models.TransitionStats) definitions.StateTransitionStats {
+	timeInState := make(map[string]float64, len(stats.TimeInState))
+	for s, d := range stats.TimeInState {
+		timeInState[s] = d.Seconds()
+	}
+	return definitions.StateTransitionStats{
+		Transitions:              stats.Transitions,
+		TransitionsPerHour:       stats.TransitionsPerHour,
+		Firings:                  stats.Firings,
+		Resolutions:              stats.Resolutions,
+		MeanTimeToResolveSeconds: stats.MeanTimeToResolve.Seconds(),
+		TimeInStateSeconds:       timeInState,
 	}
 }
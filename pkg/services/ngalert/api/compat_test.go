@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
 )
 
 func TestToModel(t *testing.T) {
@@ -34,4 +35,42 @@ func TestToModel(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, tm.Rules, 1)
 	})
+	t.Run("rule dependencies are converted and validated", func(t *testing.T) {
+		ruleGroup := definitions.AlertRuleGroup{
+			Title:     "123",
+			FolderUID: "123",
+			Interval:  10,
+			Rules: []definitions.ProvisionedAlertRule{
+				{UID: "1"},
+				{UID: "2", Dependencies: []definitions.RuleDependency{{RuleUID: "1", Condition: "firing"}}},
+			},
+		}
+		tm, err := AlertRuleGroupFromApiAlertRuleGroup(ruleGroup)
+		require.NoError(t, err)
+		require.Equal(t, []ngmodels.RuleDependency{{RuleUID: "1", Condition: ngmodels.DependencyConditionFiring}}, tm.Rules[1].Dependencies)
+
+		ruleGroup.Rules[1].Dependencies = []definitions.RuleDependency{{RuleUID: "1", Condition: "pending"}}
+		_, err = AlertRuleGroupFromApiAlertRuleGroup(ruleGroup)
+		require.ErrorIs(t, err, ngmodels.ErrAlertRuleFailedValidation)
+
+		ruleGroup.Rules[1].Dependencies = []definitions.RuleDependency{{RuleUID: "3"}}
+		_, err = AlertRuleGroupFromApiAlertRuleGroup(ruleGroup)
+		require.ErrorIs(t, err, ngmodels.ErrAlertRuleFailedValidation)
+	})
+}
+
+func TestProvisionedAlertRuleDependencies(t *testing.T) {
+	gen := ngmodels.RuleGen
+	rule := gen.With(gen.WithDependencies(ngmodels.RuleDependency{RuleUID: "upstream", Condition: ngmodels.DependencyConditionNotFiring})).GenerateRef()
+
+	provisioned := ProvisionedAlertRuleFromAlertRule(*rule, ngmodels.ProvenanceNone)
+	require.Equal(t, []definitions.RuleDependency{{RuleUID: "upstream", Condition: "not_firing"}}, provisioned.Dependencies)
+
+	converted, err := AlertRuleFromProvisionedAlertRule(provisioned)
+	require.NoError(t, err)
+	require.Equal(t, rule.Dependencies, converted.Dependencies)
+
+	export, err := AlertRuleExportFromAlertRule(*rule)
+	require.NoError(t, err)
+	require.Equal(t, []definitions.AlertRuleDependencyExport{{RuleUID: "upstream", Condition: "not_firing"}}, export.Dependencies)
 }
@@ -19,11 +19,15 @@ import (
 
 type HistoryApi interface {
 	RouteGetStateHistory(*contextmodel.ReqContext) response.Response
+	RouteGetStateHistoryStats(*contextmodel.ReqContext) response.Response
 }
 
 func (f *HistoryApiHandler) RouteGetStateHistory(ctx *contextmodel.ReqContext) response.Response {
 	return f.handleRouteGetStateHistory(ctx)
 }
+func (f *HistoryApiHandler) RouteGetStateHistoryStats(ctx *contextmodel.ReqContext) response.Response {
+	return f.handleRouteGetStateHistoryStats(ctx)
+}
 
 func (api *API) RegisterHistoryApiEndpoints(srv HistoryApi, m *metrics.API) {
 	api.RouteRegister.Group("", func(group routing.RouteRegister) {
@@ -39,5 +43,17 @@ func (api *API) RegisterHistoryApiEndpoints(srv HistoryApi, m *metrics.API) {
 				m,
 			),
 		)
+		group.Get(
+			toMacaronPath("/api/v1/rules/history/stats"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			requestmeta.SetSLOGroup(requestmeta.SLOGroupHighSlow),
+			api.authorize(http.MethodGet, "/api/v1/rules/history/stats"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/rules/history/stats",
+				api.Hooks.Wrap(srv.RouteGetStateHistoryStats),
+				m,
+			),
+		)
 	}, middleware.ReqSignedIn)
 }
@@ -487,6 +487,22 @@ type Record struct {
 	// required: true
 	// example: A
 	From string `json:"from" yaml:"from"`
+	// Where the recorded metric is written to: prometheus, influxdb, sql or live. Defaults to prometheus.
+	// required: false
+	// example: prometheus
+	Target string `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+// swagger:model
+type RuleDependency struct {
+	// UID of a rule of the same rule group that is evaluated before this rule.
+	// required: true
+	RuleUID string `json:"rule_uid" yaml:"rule_uid"`
+	// State of the upstream rule that is required for this rule to fire: firing or not_firing.
+	// The dependency only orders the evaluation of the rules if it is empty.
+	// required: false
+	// example: not_firing
+	Condition string `json:"condition,omitempty" yaml:"condition,omitempty"`
 }
 
 // swagger:model
@@ -500,6 +516,7 @@ type PostableGrafanaRule struct {
 	IsPaused             *bool                          `json:"is_paused" yaml:"is_paused"`
 	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings" yaml:"notification_settings"`
 	Record               *Record                        `json:"record" yaml:"record"`
+	Dependencies         []RuleDependency               `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 }
 
 // swagger:model
@@ -521,6 +538,7 @@ type GettableGrafanaRule struct {
 	IsPaused             bool                           `json:"is_paused" yaml:"is_paused"`
 	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty"`
 	Record               *Record                        `json:"record,omitempty" yaml:"record,omitempty"`
+	Dependencies         []RuleDependency               `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 }
 
 // AlertQuery represents a single query associated with an alert definition.
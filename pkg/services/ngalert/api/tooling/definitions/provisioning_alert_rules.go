@@ -169,6 +169,8 @@ type ProvisionedAlertRule struct {
 	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings"`
 	//example: {"metric":"grafana_alerts_ratio", "from":"A"}
 	Record *Record `json:"record"`
+	// example: [{"rule_uid":"upstream_rule","condition":"not_firing"}]
+	Dependencies []RuleDependency `json:"dependencies,omitempty"`
 }
 
 // swagger:route GET /v1/provisioning/folder/{FolderUID}/rule-groups/{Group} provisioning stable RouteGetAlertRuleGroup
@@ -276,6 +278,7 @@ type AlertRuleExport struct {
 	IsPaused             bool                                 `json:"isPaused" yaml:"isPaused" hcl:"is_paused"`
 	NotificationSettings *AlertRuleNotificationSettingsExport `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty" hcl:"notification_settings,block"`
 	Record               *AlertRuleRecordExport               `json:"record,omitempty" yaml:"record,omitempty" hcl:"record"`
+	Dependencies         []AlertRuleDependencyExport          `json:"dependencies,omitempty" yaml:"dependencies,omitempty" hcl:"dependency,block"`
 }
 
 // AlertQueryExport is the provisioned export of models.AlertQuery.
@@ -309,4 +312,11 @@ type AlertRuleNotificationSettingsExport struct {
 type AlertRuleRecordExport struct {
 	Metric string `json:"metric" yaml:"metric" hcl:"metric"`
 	From   string `json:"from" yaml:"from" hcl:"from"`
+	Target string `json:"target,omitempty" yaml:"target,omitempty" hcl:"target,optional"`
+}
+
+// AlertRuleDependencyExport is the provisioned export of models.RuleDependency.
+type AlertRuleDependencyExport struct {
+	RuleUID   string `json:"rule_uid" yaml:"rule_uid" hcl:"rule_uid"`
+	Condition string `json:"condition,omitempty" yaml:"condition,omitempty" hcl:"condition,optional"`
 }
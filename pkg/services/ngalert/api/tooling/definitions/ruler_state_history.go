@@ -1,6 +1,10 @@
 package definitions
 
-import "github.com/grafana/grafana-plugin-sdk-go/data"
+import (
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
 
 // swagger:route GET /v1/rules/history history RouteGetStateHistory
 //
@@ -51,3 +55,119 @@ type StateHistoryParams struct {
 	// Filter by dashboard's panel ID. Requires Dashboard UID to be specified.
 	PanelID int64
 }
+
+// swagger:route GET /v1/rules/history/stats history RouteGetStateHistoryStats
+//
+// Aggregate state history.
+//
+// Aggregates the state history of the rules the user can read: the flapping alert instances, the mean time to resolve
+// per rule and per values of labels, the noisiest alert instances and the time spent in each state.
+// It accepts the same filter by labels as RouteGetStateHistory.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: StateHistoryStats
+//       400: ValidationError
+//       403: ForbiddenError
+//       500: Failure
+
+// StateHistoryStatsParams is the struct used as parameters for the RouteGetStateHistoryStats endpoint.
+//
+// swagger:parameters RouteGetStateHistoryStats
+type StateHistoryStatsParams struct {
+	// The timestamp of the start point of the time range that is aggregated. Defaults to 7 days before the end point.
+	// in:query
+	// required: false
+	From int64 `json:"from"`
+	// The timestamp of the end point of the time range that is aggregated. Defaults to now.
+	// in:query
+	// required: false
+	To int64 `json:"to"`
+	// Limits the number of state transitions that are aggregated.
+	// in:query
+	// required: false
+	Limit int `json:"limit"`
+	// Aggregate the state history of a single rule.
+	// in:query
+	// required: false
+	RuleUID string `json:"ruleUID"`
+	// Aggregate the state history of the rules that are or were assigned to the specific dashboard.
+	// in:query
+	// required: false
+	DashboardUID string `json:"dashboardUID"`
+	// Filter by dashboard's panel ID. Requires Dashboard UID to be specified.
+	// in:query
+	// required: false
+	PanelID int64 `json:"panelID"`
+	// Labels of the alert instances the state history is also aggregated by.
+	// in:query
+	// required: false
+	GroupBy []string `json:"groupBy"`
+	// The number of the noisiest alert instances to return. Defaults to 10.
+	// in:query
+	// required: false
+	TopK int `json:"topK"`
+	// The number of state transitions per hour from which an alert instance is flapping. Defaults to 4.
+	// in:query
+	// required: false
+	FlappingThreshold float64 `json:"flappingThreshold"`
+}
+
+// swagger:model
+type StateHistoryStats struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// The number of state transitions that were aggregated.
+	Transitions int `json:"transitions"`
+	// Whether the state transitions of the range exceeded the limit, in which case only the most recent ones were aggregated.
+	Truncated bool `json:"truncated"`
+	// The statistics per rule, the rules with the most transitions first.
+	Rules []RuleStateHistoryStats `json:"rules"`
+	// The statistics per values of the groupBy labels, the groups with the most transitions first.
+	Groups []GroupStateHistoryStats `json:"groups"`
+	// The flapping alert instances, the ones with the most transitions per hour first.
+	Flapping []InstanceStateHistoryStats `json:"flapping"`
+	// The alert instances that fired the most.
+	TopNoisy []InstanceStateHistoryStats `json:"topNoisy"`
+}
+
+// swagger:model
+type StateTransitionStats struct {
+	Transitions        int     `json:"transitions"`
+	TransitionsPerHour float64 `json:"transitionsPerHour"`
+	// The number of transitions to Alerting.
+	Firings int `json:"firings"`
+	// The number of transitions back to Normal after a firing.
+	Resolutions int `json:"resolutions"`
+	// The mean time between a firing and its resolution, in seconds.
+	MeanTimeToResolveSeconds float64 `json:"meanTimeToResolveSeconds"`
+	// The time spent in each state, in seconds, summed over the alert instances.
+	TimeInStateSeconds map[string]float64 `json:"timeInStateSeconds"`
+}
+
+// swagger:model
+type RuleStateHistoryStats struct {
+	StateTransitionStats
+	RuleUID           string `json:"ruleUID"`
+	RuleTitle         string `json:"ruleTitle"`
+	Instances         int    `json:"instances"`
+	FlappingInstances int    `json:"flappingInstances"`
+}
+
+// swagger:model
+type GroupStateHistoryStats struct {
+	StateTransitionStats
+	Labels    map[string]string `json:"labels"`
+	Instances int               `json:"instances"`
+}
+
+// swagger:model
+type InstanceStateHistoryStats struct {
+	StateTransitionStats
+	RuleUID   string            `json:"ruleUID"`
+	RuleTitle string            `json:"ruleTitle"`
+	Labels    map[string]string `json:"labels"`
+	Flapping  bool              `json:"flapping"`
+}
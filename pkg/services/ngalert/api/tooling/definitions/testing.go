@@ -68,6 +68,21 @@ import (
 //     Responses:
 //       200: BacktestResult
 
+// swagger:route Post /v1/rule/backtest/group testing BacktestRuleGroup
+//
+// Test the rules of a rule group, or of a folder, and simulate the notifications of the notification policy tree
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: BacktestRuleGroupResult
+//       400: ValidationError
+//       404: NotFound
+
 // swagger:parameters RouteTestReceiverConfig
 type TestReceiverRequest struct {
 	// in:body
@@ -234,3 +249,58 @@ type BacktestConfig struct {
 
 // swagger:model
 type BacktestResult data.Frame
+
+// swagger:parameters BacktestRuleGroup
+type BacktestRuleGroupRequest struct {
+	// in:body
+	Body BacktestRuleGroupConfig
+}
+
+// swagger:model
+type BacktestRuleGroupConfig struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	NamespaceUID string `json:"namespace_uid"`
+	// RuleGroup is the group to test. All rules of the folder are tested if it is empty.
+	RuleGroup string `json:"rule_group,omitempty"`
+
+	// Route is the notification policy tree to simulate. The current policy tree of the organization is used if it is empty.
+	Route             *Route                    `json:"route,omitempty"`
+	MuteTimeIntervals []config.MuteTimeInterval `json:"mute_time_intervals,omitempty"`
+	TimeIntervals     []config.TimeInterval     `json:"time_intervals,omitempty"`
+}
+
+// swagger:model
+type BacktestRuleGroupResult struct {
+	Rules     []BacktestRuleResult     `json:"rules"`
+	Receivers []BacktestReceiverResult `json:"receivers"`
+}
+
+type BacktestRuleResult struct {
+	UID       string          `json:"uid"`
+	Title     string          `json:"title"`
+	RuleGroup string          `json:"rule_group"`
+	Result    json.RawMessage `json:"result,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+type BacktestReceiverResult struct {
+	Receiver string                `json:"receiver"`
+	Groups   []BacktestGroupResult `json:"groups"`
+}
+
+type BacktestGroupResult struct {
+	GroupLabels map[string]string `json:"group_labels"`
+	// Alerts is the number of distinct alerts in the group.
+	Alerts        int                    `json:"alerts"`
+	Notifications []BacktestNotification `json:"notifications"`
+	// Muted is the number of notifications that were not sent because of mute timings.
+	Muted int `json:"muted"`
+}
+
+type BacktestNotification struct {
+	Time     time.Time `json:"time"`
+	Firing   int       `json:"firing"`
+	Resolved int       `json:"resolved"`
+}
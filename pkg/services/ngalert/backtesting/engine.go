@@ -64,14 +64,45 @@ func NewEngine(appUrl *url.URL, evalFactory eval.EvaluatorFactory, tracer tracin
 }
 
 func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models.AlertRule, from, to time.Time) (*data.Frame, error) {
+	result, _, err := e.test(ctx, user, rule, nil, from, to)
+	return result, err
+}
+
+// RuleResult is the result of backtesting a rule of a group.
+type RuleResult struct {
+	Rule   *models.AlertRule
+	Frame  *data.Frame
+	Alerts []Alert
+	Err    error
+}
+
+// TestGroup backtests each rule of a group, or of a folder, over the same time range.
+// The extra labels, like the folder title, are added to the labels of the alerts.
+// An error of a rule does not stop the testing of the other rules, it is returned in its result.
+func (e *Engine) TestGroup(ctx context.Context, user identity.Requester, rules []*models.AlertRule, extraLabels data.Labels, from, to time.Time) []RuleResult {
+	results := make([]RuleResult, 0, len(rules))
+	for _, rule := range rules {
+		result := RuleResult{Rule: rule}
+		if rule.Type() == models.RuleTypeRecording {
+			result.Err = fmt.Errorf("%w: recording rules cannot be backtested", ErrInvalidInputData)
+		} else {
+			result.Frame, result.Alerts, result.Err = e.test(ctx, user, rule, extraLabels, from, to)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// test evaluates the rule over the time range, and returns the states at every evaluation and the periods during which alerts were firing.
+func (e *Engine) test(ctx context.Context, user identity.Requester, rule *models.AlertRule, extraLabels data.Labels, from, to time.Time) (*data.Frame, []Alert, error) {
 	ruleCtx := models.WithRuleKey(ctx, rule.GetKey())
 	logger := logger.FromContext(ctx)
 
 	if !from.Before(to) {
-		return nil, fmt.Errorf("%w: invalid interval of the backtesting [%d,%d]", ErrInvalidInputData, from.Unix(), to.Unix())
+		return nil, nil, fmt.Errorf("%w: invalid interval of the backtesting [%d,%d]", ErrInvalidInputData, from.Unix(), to.Unix())
 	}
 	if to.Sub(from).Seconds() < float64(rule.IntervalSeconds) {
-		return nil, fmt.Errorf("%w: interval of the backtesting [%d,%d] is less than evaluation interval [%ds]", ErrInvalidInputData, from.Unix(), to.Unix(), rule.IntervalSeconds)
+		return nil, nil, fmt.Errorf("%w: interval of the backtesting [%d,%d] is less than evaluation interval [%ds]", ErrInvalidInputData, from.Unix(), to.Unix(), rule.IntervalSeconds)
 	}
 	length := int(to.Sub(from).Seconds()) / int(rule.IntervalSeconds)
 
@@ -82,7 +113,7 @@ func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models
 		Rule:    rule,
 	})
 	if err != nil {
-		return nil, errors.Join(ErrInvalidInputData, err)
+		return nil, nil, errors.Join(ErrInvalidInputData, err)
 	}
 
 	logger.Info("Start testing alert rule", "from", from, "to", to, "interval", rule.IntervalSeconds, "evaluations", length)
@@ -91,15 +122,27 @@ func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models
 
 	tsField := data.NewField("Time", nil, make([]time.Time, length))
 	valueFields := make(map[data.Fingerprint]*data.Field)
+	firing := make(map[data.Fingerprint]*Alert)
+	var alerts []Alert
 
 	err = evaluator.Eval(ruleCtx, from, time.Duration(rule.IntervalSeconds)*time.Second, length, func(idx int, currentTime time.Time, results eval.Results) error {
 		if idx >= length {
 			logger.Info("Unexpected evaluation. Skipping", "from", from, "to", to, "interval", rule.IntervalSeconds, "evaluationTime", currentTime, "evaluationIndex", idx, "expectedEvaluations", length)
 			return nil
 		}
-		states := stateManager.ProcessEvalResults(ruleCtx, currentTime, rule, results, nil, nil)
+		states := stateManager.ProcessEvalResults(ruleCtx, currentTime, rule, results, extraLabels, nil)
 		tsField.Set(idx, currentTime)
 		for _, s := range states {
+			alert, isFiring := firing[s.CacheID]
+			switch {
+			case s.State.State == eval.Alerting && !isFiring:
+				firing[s.CacheID] = &Alert{Labels: s.Labels.Copy(), StartsAt: currentTime}
+			case s.State.State != eval.Alerting && isFiring:
+				alert.EndsAt = currentTime
+				alerts = append(alerts, *alert)
+				delete(firing, s.CacheID)
+			}
+
 			field, ok := valueFields[s.CacheID]
 			if !ok {
 				field = data.NewField("", s.Labels, make([]*string, length))
@@ -124,10 +167,13 @@ func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models
 	result := data.NewFrame("Testing results", fields...)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	for _, alert := range firing {
+		alerts = append(alerts, *alert)
 	}
 	logger.Info("Rule testing finished successfully", "duration", time.Since(start))
-	return result, nil
+	return result, alerts, nil
 }
 
 func newBacktestingEvaluator(ctx context.Context, evalFactory eval.EvaluatorFactory, user identity.Requester, condition models.Condition, reader eval.AlertingResultsReader) (backtestingEvaluator, error) {
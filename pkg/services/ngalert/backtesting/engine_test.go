@@ -411,3 +411,54 @@ func (f *fakeBacktestingEvaluator) Eval(_ context.Context, from time.Time, inter
 	}
 	return nil
 }
+
+func TestEngineTestGroup(t *testing.T) {
+	labels := data.Labels{"instance": "a"}
+	manager := &fakeStateManager{}
+	evaluator := &fakeBacktestingEvaluator{
+		evalCallback: func(now time.Time) (eval.Results, error) {
+			return eval.Results{}, nil
+		},
+	}
+	backtestingEvaluatorFactory = func(ctx context.Context, evalFactory eval.EvaluatorFactory, user identity.Requester, condition models.Condition, r eval.AlertingResultsReader) (backtestingEvaluator, error) {
+		return evaluator, nil
+	}
+	t.Cleanup(func() {
+		backtestingEvaluatorFactory = newBacktestingEvaluator
+	})
+	engine := &Engine{
+		createStateManager: func() stateManager {
+			return manager
+		},
+	}
+
+	from := time.Unix(0, 0)
+	to := from.Add(10 * time.Second)
+	// firing from the 2nd to the 5th evaluation, and again from the 8th
+	manager.stateCallback = func(now time.Time) []state.StateTransition {
+		s := eval.Normal
+		if sec := now.Sub(from) / time.Second; (sec >= 2 && sec < 5) || sec >= 8 {
+			s = eval.Alerting
+		}
+		return []state.StateTransition{{
+			State: &state.State{CacheID: labels.Fingerprint(), Labels: labels, State: s},
+		}}
+	}
+
+	gen := models.RuleGen
+	alerting := gen.With(gen.WithInterval(time.Second)).GenerateRef()
+	recording := gen.With(gen.WithInterval(time.Second), gen.WithAllRecordingRules()).GenerateRef()
+
+	results := engine.TestGroup(context.Background(), nil, []*models.AlertRule{alerting, recording}, nil, from, to)
+	require.Len(t, results, 2)
+
+	require.NoError(t, results[0].Err)
+	require.Equal(t, alerting, results[0].Rule)
+	require.NotNil(t, results[0].Frame)
+	require.Equal(t, []Alert{
+		{Labels: labels, StartsAt: from.Add(2 * time.Second), EndsAt: from.Add(5 * time.Second)},
+		{Labels: labels, StartsAt: from.Add(8 * time.Second)},
+	}, results[0].Alerts)
+
+	require.ErrorIs(t, results[1].Err, ErrInvalidInputData)
+}
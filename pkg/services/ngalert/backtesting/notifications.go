@@ -0,0 +1,252 @@
+package backtesting
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+// Alert is a period during which an alert instance was firing.
+type Alert struct {
+	Labels   data.Labels
+	StartsAt time.Time
+	// EndsAt is zero if the alert was still firing at the end of the backtesting.
+	EndsAt time.Time
+}
+
+func (a *Alert) firingAt(t time.Time) bool {
+	return !a.StartsAt.After(t) && (a.EndsAt.IsZero() || t.Before(a.EndsAt))
+}
+
+// NotificationPolicy is the notification policy tree used to simulate notifications, with the time intervals it refers to.
+type NotificationPolicy struct {
+	Route             *definitions.Route
+	MuteTimeIntervals []config.MuteTimeInterval
+	TimeIntervals     []config.TimeInterval
+}
+
+// ReceiverNotifications are the notifications a receiver would have got.
+type ReceiverNotifications struct {
+	Receiver string
+	Groups   []*GroupNotifications
+}
+
+// GroupNotifications are the notifications of an aggregation group of a route.
+type GroupNotifications struct {
+	GroupLabels data.Labels
+	// Alerts is the number of distinct alerts in the group.
+	Alerts        int
+	Notifications []Notification
+	// Muted is the number of notifications that were not sent because of mute timings.
+	Muted int
+}
+
+// Notification is a single notification sent for a group.
+type Notification struct {
+	Time     time.Time
+	Firing   int
+	Resolved int
+}
+
+// SimulateNotifications replays the alerts through the policy tree the way the Alertmanager dispatcher does:
+// alerts are grouped per matching route, a group is flushed group_wait after it is created and every group_interval
+// after that, and a flush only notifies when the alerts changed or repeat_interval elapsed. Flushes during a mute
+// timing of the route are not notified. Resolved notifications are assumed to be enabled for every receiver.
+// Only notifications in [from, to) are counted.
+func SimulateNotifications(policy NotificationPolicy, alerts []Alert, from, to time.Time) ([]*ReceiverNotifications, error) {
+	if policy.Route == nil {
+		return nil, errors.New("notification policy tree is required")
+	}
+
+	intervals := make(map[string][]timeinterval.TimeInterval, len(policy.MuteTimeIntervals)+len(policy.TimeIntervals))
+	for _, ti := range policy.MuteTimeIntervals {
+		intervals[ti.Name] = ti.TimeIntervals
+	}
+	for _, ti := range policy.TimeIntervals {
+		intervals[ti.Name] = ti.TimeIntervals
+	}
+	intervener := timeinterval.NewIntervener(intervals)
+
+	root := dispatch.NewRoute(policy.Route.AsAMRoute(), nil)
+
+	groups := map[string]*aggregationGroup{}
+	for i := range alerts {
+		alert := &alerts[i]
+		lset := make(model.LabelSet, len(alert.Labels))
+		for k, v := range alert.Labels {
+			lset[model.LabelName(k)] = model.LabelValue(v)
+		}
+		for _, route := range root.Match(lset) {
+			groupLabels := groupLabels(route, alert.Labels)
+			key := route.ID() + ":" + groupLabels.String()
+			g, ok := groups[key]
+			if !ok {
+				g = &aggregationGroup{route: route, labels: groupLabels}
+				groups[key] = g
+			}
+			g.alerts = append(g.alerts, alert)
+		}
+	}
+
+	byReceiver := map[string]*ReceiverNotifications{}
+	for _, g := range groups {
+		result, err := g.simulate(intervener, from, to)
+		if err != nil {
+			return nil, err
+		}
+		receiver := g.route.RouteOpts.Receiver
+		r, ok := byReceiver[receiver]
+		if !ok {
+			r = &ReceiverNotifications{Receiver: receiver}
+			byReceiver[receiver] = r
+		}
+		r.Groups = append(r.Groups, result)
+	}
+
+	result := make([]*ReceiverNotifications, 0, len(byReceiver))
+	for _, r := range byReceiver {
+		sort.Slice(r.Groups, func(i, j int) bool {
+			return r.Groups[i].GroupLabels.String() < r.Groups[j].GroupLabels.String()
+		})
+		result = append(result, r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Receiver < result[j].Receiver
+	})
+	return result, nil
+}
+
+func groupLabels(route *dispatch.Route, labels data.Labels) data.Labels {
+	result := data.Labels{}
+	for k, v := range labels {
+		if _, ok := route.RouteOpts.GroupBy[model.LabelName(k)]; ok || route.RouteOpts.GroupByAll {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+type aggregationGroup struct {
+	route  *dispatch.Route
+	labels data.Labels
+	alerts []*Alert
+}
+
+// notificationLogEntry is what the notification log keeps of the last notification of a group.
+type notificationLogEntry struct {
+	time     time.Time
+	firing   map[data.Fingerprint]struct{}
+	resolved map[data.Fingerprint]struct{}
+}
+
+func (g *aggregationGroup) simulate(intervener *timeinterval.Intervener, from, to time.Time) (*GroupNotifications, error) {
+	opts := g.route.RouteOpts
+	result := &GroupNotifications{GroupLabels: g.labels}
+
+	sort.Slice(g.alerts, func(i, j int) bool {
+		return g.alerts[i].StartsAt.Before(g.alerts[j].StartsAt)
+	})
+	distinct := map[data.Fingerprint]struct{}{}
+	for _, a := range g.alerts {
+		distinct[a.Labels.Fingerprint()] = struct{}{}
+	}
+	result.Alerts = len(distinct)
+
+	// the notification log outlives the aggregation groups
+	var last *notificationLogEntry
+	next := 0
+	for next < len(g.alerts) && g.alerts[next].StartsAt.Before(to) {
+		// the group is created by the first alert and lives until all its alerts are resolved and flushed
+		var current []*Alert
+		flush := g.alerts[next].StartsAt.Add(opts.GroupWait)
+		for ; flush.Before(to); flush = flush.Add(opts.GroupInterval) {
+			for next < len(g.alerts) && !g.alerts[next].StartsAt.After(flush) {
+				current = append(current, g.alerts[next])
+				next++
+			}
+
+			firing := map[data.Fingerprint]struct{}{}
+			resolved := map[data.Fingerprint]struct{}{}
+			remaining := current[:0]
+			for _, a := range current {
+				if a.firingAt(flush) {
+					firing[a.Labels.Fingerprint()] = struct{}{}
+					remaining = append(remaining, a)
+				} else {
+					resolved[a.Labels.Fingerprint()] = struct{}{}
+				}
+			}
+			// an alert that fired again is not resolved
+			for fp := range firing {
+				delete(resolved, fp)
+			}
+
+			if needsUpdate(last, firing, resolved, flush, opts.RepeatInterval) {
+				muted, err := intervener.Mutes(opts.MuteTimeIntervals, flush)
+				if err != nil {
+					return nil, fmt.Errorf("failed to check mute timings of receiver %s: %w", opts.Receiver, err)
+				}
+				switch {
+				case muted:
+					if !flush.Before(from) {
+						result.Muted++
+					}
+				default:
+					if !flush.Before(from) {
+						result.Notifications = append(result.Notifications, Notification{
+							Time:     flush,
+							Firing:   len(firing),
+							Resolved: len(resolved),
+						})
+					}
+					last = &notificationLogEntry{time: flush, firing: firing, resolved: resolved}
+				}
+			}
+
+			// resolved alerts are removed from the group once flushed
+			current = remaining
+			if len(current) == 0 {
+				break
+			}
+		}
+		if !flush.Before(to) {
+			break
+		}
+	}
+	return result, nil
+}
+
+// needsUpdate is the decision of the Alertmanager dedup stage.
+func needsUpdate(last *notificationLogEntry, firing, resolved map[data.Fingerprint]struct{}, now time.Time, repeat time.Duration) bool {
+	if last == nil {
+		return len(firing) > 0
+	}
+	if !isSubset(firing, last.firing) {
+		return true
+	}
+	if len(firing) == 0 {
+		return len(last.firing) > 0
+	}
+	if !isSubset(resolved, last.resolved) {
+		return true
+	}
+	return last.time.Before(now.Add(-repeat))
+}
+
+func isSubset(set, of map[data.Fingerprint]struct{}) bool {
+	for fp := range set {
+		if _, ok := of[fp]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,186 @@
+package backtesting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+func durationPtr(d time.Duration) *model.Duration {
+	md := model.Duration(d)
+	return &md
+}
+
+func TestSimulateNotifications(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(3 * time.Hour)
+
+	newRoute := func(t *testing.T, r *definitions.Route) *definitions.Route {
+		t.Helper()
+		require.NoError(t, r.Validate())
+		return r
+	}
+	alert := func(lbls data.Labels, start, end time.Duration) Alert {
+		a := Alert{Labels: lbls, StartsAt: from.Add(start)}
+		if end > 0 {
+			a.EndsAt = from.Add(end)
+		}
+		return a
+	}
+
+	t.Run("requires a route", func(t *testing.T) {
+		_, err := SimulateNotifications(NotificationPolicy{}, nil, from, to)
+		require.Error(t, err)
+	})
+
+	t.Run("group_wait, group_interval and repeat_interval", func(t *testing.T) {
+		policy := NotificationPolicy{Route: newRoute(t, &definitions.Route{
+			Receiver:       "default",
+			GroupByStr:     []string{"alertname"},
+			GroupWait:      durationPtr(30 * time.Second),
+			GroupInterval:  durationPtr(5 * time.Minute),
+			RepeatInterval: durationPtr(time.Hour),
+		})}
+		alerts := []Alert{
+			alert(data.Labels{"alertname": "a", "instance": "1"}, 0, 0),
+			// joins the group and is notified with the next group_interval
+			alert(data.Labels{"alertname": "a", "instance": "2"}, time.Minute, 0),
+		}
+
+		result, err := SimulateNotifications(policy, alerts, from, to)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "default", result[0].Receiver)
+		require.Len(t, result[0].Groups, 1)
+
+		group := result[0].Groups[0]
+		require.Equal(t, data.Labels{"alertname": "a"}, group.GroupLabels)
+		require.Equal(t, 2, group.Alerts)
+		require.Zero(t, group.Muted)
+		require.Equal(t, []Notification{
+			{Time: from.Add(30 * time.Second), Firing: 1},
+			{Time: from.Add(5*time.Minute + 30*time.Second), Firing: 2},
+			// repeated at the first flush after repeat_interval
+			{Time: from.Add(time.Hour + 10*time.Minute + 30*time.Second), Firing: 2},
+			{Time: from.Add(2*time.Hour + 15*time.Minute + 30*time.Second), Firing: 2},
+		}, group.Notifications)
+	})
+
+	t.Run("resolved alerts", func(t *testing.T) {
+		policy := NotificationPolicy{Route: newRoute(t, &definitions.Route{
+			Receiver:       "default",
+			GroupWait:      durationPtr(time.Minute),
+			GroupInterval:  durationPtr(5 * time.Minute),
+			RepeatInterval: durationPtr(4 * time.Hour),
+		})}
+		alerts := []Alert{
+			alert(data.Labels{"alertname": "a"}, 0, 3*time.Minute),
+			// fires again after the group was deleted
+			alert(data.Labels{"alertname": "a"}, time.Hour, 0),
+		}
+
+		result, err := SimulateNotifications(policy, alerts, from, to)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Len(t, result[0].Groups, 1)
+		require.Equal(t, 1, result[0].Groups[0].Alerts)
+		require.Equal(t, []Notification{
+			{Time: from.Add(time.Minute), Firing: 1},
+			{Time: from.Add(6 * time.Minute), Resolved: 1},
+			{Time: from.Add(time.Hour + time.Minute), Firing: 1},
+		}, result[0].Groups[0].Notifications)
+	})
+
+	t.Run("mute timings", func(t *testing.T) {
+		policy := NotificationPolicy{
+			Route: newRoute(t, &definitions.Route{
+				Receiver:       "default",
+				GroupWait:      durationPtr(time.Minute),
+				GroupInterval:  durationPtr(5 * time.Minute),
+				RepeatInterval: durationPtr(time.Hour),
+				Routes: []*definitions.Route{{
+					Receiver:          "muted",
+					Matchers:          config.Matchers{mustMatcher(t, labels.MatchEqual, "team", "night")},
+					MuteTimeIntervals: []string{"first-hour"},
+				}},
+			}),
+			MuteTimeIntervals: []config.MuteTimeInterval{{
+				Name: "first-hour",
+				TimeIntervals: []timeinterval.TimeInterval{{
+					Times: []timeinterval.TimeRange{{StartMinute: 0, EndMinute: 60}},
+				}},
+			}},
+		}
+		alerts := []Alert{alert(data.Labels{"alertname": "a", "team": "night"}, 0, 0)}
+
+		result, err := SimulateNotifications(policy, alerts, from, to)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "muted", result[0].Receiver)
+		group := result[0].Groups[0]
+		// every flush of the first hour would have notified since nothing was sent yet
+		require.Equal(t, 12, group.Muted)
+		require.Equal(t, []Notification{
+			{Time: from.Add(time.Hour + time.Minute), Firing: 1},
+			{Time: from.Add(2*time.Hour + 6*time.Minute), Firing: 1},
+		}, group.Notifications)
+	})
+
+	t.Run("continue matches several routes", func(t *testing.T) {
+		policy := NotificationPolicy{Route: newRoute(t, &definitions.Route{
+			Receiver:       "default",
+			GroupByStr:     []string{"..."},
+			GroupWait:      durationPtr(time.Minute),
+			GroupInterval:  durationPtr(5 * time.Minute),
+			RepeatInterval: durationPtr(4 * time.Hour),
+			Routes: []*definitions.Route{
+				{Receiver: "first", Matchers: config.Matchers{mustMatcher(t, labels.MatchEqual, "alertname", "a")}, Continue: true},
+				{Receiver: "second", Matchers: config.Matchers{mustMatcher(t, labels.MatchRegexp, "alertname", ".+")}},
+			},
+		})}
+		alerts := []Alert{
+			alert(data.Labels{"alertname": "a"}, 0, 0),
+			alert(data.Labels{"alertname": "b"}, 0, 0),
+		}
+
+		result, err := SimulateNotifications(policy, alerts, from, to)
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		require.Equal(t, "first", result[0].Receiver)
+		require.Len(t, result[0].Groups, 1)
+		require.Equal(t, "second", result[1].Receiver)
+		require.Len(t, result[1].Groups, 2)
+		require.Equal(t, data.Labels{"alertname": "a"}, result[1].Groups[0].GroupLabels)
+		require.Equal(t, data.Labels{"alertname": "b"}, result[1].Groups[1].GroupLabels)
+	})
+
+	t.Run("only notifications in the time range are counted", func(t *testing.T) {
+		policy := NotificationPolicy{Route: newRoute(t, &definitions.Route{
+			Receiver:       "default",
+			GroupWait:      durationPtr(time.Minute),
+			GroupInterval:  durationPtr(5 * time.Minute),
+			RepeatInterval: durationPtr(4 * time.Hour),
+		})}
+		alerts := []Alert{alert(data.Labels{"alertname": "a"}, 2*time.Hour+59*time.Minute+30*time.Second, 0)}
+
+		result, err := SimulateNotifications(policy, alerts, from, to)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Empty(t, result[0].Groups[0].Notifications)
+	})
+}
+
+func mustMatcher(t *testing.T, mt labels.MatchType, name, value string) *labels.Matcher {
+	t.Helper()
+	m, err := labels.NewMatcher(mt, name, value)
+	require.NoError(t, err)
+	return m
+}
@@ -269,6 +269,7 @@ type AlertRule struct {
 	Labels               map[string]string
 	IsPaused             bool
 	NotificationSettings []NotificationSettings `xorm:"notification_settings"` // we use slice to workaround xorm mapping that does not serialize a struct to JSON unless it's a slice
+	Dependencies         []RuleDependency       `xorm:"dependencies"`
 }
 
 // Namespaced describes a class of resources that are stored in a specific namespace.
@@ -596,6 +597,9 @@ func validateRecordingRuleFields(rule *AlertRule) error {
 	if !prommodels.IsValidMetricName(metricName) {
 		return fmt.Errorf("%w: %s", ErrAlertRuleFailedValidation, "metric name for recording rule must be a valid Prometheus metric name")
 	}
+	if !rule.Record.Target.IsValid() {
+		return fmt.Errorf("%w: unknown recording rule target %q", ErrAlertRuleFailedValidation, rule.Record.Target)
+	}
 	return nil
 }
 
@@ -652,6 +656,7 @@ type AlertRuleVersion struct {
 	Labels               map[string]string
 	IsPaused             bool
 	NotificationSettings []NotificationSettings `xorm:"notification_settings"` // we use slice to workaround xorm mapping that does not serialize a struct to JSON unless it's a slice
+	Dependencies         []RuleDependency       `xorm:"dependencies"`
 }
 
 // GetAlertRuleByUIDQuery is the query for retrieving/deleting an alert rule by UID and organisation ID.
@@ -839,6 +844,19 @@ func RuleKeyFromContext(ctx context.Context) (AlertRuleKey, bool) {
 	return key, ok
 }
 
+type recordTargetContextKey struct{}
+
+// WithRecordTarget sets the target that the results of a recording rule evaluation are written to.
+func WithRecordTarget(ctx context.Context, target RecordTarget) context.Context {
+	return context.WithValue(ctx, recordTargetContextKey{}, target)
+}
+
+// RecordTargetFromContext returns the target set by WithRecordTarget, or RecordTargetPrometheus.
+func RecordTargetFromContext(ctx context.Context) RecordTarget {
+	target, _ := ctx.Value(recordTargetContextKey{}).(RecordTarget)
+	return target.OrDefault()
+}
+
 // GroupByAlertRuleGroupKey groups all rules by AlertRuleGroupKey. Returns map of RulesGroup sorted by AlertRule.RuleGroupIndex
 func GroupByAlertRuleGroupKey(rules []*AlertRule) map[AlertRuleGroupKey]RulesGroup {
 	result := make(map[AlertRuleGroupKey]RulesGroup)
@@ -857,6 +875,34 @@ type Record struct {
 	Metric string
 	// From contains a query RefID, indicating which expression node is the output of the recording rule.
 	From string
+	// Target is the kind of storage the recorded metric is written to. Empty means RecordTargetPrometheus.
+	Target RecordTarget
+}
+
+// RecordTarget is the kind of storage the results of a recording rule are written to.
+type RecordTarget string
+
+const (
+	RecordTargetPrometheus RecordTarget = "prometheus"
+	RecordTargetInfluxDB   RecordTarget = "influxdb"
+	RecordTargetSQL        RecordTarget = "sql"
+	RecordTargetLive       RecordTarget = "live"
+)
+
+func (t RecordTarget) IsValid() bool {
+	switch t {
+	case "", RecordTargetPrometheus, RecordTargetInfluxDB, RecordTargetSQL, RecordTargetLive:
+		return true
+	}
+	return false
+}
+
+// OrDefault returns the target, or RecordTargetPrometheus if the target is not set.
+func (t RecordTarget) OrDefault() RecordTarget {
+	if t == "" {
+		return RecordTargetPrometheus
+	}
+	return t
 }
 
 func (r *Record) Fingerprint() data.Fingerprint {
@@ -871,6 +917,10 @@ func (r *Record) Fingerprint() data.Fingerprint {
 
 	writeString(r.Metric)
 	writeString(r.From)
+	// only written when set so that the fingerprint of existing rules does not change
+	if r.Target != "" {
+		writeString(string(r.Target))
+	}
 	return data.Fingerprint(h.Sum64())
 }
 
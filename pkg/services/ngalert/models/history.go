@@ -18,3 +18,72 @@ type HistoryQuery struct {
 	Limit        int
 	SignedInUser identity.Requester
 }
+
+// HistoryStatsQuery represents a query for the aggregation of the alert state history of rules.
+type HistoryStatsQuery struct {
+	HistoryQuery
+	// GroupBy are the instance labels the history is also aggregated by.
+	GroupBy []string
+	// TopK is the number of the noisiest alert instances to return.
+	TopK int
+	// FlappingThreshold is the number of state transitions per hour from which an alert instance is flapping.
+	FlappingThreshold float64
+}
+
+// HistoryStats is the aggregation of the alert state history of rules over a time range.
+type HistoryStats struct {
+	From time.Time
+	To   time.Time
+	// Transitions is the number of state transitions that were aggregated.
+	Transitions int
+	// Truncated is true if reading the state transitions of the range stopped at the limit of the query.
+	// Only the most recent transitions were aggregated then, and the rates are lower than the actual ones.
+	Truncated bool
+	// Rules are the statistics per rule, the rules with the most transitions first.
+	Rules []RuleHistoryStats
+	// Groups are the statistics per value of the GroupBy labels, the groups with the most transitions first.
+	Groups []GroupHistoryStats
+	// Flapping are the flapping alert instances, the ones with the most transitions per hour first.
+	Flapping []InstanceHistoryStats
+	// TopNoisy are the alert instances that fired the most.
+	TopNoisy []InstanceHistoryStats
+}
+
+// TransitionStats are the statistics of a set of state transitions.
+type TransitionStats struct {
+	Transitions        int
+	TransitionsPerHour float64
+	// Firings is the number of transitions to Alerting.
+	Firings int
+	// Resolutions is the number of transitions back to Normal after a firing.
+	Resolutions int
+	// MeanTimeToResolve is the mean time between a firing and its resolution.
+	MeanTimeToResolve time.Duration
+	// TimeInState is the time spent in each state, summed over the alert instances.
+	TimeInState map[string]time.Duration
+}
+
+// RuleHistoryStats are the statistics of the state history of a rule.
+type RuleHistoryStats struct {
+	TransitionStats
+	RuleUID           string
+	RuleTitle         string
+	Instances         int
+	FlappingInstances int
+}
+
+// GroupHistoryStats are the statistics of the state history of the alert instances with the same values of a set of labels.
+type GroupHistoryStats struct {
+	TransitionStats
+	Labels    map[string]string
+	Instances int
+}
+
+// InstanceHistoryStats are the statistics of the state history of an alert instance.
+type InstanceHistoryStats struct {
+	TransitionStats
+	RuleUID   string
+	RuleTitle string
+	Labels    map[string]string
+	Flapping  bool
+}
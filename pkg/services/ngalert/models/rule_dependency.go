@@ -0,0 +1,40 @@
+package models
+
+// DependencyCondition is the state of the upstream rule that a rule requires in order to fire.
+type DependencyCondition string
+
+const (
+	// DependencyConditionNone does not gate the rule, it only makes it evaluate after the upstream rule.
+	DependencyConditionNone DependencyCondition = ""
+	// DependencyConditionFiring lets the rule fire only while the upstream rule is firing.
+	DependencyConditionFiring DependencyCondition = "firing"
+	// DependencyConditionNotFiring lets the rule fire only while the upstream rule is not firing.
+	DependencyConditionNotFiring DependencyCondition = "not_firing"
+)
+
+func (c DependencyCondition) IsValid() bool {
+	switch c {
+	case DependencyConditionNone, DependencyConditionFiring, DependencyConditionNotFiring:
+		return true
+	}
+	return false
+}
+
+// IsSatisfied returns whether the condition holds for an upstream rule that is, or is not, firing.
+func (c DependencyCondition) IsSatisfied(upstreamFiring bool) bool {
+	switch c {
+	case DependencyConditionFiring:
+		return upstreamFiring
+	case DependencyConditionNotFiring:
+		return !upstreamFiring
+	}
+	return true
+}
+
+// RuleDependency is a rule of the same rule group that the rule depends on. When both rules are evaluated on the
+// same tick, the upstream rule is evaluated first. If the dependency has a condition, the rule does not fire unless
+// the upstream rule is in the required state.
+type RuleDependency struct {
+	RuleUID   string              `json:"rule_uid"`
+	Condition DependencyCondition `json:"condition,omitempty"`
+}
@@ -484,6 +484,12 @@ func (a *AlertRuleMutators) WithNoNotificationSettings() AlertRuleMutator {
 	}
 }
 
+func (a *AlertRuleMutators) WithDependencies(dependencies ...RuleDependency) AlertRuleMutator {
+	return func(rule *AlertRule) {
+		rule.Dependencies = dependencies
+	}
+}
+
 func (a *AlertRuleMutators) WithIsPaused(paused bool) AlertRuleMutator {
 	return func(rule *AlertRule) {
 		rule.IsPaused = paused
@@ -646,6 +652,7 @@ func CopyRule(r *AlertRule, mutators ...AlertRuleMutator) *AlertRule {
 		result.Record = &Record{
 			From:   r.Record.From,
 			Metric: r.Record.Metric,
+			Target: r.Record.Target,
 		}
 	}
 
@@ -653,6 +660,11 @@ func CopyRule(r *AlertRule, mutators ...AlertRuleMutator) *AlertRule {
 		result.NotificationSettings = append(result.NotificationSettings, CopyNotificationSettings(s))
 	}
 
+	if r.Dependencies != nil {
+		result.Dependencies = make([]RuleDependency, len(r.Dependencies))
+		copy(result.Dependencies, r.Dependencies)
+	}
+
 	if len(mutators) > 0 {
 		for _, mutator := range mutators {
 			mutator(&result)
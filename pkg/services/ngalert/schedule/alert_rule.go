@@ -263,6 +263,7 @@ func (a *alertRule) Run() error {
 				defer func() {
 					evalDuration.Observe(a.clock.Now().Sub(evalStart).Seconds())
 					a.evalApplied(ctx.scheduledAt)
+					ctx.done()
 				}()
 
 				for attempt := int64(1); attempt <= a.maxAttempts; attempt++ {
@@ -422,6 +423,14 @@ func (a *alertRule) evaluate(ctx context.Context, e *Evaluation, span trace.Span
 			attribute.Int64("results", int64(len(results))),
 		))
 	}
+
+	if upstream, ok := unsatisfiedDependency(logger, a.ruleProvider, a.stateManager, e.rule); ok {
+		logger.Debug("Alert rule cannot fire because of the state of a rule it depends on", "upstream", upstream)
+		span.AddEvent("firing results suppressed by dependency", trace.WithAttributes(
+			attribute.String("upstream_rule_uid", upstream),
+		))
+		suppressFiring(results)
+	}
 	start = a.clock.Now()
 	_ = a.stateManager.ProcessEvalResults(
 		ctx,
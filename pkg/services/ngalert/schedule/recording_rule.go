@@ -126,6 +126,7 @@ func (r *recordingRule) Run() error {
 			}
 			if !r.featureToggles.IsEnabled(ctx, featuremgmt.FlagGrafanaManagedRecordingRules) {
 				logger.Warn("Recording rule scheduled but toggle is not enabled. Skipping")
+				eval.done()
 				return nil
 			}
 			// TODO: Skipping the "evalRunning" guard that the alert rule routine does, because it seems to be dead code and impossible to hit.
@@ -158,6 +159,7 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 		r.evaluationDuration.Store(dur)
 
 		r.evaluationDoneTestHook(ev)
+		ev.done()
 	}()
 
 	if ev.rule.IsPaused {
@@ -257,7 +259,8 @@ func (r *recordingRule) tryEvaluation(ctx context.Context, ev *Evaluation, logge
 	}
 
 	writeStart := r.clock.Now()
-	err = r.writer.Write(ctx, ev.rule.Record.Metric, ev.scheduledAt, frames, ev.rule.Labels)
+	writeCtx := ngmodels.WithRecordTarget(ctx, ev.rule.Record.Target)
+	err = r.writer.Write(writeCtx, ev.rule.Record.Metric, ev.scheduledAt, frames, ev.rule.Labels)
 	writeDur := r.clock.Now().Sub(writeStart)
 
 	if err != nil {
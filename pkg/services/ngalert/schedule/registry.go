@@ -85,6 +85,16 @@ type Evaluation struct {
 	scheduledAt time.Time
 	rule        *models.AlertRule
 	folderTitle string
+	// afterEval is called once the evaluation is over, whether it succeeded or not. It is used to start the
+	// evaluation of the rules that depend on this rule.
+	afterEval func()
+}
+
+// done runs the afterEval hook of the evaluation, if any.
+func (e *Evaluation) done() {
+	if e.afterEval != nil {
+		e.afterEval()
+	}
 }
 
 func (e *Evaluation) Fingerprint() fingerprint {
@@ -301,6 +311,11 @@ func (r ruleWithFolder) Fingerprint() fingerprint {
 		writeBytes(tmp)
 	}
 
+	for _, d := range rule.Dependencies {
+		writeString(d.RuleUID)
+		writeString(string(d.Condition))
+	}
+
 	// fields that do not affect the state.
 	// TODO consider removing fields below from the fingerprint
 	writeInt(rule.ID)
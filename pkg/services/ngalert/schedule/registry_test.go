@@ -204,6 +204,9 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 			NotificationSettings: []models.NotificationSettings{
 				models.NotificationSettingsGen()(),
 			},
+			Dependencies: []models.RuleDependency{
+				{RuleUID: "upstream"},
+			},
 		}
 		r2 := &models.AlertRule{
 			ID:        2,
@@ -243,6 +246,9 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 			NotificationSettings: []models.NotificationSettings{
 				models.NotificationSettingsGen()(),
 			},
+			Dependencies: []models.RuleDependency{
+				{RuleUID: "upstream-2", Condition: models.DependencyConditionNotFiring},
+			},
 		}
 
 		excludedFields := map[string]struct{}{
@@ -0,0 +1,152 @@
+package schedule
+
+import (
+	"sync"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+// chainDependentEvaluations builds the DAG of the evaluations of a tick from the dependencies between the rules of a group.
+// It returns the evaluations that do not wait for any other evaluation. The other evaluations are started with the
+// dispatch function once all their upstream evaluations are done. Rules that depend on a rule that is not evaluated on
+// this tick do not wait for it. Dependencies that form a cycle are ignored, which should not happen as cycles are
+// rejected by the API.
+func chainDependentEvaluations(logger log.Logger, items []readyToRunItem, dispatch func(readyToRunItem)) []readyToRunItem {
+	index := make(map[ngmodels.AlertRuleKey]int, len(items))
+	for i, item := range items {
+		index[item.rule.GetKey()] = i
+	}
+
+	pending := make([]int, len(items))
+	dependents := make([][]int, len(items))
+	hasDependencies := false
+	for i, item := range items {
+		for _, d := range item.rule.Dependencies {
+			j, ok := index[ngmodels.AlertRuleKey{OrgID: item.rule.OrgID, UID: d.RuleUID}]
+			if !ok || items[j].rule.GetGroupKey() != item.rule.GetGroupKey() {
+				continue
+			}
+			dependents[j] = append(dependents[j], i)
+			pending[i]++
+			hasDependencies = true
+		}
+	}
+	if !hasDependencies {
+		return items
+	}
+
+	// topological sort, the evaluations that are not sorted are in a cycle or wait for one
+	sorted := make([]bool, len(items))
+	remaining := make([]int, len(items))
+	copy(remaining, pending)
+	queue := make([]int, 0, len(items))
+	for i := range items {
+		if remaining[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		sorted[i] = true
+		for _, d := range dependents[i] {
+			remaining[d]--
+			if remaining[d] == 0 {
+				queue = append(queue, d)
+			}
+		}
+	}
+	for i := range items {
+		if sorted[i] {
+			continue
+		}
+		logger.Warn("Rule dependencies form a cycle, the rule is evaluated without waiting for its dependencies", items[i].rule.GetKey().LogContext()...)
+		pending[i] = 0
+		for j := range dependents {
+			dependents[j] = removeDependent(dependents[j], i)
+		}
+	}
+
+	var mtx sync.Mutex
+	for i := range items {
+		if len(dependents[i]) > 0 {
+			var once sync.Once
+			downstream := dependents[i]
+			items[i].afterEval = func() {
+				once.Do(func() {
+					for _, d := range downstream {
+						mtx.Lock()
+						pending[d]--
+						ready := pending[d] == 0
+						mtx.Unlock()
+						if ready {
+							dispatch(items[d])
+						}
+					}
+				})
+			}
+		}
+	}
+	roots := make([]readyToRunItem, 0, len(items))
+	for i := range items {
+		if pending[i] == 0 {
+			roots = append(roots, items[i])
+		}
+	}
+	return roots
+}
+
+func removeDependent(dependents []int, i int) []int {
+	result := dependents[:0]
+	for _, d := range dependents {
+		if d != i {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// ruleStateReader is the part of the state manager that provides the current state of a rule.
+type ruleStateReader interface {
+	GetStatesForRuleUID(orgID int64, alertRuleUID string) []*state.State
+}
+
+// unsatisfiedDependency returns the UID of the first rule the rule depends on whose state does not satisfy the
+// condition of the dependency. The second value is false if all conditions are satisfied.
+// The upstream rule can be deleted or moved to another group after the dependency was created. Such a dependency
+// cannot be satisfied anymore, so it is ignored rather than blocking the rule forever.
+func unsatisfiedDependency(logger log.Logger, rules ruleProvider, states ruleStateReader, rule *ngmodels.AlertRule) (string, bool) {
+	for _, d := range rule.Dependencies {
+		if d.Condition == ngmodels.DependencyConditionNone {
+			continue
+		}
+		upstream := rules.get(ngmodels.AlertRuleKey{OrgID: rule.OrgID, UID: d.RuleUID})
+		if upstream == nil || upstream.GetGroupKey() != rule.GetGroupKey() {
+			logger.Warn("Alert rule depends on a rule that is not in its rule group, the dependency is ignored", "upstream", d.RuleUID)
+			continue
+		}
+		firing := false
+		for _, s := range states.GetStatesForRuleUID(rule.OrgID, d.RuleUID) {
+			if s.State == eval.Alerting {
+				firing = true
+				break
+			}
+		}
+		if !d.Condition.IsSatisfied(firing) {
+			return d.RuleUID, true
+		}
+	}
+	return "", false
+}
+
+// suppressFiring turns the alerting results into normal ones, so that the rule does not fire.
+func suppressFiring(results eval.Results) {
+	for i := range results {
+		if results[i].State == eval.Alerting {
+			results[i].State = eval.Normal
+		}
+	}
+}
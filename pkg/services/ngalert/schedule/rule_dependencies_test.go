@@ -0,0 +1,176 @@
+package schedule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+func TestChainDependentEvaluations(t *testing.T) {
+	gen := models.RuleGen
+	group := gen.With(gen.WithOrgID(1), gen.WithNamespaceUID("ns"), gen.WithGroupName("group"))
+	toItems := func(rules ...*models.AlertRule) []readyToRunItem {
+		items := make([]readyToRunItem, 0, len(rules))
+		for _, r := range rules {
+			items = append(items, readyToRunItem{Evaluation: Evaluation{rule: r}})
+		}
+		return items
+	}
+	uids := func(items []readyToRunItem) []string {
+		result := make([]string, 0, len(items))
+		for _, item := range items {
+			result = append(result, item.rule.UID)
+		}
+		return result
+	}
+
+	t.Run("rules without dependencies are all started", func(t *testing.T) {
+		items := toItems(group.GenerateManyRef(3)...)
+		roots := chainDependentEvaluations(log.NewNopLogger(), items, func(readyToRunItem) {
+			t.Fatal("unexpected dispatch")
+		})
+		require.Equal(t, uids(items), uids(roots))
+	})
+
+	t.Run("rules are started after all their upstream rules", func(t *testing.T) {
+		recording := group.With(gen.WithAllRecordingRules()).GenerateRef()
+		gate := group.GenerateRef()
+		downstream := group.With(gen.WithDependencies(
+			models.RuleDependency{RuleUID: recording.UID},
+			models.RuleDependency{RuleUID: gate.UID, Condition: models.DependencyConditionNotFiring},
+		)).GenerateRef()
+		last := group.With(gen.WithDependencies(models.RuleDependency{RuleUID: downstream.UID})).GenerateRef()
+		// a rule of another group with the same UID as an upstream rule is not a dependency
+		other := gen.With(gen.WithOrgID(1), gen.WithGroupName("other"), gen.WithDependencies(models.RuleDependency{RuleUID: gate.UID})).GenerateRef()
+
+		items := toItems(last, downstream, gate, recording, other)
+		var dispatched []readyToRunItem
+		roots := chainDependentEvaluations(log.NewNopLogger(), items, func(item readyToRunItem) {
+			dispatched = append(dispatched, item)
+		})
+		require.ElementsMatch(t, []string{gate.UID, recording.UID, other.UID}, uids(roots))
+
+		done := func(uid string) {
+			for _, item := range append(roots, dispatched...) {
+				if item.rule.UID == uid {
+					item.done()
+					return
+				}
+			}
+			t.Fatalf("rule %s was not started", uid)
+		}
+
+		done(gate.UID)
+		require.Empty(t, dispatched)
+		// the hook is called once per evaluation
+		done(gate.UID)
+		done(recording.UID)
+		require.Equal(t, []string{downstream.UID}, uids(dispatched))
+		done(downstream.UID)
+		require.Equal(t, []string{downstream.UID, last.UID}, uids(dispatched))
+		done(last.UID)
+		done(other.UID)
+		require.Len(t, dispatched, 2)
+	})
+
+	t.Run("rules in a cycle do not wait", func(t *testing.T) {
+		rules := group.GenerateManyRef(3)
+		rules[0].Dependencies = []models.RuleDependency{{RuleUID: rules[1].UID}}
+		rules[1].Dependencies = []models.RuleDependency{{RuleUID: rules[0].UID}}
+		rules[2].Dependencies = []models.RuleDependency{{RuleUID: rules[0].UID}}
+
+		items := toItems(rules...)
+		roots := chainDependentEvaluations(log.NewNopLogger(), items, func(readyToRunItem) {
+			t.Fatal("unexpected dispatch")
+		})
+		require.Equal(t, uids(items), uids(roots))
+		for _, item := range roots {
+			item.done()
+		}
+	})
+}
+
+type fakeRuleStateReader map[string][]*state.State
+
+func (f fakeRuleStateReader) GetStatesForRuleUID(_ int64, uid string) []*state.State {
+	return f[uid]
+}
+
+func TestUnsatisfiedDependency(t *testing.T) {
+	states := fakeRuleStateReader{
+		"firing":  {{State: eval.Normal}, {State: eval.Alerting}},
+		"pending": {{State: eval.Pending}},
+		"moved":   {{State: eval.Alerting}},
+	}
+	gen := models.RuleGen
+	group := gen.With(gen.WithOrgID(1), gen.WithNamespaceUID("ns"), gen.WithGroupName("group"))
+	withUID := func(uid string) models.AlertRuleMutator {
+		return func(rule *models.AlertRule) {
+			rule.UID = uid
+		}
+	}
+	rules := &alertRulesRegistry{}
+	rules.set([]*models.AlertRule{
+		group.With(withUID("firing")).GenerateRef(),
+		group.With(withUID("pending")).GenerateRef(),
+		group.With(withUID("unknown")).GenerateRef(),
+		group.With(withUID("moved"), gen.WithGroupName("other")).GenerateRef(),
+	}, nil)
+
+	testCases := []struct {
+		name     string
+		deps     []models.RuleDependency
+		expected string
+	}{
+		{
+			name: "no condition",
+			deps: []models.RuleDependency{{RuleUID: "firing"}, {RuleUID: "pending"}},
+		},
+		{
+			name: "upstream is firing",
+			deps: []models.RuleDependency{{RuleUID: "firing", Condition: models.DependencyConditionFiring}},
+		},
+		{
+			name:     "upstream is not firing",
+			deps:     []models.RuleDependency{{RuleUID: "pending", Condition: models.DependencyConditionFiring}},
+			expected: "pending",
+		},
+		{
+			name:     "upstream must not fire",
+			deps:     []models.RuleDependency{{RuleUID: "pending", Condition: models.DependencyConditionNotFiring}, {RuleUID: "firing", Condition: models.DependencyConditionNotFiring}},
+			expected: "firing",
+		},
+		{
+			name: "upstream has no state",
+			deps: []models.RuleDependency{{RuleUID: "unknown", Condition: models.DependencyConditionNotFiring}},
+		},
+		{
+			name: "upstream was deleted",
+			deps: []models.RuleDependency{{RuleUID: "deleted", Condition: models.DependencyConditionFiring}},
+		},
+		{
+			name: "upstream was moved to another group",
+			deps: []models.RuleDependency{{RuleUID: "moved", Condition: models.DependencyConditionNotFiring}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			upstream, ok := unsatisfiedDependency(log.NewNopLogger(), rules, states, group.With(gen.WithDependencies(tc.deps...)).GenerateRef())
+			require.Equal(t, tc.expected != "", ok)
+			require.Equal(t, tc.expected, upstream)
+		})
+	}
+
+	t.Run("suppressFiring turns alerting results into normal", func(t *testing.T) {
+		results := eval.Results{{State: eval.Alerting}, {State: eval.NoData}, {State: eval.Error}}
+		suppressFiring(results)
+		require.Equal(t, eval.Normal, results[0].State)
+		require.Equal(t, eval.NoData, results[1].State)
+		require.Equal(t, eval.Error, results[2].State)
+	})
+}
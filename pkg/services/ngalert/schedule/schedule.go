@@ -339,29 +339,24 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		sch.log.Warn("Unable to obtain folder titles for some rules", "missingFolderUIDToRuleUID", missingFolder)
 	}
 
-	var step int64 = 0
-	if len(readyToRun) > 0 {
-		step = sch.baseInterval.Nanoseconds() / int64(len(readyToRun))
-	}
-
 	slices.SortFunc(readyToRun, func(a, b readyToRunItem) int {
 		return strings.Compare(a.rule.UID, b.rule.UID)
 	})
-	for i := range readyToRun {
-		item := readyToRun[i]
+	// rules that depend on other rules of their group are started when their upstream rules are evaluated
+	roots := chainDependentEvaluations(sch.log, readyToRun, func(item readyToRunItem) {
+		go sch.runEvaluation(item, tick)
+	})
+
+	var step int64 = 0
+	if len(roots) > 0 {
+		step = sch.baseInterval.Nanoseconds() / int64(len(roots))
+	}
+
+	for i := range roots {
+		item := roots[i]
 
 		time.AfterFunc(time.Duration(int64(i)*step), func() {
-			key := item.rule.GetKey()
-			success, dropped := item.ruleRoutine.Eval(&item.Evaluation)
-			if !success {
-				sch.log.Debug("Scheduled evaluation was canceled because evaluation routine was stopped", append(key.LogContext(), "time", tick)...)
-				return
-			}
-			if dropped != nil {
-				sch.log.Warn("Tick dropped because alert rule evaluation is too slow", append(key.LogContext(), "time", tick, "droppedTick", dropped.scheduledAt)...)
-				orgID := fmt.Sprint(key.OrgID)
-				sch.metrics.EvaluationMissed.WithLabelValues(orgID, item.rule.Title).Inc()
-			}
+			sch.runEvaluation(item, tick)
 		})
 	}
 
@@ -373,3 +368,20 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 	sch.deleteAlertRule(toDelete...)
 	return readyToRun, registeredDefinitions, updatedRules
 }
+
+// runEvaluation sends the evaluation to the routine of the rule.
+func (sch *schedule) runEvaluation(item readyToRunItem, tick time.Time) {
+	key := item.rule.GetKey()
+	success, dropped := item.ruleRoutine.Eval(&item.Evaluation)
+	if !success {
+		sch.log.Debug("Scheduled evaluation was canceled because evaluation routine was stopped", append(key.LogContext(), "time", tick)...)
+		item.Evaluation.done()
+		return
+	}
+	if dropped != nil {
+		sch.log.Warn("Tick dropped because alert rule evaluation is too slow", append(key.LogContext(), "time", tick, "droppedTick", dropped.scheduledAt)...)
+		orgID := fmt.Sprint(key.OrgID)
+		sch.metrics.EvaluationMissed.WithLabelValues(orgID, item.rule.Title).Inc()
+		dropped.done()
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/annotations"
 	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/ngalert/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -43,6 +44,7 @@ type AnnotationBackend struct {
 
 type RuleStore interface {
 	GetAlertRuleByUID(ctx context.Context, query *ngmodels.GetAlertRuleByUIDQuery) (*ngmodels.AlertRule, error)
+	ListAlertRules(ctx context.Context, query *ngmodels.ListAlertRulesQuery) (ngmodels.RulesGroup, error)
 	GetUserVisibleNamespaces(ctx context.Context, orgID int64, user identity.Requester) (map[string]*folder.Folder, error)
 }
 
@@ -197,6 +199,138 @@ func (h *AnnotationBackend) Query(ctx context.Context, query ngmodels.HistoryQue
 	return frame, nil
 }
 
+// QueryStats aggregates the state history annotations of the rules the user can read.
+// Label filters match the labels formatted into the text of the annotations.
+func (h *AnnotationBackend) QueryStats(ctx context.Context, query ngmodels.HistoryStatsQuery) (*ngmodels.HistoryStats, error) {
+	query = withStatsDefaults(query, h.clock.Now())
+	rules, err := h.readableRules(ctx, query.HistoryQuery)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*ngmodels.AlertRule, len(rules))
+	for _, rule := range rules {
+		byID[rule.ID] = rule
+	}
+
+	q := annotations.ItemQuery{
+		OrgID:        query.OrgID,
+		From:         query.From.UnixMilli(),
+		To:           query.To.UnixMilli(),
+		Type:         "alert",
+		SignedInUser: query.SignedInUser,
+		Limit:        statsLimit(query.Limit),
+	}
+	if query.RuleUID != "" && len(rules) == 1 {
+		q.AlertID = rules[0].ID
+	}
+	items, err := h.store.Find(ctx, &q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query annotations for state history: %w", err)
+	}
+
+	transitions := make([]transition, 0, len(items))
+	for _, item := range items {
+		rule, ok := byID[item.AlertID]
+		if !ok {
+			continue
+		}
+		lbls := parseAnnotationLabels(rule.Title, item.Text)
+		if !matchesLabels(lbls, query.Labels) {
+			continue
+		}
+		transitions = append(transitions, transition{
+			time:      time.UnixMilli(item.Time),
+			ruleUID:   rule.UID,
+			ruleTitle: rule.Title,
+			labels:    lbls,
+			previous:  item.PrevState,
+			current:   item.NewState,
+		})
+	}
+	stats := aggregateTransitions(query, transitions)
+	stats.Truncated = int64(len(items)) >= q.Limit
+	return stats, nil
+}
+
+// readableRules returns the rules of the query that the user can read.
+func (h *AnnotationBackend) readableRules(ctx context.Context, query ngmodels.HistoryQuery) ([]*ngmodels.AlertRule, error) {
+	if query.RuleUID != "" {
+		rule, err := h.rules.GetAlertRuleByUID(ctx, &ngmodels.GetAlertRuleByUIDQuery{
+			UID:   query.RuleUID,
+			OrgID: query.OrgID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up the requested rule")
+		}
+		if rule == nil {
+			return nil, fmt.Errorf("no such rule exists")
+		}
+		if err := h.ac.AuthorizeAccessInFolder(ctx, query.SignedInUser, rule); err != nil {
+			return nil, err
+		}
+		return []*ngmodels.AlertRule{rule}, nil
+	}
+
+	q := ngmodels.ListAlertRulesQuery{
+		OrgID:        query.OrgID,
+		DashboardUID: query.DashboardUID,
+		PanelID:      query.PanelID,
+	}
+	canReadAll, err := h.ac.CanReadAllRules(ctx, query.SignedInUser)
+	if err != nil {
+		return nil, err
+	}
+	if !canReadAll {
+		folders, err := h.rules.GetUserVisibleNamespaces(ctx, query.OrgID, query.SignedInUser)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch folders that user can access: %w", err)
+		}
+		for _, f := range folders {
+			hasAccess, err := h.ac.HasAccessInFolder(ctx, query.SignedInUser, ngmodels.Namespace(*f))
+			if err != nil {
+				return nil, err
+			}
+			if hasAccess {
+				q.NamespaceUIDs = append(q.NamespaceUIDs, f.UID)
+			}
+		}
+		if len(q.NamespaceUIDs) == 0 {
+			return nil, accesscontrol.NewAuthorizationErrorGeneric("read rules in any folder")
+		}
+	}
+	rules, err := h.rules.ListAlertRules(ctx, &q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert rules: %w", err)
+	}
+	return rules, nil
+}
+
+// parseAnnotationLabels extracts the labels of the alert instance from the text built by BuildAnnotationTextAndData.
+// The whole label set is used as the value of a single label if it cannot be parsed.
+func parseAnnotationLabels(title, text string) data.Labels {
+	text = strings.TrimPrefix(text, title+" {")
+	if idx := strings.LastIndex(text, "} - "); idx >= 0 {
+		text = text[:idx]
+	}
+	lbls, err := data.LabelsFromString(text)
+	if err != nil {
+		return data.Labels{"labels": text}
+	}
+	if lbls == nil {
+		return data.Labels{}
+	}
+	return lbls
+}
+
+func matchesLabels(lbls data.Labels, filter map[string]string) bool {
+	for k, v := range filter {
+		if lbls[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
 func buildAnnotations(rule history_model.RuleMeta, states []state.StateTransition, logger log.Logger) []annotations.Item {
 	items := make([]annotations.Item, 0, len(states))
 	for _, state := range states {
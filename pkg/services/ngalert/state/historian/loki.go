@@ -19,7 +19,6 @@ import (
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
-	"github.com/grafana/grafana/pkg/services/ngalert/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/ngalert/client"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
@@ -141,15 +140,46 @@ func (h *RemoteLokiBackend) Record(ctx context.Context, rule history_model.RuleM
 
 // Query retrieves state history entries from an external Loki instance and formats the results into a dataframe.
 func (h *RemoteLokiBackend) Query(ctx context.Context, query models.HistoryQuery) (*data.Frame, error) {
-	uids, err := h.getFolderUIDsForFilter(ctx, query)
+	res, uids, err := h.queryStreams(ctx, query, int64(query.Limit))
 	if err != nil {
 		return nil, err
 	}
+	return merge(res, uids)
+}
 
-	queries, err := BuildLogQuery(query, uids, h.client.MaxQuerySize())
+// QueryStats aggregates the state history entries of the rules the user can read.
+func (h *RemoteLokiBackend) QueryStats(ctx context.Context, query models.HistoryStatsQuery) (*models.HistoryStats, error) {
+	query = withStatsDefaults(query, h.clock.Now())
+	limit := statsLimit(query.Limit)
+	res, uids, err := h.queryStreams(ctx, query.HistoryQuery, limit)
+	if err != nil {
+		return nil, err
+	}
+	transitions, err := streamTransitions(res, uids)
 	if err != nil {
 		return nil, err
 	}
+	entries := 0
+	for _, stream := range res {
+		entries += len(stream.Values)
+	}
+	stats := aggregateTransitions(query, transitions)
+	stats.Truncated = int64(entries) >= limit
+	return stats, nil
+}
+
+// queryStreams retrieves the streams of state history entries that match the query.
+// It also returns the UIDs of the folders the entries must be in, or nil if the user can read all of them.
+func (h *RemoteLokiBackend) queryStreams(ctx context.Context, query models.HistoryQuery, limit int64) ([]Stream, []string, error) {
+	uids, err := h.getFolderUIDsForFilter(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	queries, err := BuildLogQuery(query, uids, h.client.MaxQuerySize())
+	if err != nil {
+		return nil, nil, err
+	}
 	if len(queries) > 1 {
 		h.log.FromContext(ctx).Info("Execute query in multiple batches", "batchSize", len(queries), "folders", len(uids), "maxQueryLimit", h.client.MaxQuerySize())
 	}
@@ -166,13 +196,45 @@ func (h *RemoteLokiBackend) Query(ctx context.Context, query models.HistoryQuery
 		// Timestamps are expected in RFC3339Nano.
 		// Apply user-defined limit to every request. Multiple batches is a very rare case, and therefore we can tolerate getting more data than needed.
 		// The limit can be applied after all results are merged
-		r, err := h.client.RangeQuery(ctx, logQL, query.From.UnixNano(), query.To.UnixNano(), int64(query.Limit))
+		r, err := h.client.RangeQuery(ctx, logQL, query.From.UnixNano(), query.To.UnixNano(), limit)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		res = append(res, r.Data.Result...)
 	}
-	return merge(res, uids)
+	return res, uids, nil
+}
+
+// streamTransitions decodes the state transitions of the streams that are in one of the given folders.
+func streamTransitions(res []Stream, folderUIDToFilter []string) ([]transition, error) {
+	filterByFolderUIDMap := make(map[string]struct{}, len(folderUIDToFilter))
+	for _, uid := range folderUIDToFilter {
+		filterByFolderUIDMap[uid] = struct{}{}
+	}
+
+	var transitions []transition
+	for _, stream := range res {
+		if len(filterByFolderUIDMap) > 0 {
+			if _, ok := filterByFolderUIDMap[stream.Stream[FolderUIDLabel]]; !ok {
+				continue
+			}
+		}
+		for _, sample := range stream.Values {
+			var entry LokiEntry
+			if err := json.Unmarshal([]byte(sample.V), &entry); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal entry: %w", err)
+			}
+			transitions = append(transitions, transition{
+				time:      sample.T,
+				ruleUID:   entry.RuleUID,
+				ruleTitle: entry.RuleTitle,
+				labels:    entry.InstanceLabels,
+				previous:  entry.Previous,
+				current:   entry.Current,
+			})
+		}
+	}
+	return transitions, nil
 }
 
 // merge will put all the results in one array sorted by timestamp.
@@ -493,47 +555,5 @@ func queryHasLogFilters(query models.HistoryQuery) bool {
 }
 
 func (h *RemoteLokiBackend) getFolderUIDsForFilter(ctx context.Context, query models.HistoryQuery) ([]string, error) {
-	bypass, err := h.ac.CanReadAllRules(ctx, query.SignedInUser)
-	if err != nil {
-		return nil, err
-	}
-	if bypass { // if user has access to all rules and folder, remove filter
-		return nil, nil
-	}
-	// if there is a filter by rule UID, find that rule UID and make sure that user has access to it.
-	if query.RuleUID != "" {
-		rule, err := h.ruleStore.GetAlertRuleByUID(ctx, &models.GetAlertRuleByUIDQuery{
-			UID:   query.RuleUID,
-			OrgID: query.OrgID,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch alert rule by UID: %w", err)
-		}
-		if rule == nil {
-			return nil, models.ErrAlertRuleNotFound
-		}
-		return nil, h.ac.AuthorizeAccessInFolder(ctx, query.SignedInUser, rule)
-	}
-	// if no filter, then we need to get all namespaces user has access to
-	folders, err := h.ruleStore.GetUserVisibleNamespaces(ctx, query.OrgID, query.SignedInUser)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch folders that user can access: %w", err)
-	}
-	uids := make([]string, 0, len(folders))
-	// now keep only UIDs of folder in which user can read rules.
-	for _, f := range folders {
-		hasAccess, err := h.ac.HasAccessInFolder(ctx, query.SignedInUser, models.Namespace(*f))
-		if err != nil {
-			return nil, err
-		}
-		if !hasAccess {
-			continue
-		}
-		uids = append(uids, f.UID)
-	}
-	if len(uids) == 0 {
-		return nil, accesscontrol.NewAuthorizationErrorGeneric("read rules in any folder")
-	}
-	sort.Strings(uids)
-	return uids, nil
+	return folderUIDsForFilter(ctx, h.ac, h.ruleStore, query)
 }
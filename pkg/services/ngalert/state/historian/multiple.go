@@ -12,6 +12,7 @@ import (
 type Backend interface {
 	Record(ctx context.Context, rule history_model.RuleMeta, states []state.StateTransition) <-chan error
 	Query(ctx context.Context, query ngmodels.HistoryQuery) (*data.Frame, error)
+	QueryStats(ctx context.Context, query ngmodels.HistoryStatsQuery) (*ngmodels.HistoryStats, error)
 }
 
 // MultipleBackend is a state.Historian that records history to multiple backends at once.
@@ -53,6 +54,10 @@ func (h *MultipleBackend) Query(ctx context.Context, query ngmodels.HistoryQuery
 	return h.primary.Query(ctx, query)
 }
 
+func (h *MultipleBackend) QueryStats(ctx context.Context, query ngmodels.HistoryStatsQuery) (*ngmodels.HistoryStats, error) {
+	return h.primary.QueryStats(ctx, query)
+}
+
 // TODO: This is vendored verbatim from the Go standard library.
 // TODO: The grafana project doesn't support go 1.20 yet, so we can't use errors.Join() directly.
 // TODO: Remove this and replace calls with "errors.Join(...)" when go 1.20 becomes the minimum supported version.
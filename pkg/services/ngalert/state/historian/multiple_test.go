@@ -76,3 +76,7 @@ func (f *fakeBackend) Record(ctx context.Context, rule history_model.RuleMeta, s
 func (f *fakeBackend) Query(ctx context.Context, query ngmodels.HistoryQuery) (*data.Frame, error) {
 	return f.resp, f.err
 }
+
+func (f *fakeBackend) QueryStats(ctx context.Context, query ngmodels.HistoryStatsQuery) (*ngmodels.HistoryStats, error) {
+	return &ngmodels.HistoryStats{From: query.From, To: query.To}, f.err
+}
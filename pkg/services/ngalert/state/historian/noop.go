@@ -2,6 +2,7 @@ package historian
 
 import (
 	"context"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -25,3 +26,7 @@ func (f *NoOpHistorian) Record(ctx context.Context, _ history_model.RuleMeta, _
 func (f *NoOpHistorian) Query(ctx context.Context, query models.HistoryQuery) (*data.Frame, error) {
 	return data.NewFrame("states"), nil
 }
+
+func (f *NoOpHistorian) QueryStats(ctx context.Context, query models.HistoryStatsQuery) (*models.HistoryStats, error) {
+	return aggregateTransitions(withStatsDefaults(query, time.Now()), nil), nil
+}
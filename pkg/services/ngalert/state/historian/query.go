@@ -2,9 +2,15 @@ package historian
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 )
 
@@ -14,3 +20,109 @@ import (
 type Querier interface {
 	Query(ctx context.Context, query models.HistoryQuery) (*data.Frame, error)
 }
+
+// folderUIDsForFilter returns the UIDs of the folders the history of the query must be in for the user to read it.
+// It returns nil if the user can read the history of all rules of the query.
+func folderUIDsForFilter(ctx context.Context, ac AccessControl, rules RuleStore, query models.HistoryQuery) ([]string, error) {
+	bypass, err := ac.CanReadAllRules(ctx, query.SignedInUser)
+	if err != nil {
+		return nil, err
+	}
+	if bypass { // if user has access to all rules and folder, remove filter
+		return nil, nil
+	}
+	// if there is a filter by rule UID, find that rule UID and make sure that user has access to it.
+	if query.RuleUID != "" {
+		rule, err := rules.GetAlertRuleByUID(ctx, &models.GetAlertRuleByUIDQuery{
+			UID:   query.RuleUID,
+			OrgID: query.OrgID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch alert rule by UID: %w", err)
+		}
+		if rule == nil {
+			return nil, models.ErrAlertRuleNotFound
+		}
+		return nil, ac.AuthorizeAccessInFolder(ctx, query.SignedInUser, rule)
+	}
+	// if no filter, then we need to get all namespaces user has access to
+	folders, err := rules.GetUserVisibleNamespaces(ctx, query.OrgID, query.SignedInUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch folders that user can access: %w", err)
+	}
+	uids := make([]string, 0, len(folders))
+	// now keep only UIDs of folder in which user can read rules.
+	for _, f := range folders {
+		hasAccess, err := ac.HasAccessInFolder(ctx, query.SignedInUser, models.Namespace(*f))
+		if err != nil {
+			return nil, err
+		}
+		if !hasAccess {
+			continue
+		}
+		uids = append(uids, f.UID)
+	}
+	if len(uids) == 0 {
+		return nil, accesscontrol.NewAuthorizationErrorGeneric("read rules in any folder")
+	}
+	sort.Strings(uids)
+	return uids, nil
+}
+
+// selectStateHistory returns the rows of the SQL backend that match the query and are in one of the given folders,
+// the most recent first. If before is set, only the rows that come after it in this order are returned.
+// Label filters are matched against the JSON of the labels and must be checked again by the caller.
+func selectStateHistory(sess *db.Session, query models.HistoryQuery, folderUIDs []string, before *stateHistoryEntry, limit int) ([]stateHistoryEntry, error) {
+	q := sess.Table(stateHistoryEntry{}.TableName()).Where("org_id = ?", query.OrgID)
+	if before != nil {
+		q = q.And("(evaluated_at < ? OR (evaluated_at = ? AND id < ?))", before.EvaluatedAt, before.EvaluatedAt, before.ID)
+	}
+	if query.RuleUID != "" {
+		q = q.And("rule_uid = ?", query.RuleUID)
+	}
+	if query.DashboardUID != "" {
+		q = q.And("dashboard_uid = ?", query.DashboardUID)
+		if query.PanelID != 0 {
+			q = q.And("panel_id = ?", query.PanelID)
+		}
+	}
+	if !query.From.IsZero() {
+		q = q.And("evaluated_at >= ?", query.From.UnixMilli())
+	}
+	if !query.To.IsZero() {
+		q = q.And("evaluated_at <= ?", query.To.UnixMilli())
+	}
+	if len(folderUIDs) > 0 {
+		args := make([]any, 0, len(folderUIDs))
+		for _, uid := range folderUIDs {
+			args = append(args, uid)
+		}
+		q = q.In("namespace_uid", args...)
+	}
+	keys := make([]string, 0, len(query.Labels))
+	for k := range query.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		pair, err := json.Marshal(map[string]string{k: query.Labels[k]})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode label filter: %w", err)
+		}
+		// the labels are stored as a JSON object, which contains the pair without the enclosing braces.
+		pattern := likeEscaper.Replace(string(pair[1 : len(pair)-1]))
+		q = q.And("labels LIKE ? ESCAPE '"+likeEscapeChar+"'", "%"+pattern+"%")
+	}
+
+	var rows []stateHistoryEntry
+	if err := q.Desc("evaluated_at", "id").Limit(limit).Find(&rows); err != nil {
+		return nil, fmt.Errorf("failed to query state history: %w", err)
+	}
+	return rows, nil
+}
+
+// likeEscapeChar escapes the characters of LIKE patterns. It is not a backslash because backslashes
+// have a meaning in the string literals of some databases and appear in JSON encoded labels.
+const likeEscapeChar = "!"
+
+var likeEscaper = strings.NewReplacer(likeEscapeChar, likeEscapeChar+likeEscapeChar, "%", likeEscapeChar+"%", "_", likeEscapeChar+"_")
@@ -0,0 +1,348 @@
+package historian
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	history_model "github.com/grafana/grafana/pkg/services/ngalert/state/historian/model"
+)
+
+// sqlInsertBatchSize is the maximum number of rows inserted by a single statement.
+const sqlInsertBatchSize = 100
+
+// sqlDeleteBatchSize is the maximum number of rows deleted by a single statement of the retention cleanup.
+// It stays below the limit of 999 parameters of SQLite.
+const sqlDeleteBatchSize = 500
+
+// stateHistoryEntry is a state transition of an alert instance, as stored by the SQL backend.
+type stateHistoryEntry struct {
+	ID             int64  `xorm:"pk autoincr 'id'"`
+	OrgID          int64  `xorm:"org_id"`
+	RuleUID        string `xorm:"rule_uid"`
+	RuleTitle      string `xorm:"rule_title"`
+	RuleGroup      string `xorm:"rule_group"`
+	NamespaceUID   string `xorm:"namespace_uid"`
+	DashboardUID   string `xorm:"dashboard_uid"`
+	PanelID        int64  `xorm:"panel_id"`
+	Condition      string `xorm:"rule_condition"`
+	Fingerprint    string `xorm:"fingerprint"`
+	Labels         string `xorm:"labels"`
+	PreviousState  string `xorm:"previous_state"`
+	PreviousReason string `xorm:"previous_reason"`
+	State          string `xorm:"state"`
+	Reason         string `xorm:"reason"`
+	Values         string `xorm:"state_values"`
+	Error          string `xorm:"state_error"`
+	// EvaluatedAt is the time of the evaluation that caused the transition, in Unix milliseconds.
+	EvaluatedAt int64 `xorm:"evaluated_at"`
+}
+
+func (stateHistoryEntry) TableName() string {
+	return "alert_state_history"
+}
+
+// SQLBackend is an implementation of state.Historian that uses a dedicated table of the Grafana database as the backing datastore.
+type SQLBackend struct {
+	db        db.DB
+	ruleStore RuleStore
+	ac        AccessControl
+	clock     clock.Clock
+	metrics   *metrics.Historian
+	log       log.Logger
+}
+
+func NewSQLBackend(logger log.Logger, store db.DB, rules RuleStore, metrics *metrics.Historian, ac AccessControl) *SQLBackend {
+	return &SQLBackend{
+		db:        store,
+		ruleStore: rules,
+		ac:        ac,
+		clock:     clock.New(),
+		metrics:   metrics,
+		log:       logger,
+	}
+}
+
+// Record writes a number of state transitions for a given rule to the state history table.
+func (h *SQLBackend) Record(ctx context.Context, rule history_model.RuleMeta, states []state.StateTransition) <-chan error {
+	logger := h.log.FromContext(ctx)
+	// Build the rows before starting the goroutine, to make sure all data is copied and won't mutate underneath us.
+	rows := statesToEntries(rule, states, logger)
+
+	errCh := make(chan error, 1)
+	if len(rows) == 0 {
+		close(errCh)
+		return errCh
+	}
+
+	// This is a new background job, so let's create a brand new context for it.
+	// We want it to be isolated, i.e. we don't want grafana shutdowns to interrupt this work
+	// immediately but rather try to flush writes.
+	// This also prevents timeouts or other lingering objects (like transactions) from being
+	// incorrectly propagated here from other areas.
+	writeCtx := context.Background()
+	writeCtx, cancel := context.WithTimeout(writeCtx, StateHistoryWriteTimeout)
+	writeCtx = history_model.WithRuleData(writeCtx, rule)
+	writeCtx = trace.ContextWithSpan(writeCtx, trace.SpanFromContext(ctx))
+
+	go func(ctx context.Context) {
+		defer cancel()
+		defer close(errCh)
+		logger := h.log.FromContext(ctx)
+		logger.Debug("Saving state history batch", "samples", len(rows))
+		org := fmt.Sprint(rule.OrgID)
+		h.metrics.WritesTotal.WithLabelValues(org, "sql").Inc()
+		h.metrics.TransitionsTotal.WithLabelValues(org).Add(float64(len(rows)))
+
+		err := h.db.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+			for start := 0; start < len(rows); start += sqlInsertBatchSize {
+				batch := rows[start:min(start+sqlInsertBatchSize, len(rows))]
+				if _, err := sess.InsertMulti(&batch); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			logger.Error("Failed to save alert state history batch", "error", err)
+			h.metrics.WritesFailed.WithLabelValues(org, "sql").Inc()
+			h.metrics.TransitionsFailed.WithLabelValues(org).Add(float64(len(rows)))
+			errCh <- fmt.Errorf("failed to save alert state history batch: %w", err)
+			return
+		}
+		logger.Debug("Done saving alert state history batch", "samples", len(rows))
+	}(writeCtx)
+	return errCh
+}
+
+// Query retrieves state history entries from the state history table and formats them into a dataframe.
+// The dataframe has the same format as the one of the Loki backend.
+func (h *SQLBackend) Query(ctx context.Context, query ngmodels.HistoryQuery) (*data.Frame, error) {
+	limit := query.Limit
+	if limit < 1 {
+		limit = defaultPageSize
+	}
+	rows, err := h.selectEntries(ctx, query, min(limit, maximumPageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	frame := data.NewFrame("states")
+	lbls := data.Labels(map[string]string{})
+	times := make([]time.Time, 0, len(rows))
+	lines := make([]json.RawMessage, 0, len(rows))
+	labels := make([]json.RawMessage, 0, len(rows))
+	// rows are sorted from the most recent, the frame from the oldest
+	for i := len(rows) - 1; i >= 0; i-- {
+		row := rows[i]
+		line, err := json.Marshal(row.lokiEntry())
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize state history entry: %w", err)
+		}
+		streamLbls, err := json.Marshal(map[string]string{
+			StateHistoryLabelKey: StateHistoryLabelValue,
+			OrgIDLabel:           fmt.Sprint(row.OrgID),
+			GroupLabel:           row.RuleGroup,
+			FolderUIDLabel:       row.NamespaceUID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize state history labels: %w", err)
+		}
+		times = append(times, time.UnixMilli(row.EvaluatedAt))
+		lines = append(lines, line)
+		labels = append(labels, streamLbls)
+	}
+
+	frame.Fields = append(frame.Fields, data.NewField(dfTime, lbls, times))
+	frame.Fields = append(frame.Fields, data.NewField(dfLine, lbls, lines))
+	frame.Fields = append(frame.Fields, data.NewField(dfLabels, lbls, labels))
+	return frame, nil
+}
+
+// QueryStats aggregates the state history entries of the rules the user can read.
+func (h *SQLBackend) QueryStats(ctx context.Context, query ngmodels.HistoryStatsQuery) (*ngmodels.HistoryStats, error) {
+	query = withStatsDefaults(query, h.clock.Now())
+	limit := int(statsLimit(query.Limit))
+	rows, err := h.selectEntries(ctx, query.HistoryQuery, limit)
+	if err != nil {
+		return nil, err
+	}
+	transitions := make([]transition, 0, len(rows))
+	for _, row := range rows {
+		transitions = append(transitions, transition{
+			time:      time.UnixMilli(row.EvaluatedAt),
+			ruleUID:   row.RuleUID,
+			ruleTitle: row.RuleTitle,
+			labels:    row.labels(),
+			previous:  state.FormatStateAndReason(parseStateOrNormal(row.PreviousState), row.PreviousReason),
+			current:   state.FormatStateAndReason(parseStateOrNormal(row.State), row.Reason),
+		})
+	}
+	stats := aggregateTransitions(query, transitions)
+	stats.Truncated = len(rows) >= limit
+	return stats, nil
+}
+
+// selectEntries returns the entries of the query the user can read, the most recent first.
+// The label filters of the query are not exact in SQL, so the rows are fetched page by page until limit rows match them.
+func (h *SQLBackend) selectEntries(ctx context.Context, query ngmodels.HistoryQuery, limit int) ([]stateHistoryEntry, error) {
+	uids, err := folderUIDsForFilter(ctx, h.ac, h.ruleStore, query)
+	if err != nil {
+		return nil, err
+	}
+	var result []stateHistoryEntry
+	err = h.db.WithDbSession(ctx, func(sess *db.Session) error {
+		var before *stateHistoryEntry
+		for {
+			rows, err := selectStateHistory(sess, query, uids, before, limit)
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				if len(query.Labels) > 0 && !matchesLabels(row.labels(), query.Labels) {
+					continue
+				}
+				result = append(result, row)
+				if len(result) == limit {
+					return nil
+				}
+			}
+			if len(rows) < limit {
+				return nil
+			}
+			before = &rows[len(rows)-1]
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteExpiredSQLHistory deletes the state transitions stored by the SQL backend that are older than maxAge.
+// Like the annotation cleanup, the IDs are loaded first and the rows are deleted in bounded batches, so that a single
+// statement does not lock a large part of the table.
+func DeleteExpiredSQLHistory(ctx context.Context, store db.DB, maxAge time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-maxAge).UnixMilli()
+	var totalAffected int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return totalAffected, err
+		}
+		var ids []int64
+		err := store.WithDbSession(ctx, func(sess *db.Session) error {
+			return sess.Table(stateHistoryEntry{}.TableName()).Cols("id").Where("evaluated_at < ?", cutoff).Asc("id").Limit(sqlDeleteBatchSize).Find(&ids)
+		})
+		if err != nil {
+			return totalAffected, err
+		}
+		if len(ids) == 0 {
+			return totalAffected, nil
+		}
+		err = store.WithDbSession(ctx, func(sess *db.Session) error {
+			affected, err := sess.In("id", ids).Delete(&stateHistoryEntry{})
+			totalAffected += affected
+			return err
+		})
+		if err != nil || len(ids) < sqlDeleteBatchSize {
+			return totalAffected, err
+		}
+	}
+}
+
+func statesToEntries(rule history_model.RuleMeta, states []state.StateTransition, logger log.Logger) []stateHistoryEntry {
+	rows := make([]stateHistoryEntry, 0, len(states))
+	for _, s := range states {
+		if !shouldRecord(s) {
+			continue
+		}
+		sanitizedLabels := removePrivateLabels(s.Labels)
+		lbls, err := json.Marshal(sanitizedLabels)
+		if err != nil {
+			logger.Error("Failed to serialize labels of state transition, skipping", "error", err)
+			continue
+		}
+		values, err := json.Marshal(valuesAsDataBlob(s.State))
+		if err != nil {
+			logger.Error("Failed to serialize values of state transition, skipping", "error", err)
+			continue
+		}
+		row := stateHistoryEntry{
+			OrgID:          rule.OrgID,
+			RuleUID:        rule.UID,
+			RuleTitle:      rule.Title,
+			RuleGroup:      rule.Group,
+			NamespaceUID:   rule.NamespaceUID,
+			DashboardUID:   rule.DashboardUID,
+			PanelID:        rule.PanelID,
+			Condition:      rule.Condition,
+			Fingerprint:    labelFingerprint(sanitizedLabels),
+			Labels:         string(lbls),
+			PreviousState:  s.PreviousState.String(),
+			PreviousReason: s.PreviousStateReason,
+			State:          s.State.State.String(),
+			Reason:         s.StateReason,
+			Values:         string(values),
+			EvaluatedAt:    s.LastEvaluationTime.UnixMilli(),
+		}
+		if s.State.State == eval.Error && s.Error != nil {
+			row.Error = s.Error.Error()
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (e stateHistoryEntry) labels() data.Labels {
+	lbls := data.Labels{}
+	if e.Labels == "" {
+		return lbls
+	}
+	if err := json.Unmarshal([]byte(e.Labels), &lbls); err != nil {
+		return data.Labels{}
+	}
+	return lbls
+}
+
+func (e stateHistoryEntry) lokiEntry() LokiEntry {
+	values := simplejson.New()
+	if e.Values != "" && e.Values != "null" {
+		if v, err := simplejson.NewJson([]byte(e.Values)); err == nil {
+			values = v
+		}
+	}
+	return LokiEntry{
+		SchemaVersion:  1,
+		Previous:       state.FormatStateAndReason(parseStateOrNormal(e.PreviousState), e.PreviousReason),
+		Current:        state.FormatStateAndReason(parseStateOrNormal(e.State), e.Reason),
+		Error:          e.Error,
+		Values:         values,
+		Condition:      e.Condition,
+		DashboardUID:   e.DashboardUID,
+		PanelID:        e.PanelID,
+		Fingerprint:    e.Fingerprint,
+		RuleTitle:      e.RuleTitle,
+		RuleUID:        e.RuleUID,
+		InstanceLabels: e.labels(),
+	}
+}
+
+func parseStateOrNormal(s string) eval.State {
+	st, err := eval.ParseStateString(s)
+	if err != nil {
+		return eval.Normal
+	}
+	return st
+}
@@ -0,0 +1,176 @@
+package historian
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	acfakes "github.com/grafana/grafana/pkg/services/ngalert/accesscontrol/fakes"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+func TestIntegrationSQLBackend(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	store := db.InitTestDB(t)
+	canReadAll := &acfakes.FakeRuleService{
+		CanReadAllRulesFunc: func(context.Context, identity.Requester) (bool, error) {
+			return true, nil
+		},
+	}
+	sql := NewSQLBackend(log.NewNopLogger(), store, fakes.NewRuleStore(t), metrics.NewHistorianMetrics(prometheus.NewRegistry(), metrics.Subsystem), canReadAll)
+
+	now := time.Now().Truncate(time.Millisecond)
+	rule := createTestRule()
+	transition := func(ts time.Time, previous eval.State, current eval.State, lbls data.Labels) state.StateTransition {
+		return state.StateTransition{
+			PreviousState: previous,
+			State: &state.State{
+				State:              current,
+				Labels:             lbls,
+				LastEvaluationTime: ts,
+				Error:              errors.New("oh no"),
+			},
+		}
+	}
+	a := data.Labels{"team": "a", "instance_id": "node_1", "__private__": "x"}
+	b := data.Labels{"team": "b", "instance_id": "node%2!"}
+	err := <-sql.Record(context.Background(), rule, []state.StateTransition{
+		transition(now.Add(-50*time.Minute), eval.Normal, eval.Alerting, a),
+		transition(now.Add(-40*time.Minute), eval.Normal, eval.Error, b),
+		transition(now.Add(-20*time.Minute), eval.Alerting, eval.Normal, a),
+		// not a transition
+		transition(now.Add(-10*time.Minute), eval.Normal, eval.Normal, a),
+	})
+	require.NoError(t, err)
+
+	t.Run("Query returns the transitions as a dataframe in ascending order", func(t *testing.T) {
+		frame, err := sql.Query(context.Background(), models.HistoryQuery{OrgID: 1, RuleUID: rule.UID, From: now.Add(-time.Hour), To: now})
+		require.NoError(t, err)
+		require.Equal(t, 3, frame.Rows())
+
+		times := frame.Fields[0]
+		require.Equal(t, now.Add(-50*time.Minute).UnixMilli(), times.At(0).(time.Time).UnixMilli())
+		require.Equal(t, now.Add(-20*time.Minute).UnixMilli(), times.At(2).(time.Time).UnixMilli())
+
+		var entry LokiEntry
+		require.NoError(t, json.Unmarshal(frame.Fields[1].At(1).(json.RawMessage), &entry))
+		require.Equal(t, "Normal", entry.Previous)
+		require.Equal(t, "Error", entry.Current)
+		require.Equal(t, "oh no", entry.Error)
+		require.Equal(t, rule.UID, entry.RuleUID)
+		require.Equal(t, map[string]string{"team": "b", "instance_id": "node%2!"}, entry.InstanceLabels)
+
+		var lbls map[string]string
+		require.NoError(t, json.Unmarshal(frame.Fields[2].At(1).(json.RawMessage), &lbls))
+		require.Equal(t, rule.NamespaceUID, lbls[FolderUIDLabel])
+		require.Equal(t, rule.Group, lbls[GroupLabel])
+	})
+
+	t.Run("Query filters by labels, time range and limit", func(t *testing.T) {
+		frame, err := sql.Query(context.Background(), models.HistoryQuery{OrgID: 1, From: now.Add(-time.Hour), To: now, Labels: map[string]string{"team": "a"}})
+		require.NoError(t, err)
+		require.Equal(t, 2, frame.Rows())
+
+		frame, err = sql.Query(context.Background(), models.HistoryQuery{OrgID: 1, From: now.Add(-30 * time.Minute), To: now})
+		require.NoError(t, err)
+		require.Equal(t, 1, frame.Rows())
+
+		frame, err = sql.Query(context.Background(), models.HistoryQuery{OrgID: 1, From: now.Add(-time.Hour), To: now, Limit: 2})
+		require.NoError(t, err)
+		require.Equal(t, 2, frame.Rows())
+
+		frame, err = sql.Query(context.Background(), models.HistoryQuery{OrgID: 2, From: now.Add(-time.Hour), To: now})
+		require.NoError(t, err)
+		require.Equal(t, 0, frame.Rows())
+	})
+
+	t.Run("Query filters by labels with LIKE wildcards before the limit", func(t *testing.T) {
+		frame, err := sql.Query(context.Background(), models.HistoryQuery{OrgID: 1, From: now.Add(-time.Hour), To: now, Labels: map[string]string{"instance_id": "node%2!"}, Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, 1, frame.Rows())
+
+		frame, err = sql.Query(context.Background(), models.HistoryQuery{OrgID: 1, From: now.Add(-time.Hour), To: now, Labels: map[string]string{"instance_id": "node_1"}, Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, 1, frame.Rows())
+
+		frame, err = sql.Query(context.Background(), models.HistoryQuery{OrgID: 1, From: now.Add(-time.Hour), To: now, Labels: map[string]string{"instance_id": "node%"}})
+		require.NoError(t, err)
+		require.Equal(t, 0, frame.Rows())
+	})
+
+	t.Run("QueryStats aggregates the transitions", func(t *testing.T) {
+		stats, err := sql.QueryStats(context.Background(), models.HistoryStatsQuery{
+			HistoryQuery: models.HistoryQuery{OrgID: 1, From: now.Add(-time.Hour), To: now},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, stats.Transitions)
+		require.False(t, stats.Truncated)
+		require.Len(t, stats.Rules, 1)
+		require.Equal(t, 1, stats.Rules[0].Firings)
+		require.Equal(t, 30*time.Minute, stats.Rules[0].MeanTimeToResolve)
+	})
+
+	t.Run("QueryStats reports when the transitions reach the limit", func(t *testing.T) {
+		stats, err := sql.QueryStats(context.Background(), models.HistoryStatsQuery{
+			HistoryQuery: models.HistoryQuery{OrgID: 1, From: now.Add(-time.Hour), To: now, Limit: 2},
+		})
+		require.NoError(t, err)
+		require.True(t, stats.Truncated)
+		require.Equal(t, 2, stats.Transitions)
+	})
+
+	t.Run("Query fetches more rows until the labels match exactly", func(t *testing.T) {
+		other := rule
+		other.OrgID = 3
+		// LIKE is case-insensitive, the most recent rows match the label filter in SQL but not exactly.
+		upper := data.Labels{"team": "A"}
+		err := <-sql.Record(context.Background(), other, []state.StateTransition{
+			transition(now.Add(-5*time.Minute), eval.Normal, eval.Alerting, a),
+			transition(now.Add(-4*time.Minute), eval.Normal, eval.Alerting, upper),
+			transition(now.Add(-3*time.Minute), eval.Alerting, eval.Normal, upper),
+			transition(now.Add(-2*time.Minute), eval.Normal, eval.Alerting, upper),
+		})
+		require.NoError(t, err)
+
+		query := models.HistoryQuery{OrgID: 3, From: now.Add(-time.Hour), To: now, Labels: map[string]string{"team": "a"}, Limit: 2}
+		frame, err := sql.Query(context.Background(), query)
+		require.NoError(t, err)
+		require.Equal(t, 1, frame.Rows())
+
+		query.Labels = map[string]string{"team": "A"}
+		frame, err = sql.Query(context.Background(), query)
+		require.NoError(t, err)
+		require.Equal(t, 2, frame.Rows())
+		require.Equal(t, now.Add(-2*time.Minute).UnixMilli(), frame.Fields[0].At(1).(time.Time).UnixMilli())
+	})
+
+	t.Run("DeleteExpiredSQLHistory deletes old transitions", func(t *testing.T) {
+		affected, err := DeleteExpiredSQLHistory(context.Background(), store, 30*time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), affected)
+
+		frame, err := sql.Query(context.Background(), models.HistoryQuery{OrgID: 1, From: now.Add(-time.Hour), To: now})
+		require.NoError(t, err)
+		require.Equal(t, 1, frame.Rows())
+	})
+}
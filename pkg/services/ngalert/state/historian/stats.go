@@ -0,0 +1,309 @@
+package historian
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+const (
+	// defaultStatsTopK is the number of the noisiest alert instances returned if the query does not define it.
+	defaultStatsTopK = 10
+	// defaultFlappingThreshold is the number of state transitions per hour from which an alert instance is flapping
+	// if the query does not define it.
+	defaultFlappingThreshold = 4
+	// defaultStatsRange is the time range that is aggregated if the query does not define it.
+	defaultStatsRange = 7 * 24 * time.Hour
+	// defaultStatsLimit is the number of state transitions that are aggregated if the query does not limit it.
+	defaultStatsLimit = 5000
+)
+
+// StatsQuerier represents the ability to aggregate state history.
+type StatsQuerier interface {
+	QueryStats(ctx context.Context, query ngmodels.HistoryStatsQuery) (*ngmodels.HistoryStats, error)
+}
+
+// transition is a state transition of an alert instance, as read from any of the backends.
+type transition struct {
+	time      time.Time
+	ruleUID   string
+	ruleTitle string
+	labels    data.Labels
+	previous  string
+	current   string
+}
+
+// withStatsDefaults returns the query with the defaults of the parameters it does not define.
+func withStatsDefaults(query ngmodels.HistoryStatsQuery, now time.Time) ngmodels.HistoryStatsQuery {
+	if query.To.IsZero() || query.To.After(now) {
+		query.To = now
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-defaultStatsRange)
+	}
+	if query.TopK <= 0 {
+		query.TopK = defaultStatsTopK
+	}
+	if query.FlappingThreshold <= 0 {
+		query.FlappingThreshold = defaultFlappingThreshold
+	}
+	return query
+}
+
+func statsLimit(limit int) int64 {
+	if limit <= 0 {
+		return defaultStatsLimit
+	}
+	return int64(limit)
+}
+
+// aggregateTransitions computes the statistics of the state transitions of a time range.
+// The state of an alert instance before its first transition of the range is the previous state of that transition,
+// and the state after its last transition lasts until the end of the range.
+// A firing is a transition to Alerting from another state and it is resolved by the next transition to Normal.
+func aggregateTransitions(query ngmodels.HistoryStatsQuery, transitions []transition) *ngmodels.HistoryStats {
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].time.Before(transitions[j].time)
+	})
+
+	hours := query.To.Sub(query.From).Hours()
+	instances := map[string]*instanceAccumulator{}
+	order := make([]string, 0)
+	for _, t := range transitions {
+		if t.time.Before(query.From) || t.time.After(query.To) {
+			continue
+		}
+		key := t.ruleUID + "/" + labelFingerprint(t.labels)
+		acc, ok := instances[key]
+		if !ok {
+			acc = &instanceAccumulator{
+				ruleUID:   t.ruleUID,
+				ruleTitle: t.ruleTitle,
+				labels:    t.labels,
+				last:      query.From,
+				state:     parseState(t.previous),
+			}
+			instances[key] = acc
+			order = append(order, key)
+		}
+		acc.add(t)
+	}
+
+	result := &ngmodels.HistoryStats{
+		From:     query.From,
+		To:       query.To,
+		Rules:    make([]ngmodels.RuleHistoryStats, 0),
+		Groups:   make([]ngmodels.GroupHistoryStats, 0),
+		Flapping: make([]ngmodels.InstanceHistoryStats, 0),
+		TopNoisy: make([]ngmodels.InstanceHistoryStats, 0),
+	}
+
+	rules := map[string]*ruleAccumulator{}
+	groups := map[string]*groupAccumulator{}
+	var ruleOrder, groupOrder []string
+	noisy := make([]ngmodels.InstanceHistoryStats, 0, len(order))
+	for _, key := range order {
+		acc := instances[key]
+		acc.finish(query.To)
+		result.Transitions += acc.transitions
+
+		stats := ngmodels.InstanceHistoryStats{
+			TransitionStats: acc.stats(hours),
+			RuleUID:         acc.ruleUID,
+			RuleTitle:       acc.ruleTitle,
+			Labels:          acc.labels,
+		}
+		stats.Flapping = stats.TransitionsPerHour >= query.FlappingThreshold
+		if stats.Flapping {
+			result.Flapping = append(result.Flapping, stats)
+		}
+		if stats.Firings > 0 {
+			noisy = append(noisy, stats)
+		}
+
+		r, ok := rules[acc.ruleUID]
+		if !ok {
+			r = &ruleAccumulator{uid: acc.ruleUID, title: acc.ruleTitle}
+			rules[acc.ruleUID] = r
+			ruleOrder = append(ruleOrder, acc.ruleUID)
+		}
+		r.merge(acc)
+		r.instances++
+		if stats.Flapping {
+			r.flapping++
+		}
+
+		if len(query.GroupBy) > 0 {
+			lbls := make(map[string]string, len(query.GroupBy))
+			for _, name := range query.GroupBy {
+				if v, ok := acc.labels[name]; ok {
+					lbls[name] = v
+				}
+			}
+			groupKey := data.Labels(lbls).String()
+			g, ok := groups[groupKey]
+			if !ok {
+				g = &groupAccumulator{labels: lbls}
+				groups[groupKey] = g
+				groupOrder = append(groupOrder, groupKey)
+			}
+			g.merge(acc)
+			g.instances++
+		}
+	}
+
+	for _, uid := range ruleOrder {
+		r := rules[uid]
+		result.Rules = append(result.Rules, ngmodels.RuleHistoryStats{
+			TransitionStats:   r.stats(hours),
+			RuleUID:           r.uid,
+			RuleTitle:         r.title,
+			Instances:         r.instances,
+			FlappingInstances: r.flapping,
+		})
+	}
+	sort.SliceStable(result.Rules, func(i, j int) bool {
+		return result.Rules[i].Transitions > result.Rules[j].Transitions
+	})
+
+	for _, key := range groupOrder {
+		g := groups[key]
+		result.Groups = append(result.Groups, ngmodels.GroupHistoryStats{
+			TransitionStats: g.stats(hours),
+			Labels:          g.labels,
+			Instances:       g.instances,
+		})
+	}
+	sort.SliceStable(result.Groups, func(i, j int) bool {
+		return result.Groups[i].Transitions > result.Groups[j].Transitions
+	})
+
+	sort.SliceStable(result.Flapping, func(i, j int) bool {
+		return result.Flapping[i].TransitionsPerHour > result.Flapping[j].TransitionsPerHour
+	})
+
+	sort.SliceStable(noisy, func(i, j int) bool {
+		if noisy[i].Firings != noisy[j].Firings {
+			return noisy[i].Firings > noisy[j].Firings
+		}
+		return noisy[i].Transitions > noisy[j].Transitions
+	})
+	if len(noisy) > query.TopK {
+		noisy = noisy[:query.TopK]
+	}
+	result.TopNoisy = noisy
+
+	return result
+}
+
+// parseState returns the state of a formatted state. Unknown states are kept as they are.
+func parseState(formatted string) string {
+	s, _, err := state.ParseFormattedState(formatted)
+	if err != nil {
+		return strings.TrimSpace(formatted)
+	}
+	return s.String()
+}
+
+// accumulator accumulates the statistics of state transitions.
+type accumulator struct {
+	transitions int
+	firings     int
+	resolutions int
+	resolveTime time.Duration
+	timeInState map[string]time.Duration
+}
+
+func (a *accumulator) merge(other *instanceAccumulator) {
+	a.transitions += other.transitions
+	a.firings += other.firings
+	a.resolutions += other.resolutions
+	a.resolveTime += other.resolveTime
+	if a.timeInState == nil {
+		a.timeInState = map[string]time.Duration{}
+	}
+	for s, d := range other.timeInState {
+		a.timeInState[s] += d
+	}
+}
+
+func (a *accumulator) stats(hours float64) ngmodels.TransitionStats {
+	result := ngmodels.TransitionStats{
+		Transitions: a.transitions,
+		Firings:     a.firings,
+		Resolutions: a.resolutions,
+		TimeInState: a.timeInState,
+	}
+	if hours > 0 {
+		result.TransitionsPerHour = float64(a.transitions) / hours
+	}
+	if a.resolutions > 0 {
+		result.MeanTimeToResolve = a.resolveTime / time.Duration(a.resolutions)
+	}
+	return result
+}
+
+type instanceAccumulator struct {
+	accumulator
+	ruleUID   string
+	ruleTitle string
+	labels    data.Labels
+	// state is the current state of the instance and last the time it entered it.
+	state string
+	last  time.Time
+	// firingSince is the time of the current firing, zero if the instance is not firing.
+	firingSince time.Time
+}
+
+func (a *instanceAccumulator) add(t transition) {
+	if a.timeInState == nil {
+		a.timeInState = map[string]time.Duration{}
+	}
+	current := parseState(t.current)
+	a.transitions++
+	a.timeInState[a.state] += t.time.Sub(a.last)
+	a.state = current
+	a.last = t.time
+
+	switch current {
+	case eval.Alerting.String():
+		if a.firingSince.IsZero() {
+			a.firings++
+			a.firingSince = t.time
+		}
+	case eval.Normal.String():
+		if !a.firingSince.IsZero() {
+			a.resolutions++
+			a.resolveTime += t.time.Sub(a.firingSince)
+			a.firingSince = time.Time{}
+		}
+	}
+}
+
+func (a *instanceAccumulator) finish(to time.Time) {
+	if to.After(a.last) {
+		a.timeInState[a.state] += to.Sub(a.last)
+		a.last = to
+	}
+}
+
+type ruleAccumulator struct {
+	accumulator
+	uid       string
+	title     string
+	instances int
+	flapping  int
+}
+
+type groupAccumulator struct {
+	accumulator
+	labels    map[string]string
+	instances int
+}
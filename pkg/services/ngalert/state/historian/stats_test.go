@@ -0,0 +1,246 @@
+package historian
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/annotations"
+	acfakes "github.com/grafana/grafana/pkg/services/ngalert/accesscontrol/fakes"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
+)
+
+func TestAggregateTransitions(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+	at := func(d time.Duration) time.Time { return from.Add(d) }
+
+	a := data.Labels{"team": "a", "instance": "1"}
+	b := data.Labels{"team": "b", "instance": "2"}
+	c := data.Labels{"team": "a"}
+	transitions := []transition{
+		{time: at(10 * time.Minute), ruleUID: "r1", ruleTitle: "rule 1", labels: a, previous: "Normal", current: "Pending"},
+		{time: at(15 * time.Minute), ruleUID: "r1", ruleTitle: "rule 1", labels: a, previous: "Pending", current: "Alerting"},
+		{time: at(45 * time.Minute), ruleUID: "r1", ruleTitle: "rule 1", labels: a, previous: "Alerting", current: "Normal"},
+		{time: at(60 * time.Minute), ruleUID: "r1", ruleTitle: "rule 1", labels: a, previous: "Normal", current: "Alerting"},
+		{time: at(90 * time.Minute), ruleUID: "r1", ruleTitle: "rule 1", labels: a, previous: "Alerting", current: "Normal (MissingSeries)"},
+		// outside of the time range
+		{time: at(-time.Hour), ruleUID: "r2", ruleTitle: "rule 2", labels: c, previous: "Normal", current: "Alerting (NoData)"},
+		{time: at(30 * time.Minute), ruleUID: "r2", ruleTitle: "rule 2", labels: c, previous: "Alerting (NoData)", current: "Normal"},
+	}
+	// b flaps every 5 minutes during the first 50 minutes
+	for i := 1; i <= 10; i++ {
+		tr := transition{time: at(time.Duration(i) * 5 * time.Minute), ruleUID: "r1", ruleTitle: "rule 1", labels: b, previous: "Normal", current: "Alerting"}
+		if i%2 == 0 {
+			tr.previous, tr.current = tr.current, tr.previous
+		}
+		transitions = append(transitions, tr)
+	}
+
+	query := models.HistoryStatsQuery{
+		HistoryQuery:      models.HistoryQuery{From: from, To: to},
+		GroupBy:           []string{"team"},
+		TopK:              1,
+		FlappingThreshold: 4,
+	}
+	stats := aggregateTransitions(query, transitions)
+
+	require.Equal(t, from, stats.From)
+	require.Equal(t, to, stats.To)
+	require.Equal(t, 16, stats.Transitions)
+
+	t.Run("per rule", func(t *testing.T) {
+		require.Len(t, stats.Rules, 2)
+		r1 := stats.Rules[0]
+		require.Equal(t, "r1", r1.RuleUID)
+		require.Equal(t, "rule 1", r1.RuleTitle)
+		require.Equal(t, 2, r1.Instances)
+		require.Equal(t, 1, r1.FlappingInstances)
+		require.Equal(t, 15, r1.Transitions)
+		require.Equal(t, 7.5, r1.TransitionsPerHour)
+		require.Equal(t, 7, r1.Firings)
+		require.Equal(t, 7, r1.Resolutions)
+		// (30m + 30m + 5 * 5m) / 7
+		require.Equal(t, 85*time.Minute/7, r1.MeanTimeToResolve)
+		require.Equal(t, map[string]time.Duration{
+			"Normal":   55*time.Minute + 95*time.Minute,
+			"Pending":  5 * time.Minute,
+			"Alerting": 60*time.Minute + 25*time.Minute,
+		}, r1.TimeInState)
+
+		r2 := stats.Rules[1]
+		require.Equal(t, "r2", r2.RuleUID)
+		require.Equal(t, 1, r2.Transitions)
+		require.Zero(t, r2.Firings)
+		require.Zero(t, r2.Resolutions)
+		require.Zero(t, r2.MeanTimeToResolve)
+		require.Equal(t, map[string]time.Duration{
+			"Alerting": 30 * time.Minute,
+			"Normal":   90 * time.Minute,
+		}, r2.TimeInState)
+	})
+
+	t.Run("per group", func(t *testing.T) {
+		require.Len(t, stats.Groups, 2)
+		require.Equal(t, map[string]string{"team": "b"}, stats.Groups[0].Labels)
+		require.Equal(t, 10, stats.Groups[0].Transitions)
+		require.Equal(t, 1, stats.Groups[0].Instances)
+		require.Equal(t, 5*time.Minute, stats.Groups[0].MeanTimeToResolve)
+		require.Equal(t, map[string]string{"team": "a"}, stats.Groups[1].Labels)
+		require.Equal(t, 6, stats.Groups[1].Transitions)
+		require.Equal(t, 2, stats.Groups[1].Instances)
+		require.Equal(t, 30*time.Minute, stats.Groups[1].MeanTimeToResolve)
+	})
+
+	t.Run("flapping and noisy instances", func(t *testing.T) {
+		require.Len(t, stats.Flapping, 1)
+		require.Equal(t, map[string]string(b), stats.Flapping[0].Labels)
+		require.Equal(t, 5.0, stats.Flapping[0].TransitionsPerHour)
+		require.True(t, stats.Flapping[0].Flapping)
+
+		require.Len(t, stats.TopNoisy, 1)
+		require.Equal(t, map[string]string(b), stats.TopNoisy[0].Labels)
+		require.Equal(t, 5, stats.TopNoisy[0].Firings)
+	})
+
+	t.Run("empty history", func(t *testing.T) {
+		stats := aggregateTransitions(query, nil)
+		require.Zero(t, stats.Transitions)
+		require.Empty(t, stats.Rules)
+		require.NotNil(t, stats.TopNoisy)
+	})
+}
+
+func TestWithStatsDefaults(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := withStatsDefaults(models.HistoryStatsQuery{HistoryQuery: models.HistoryQuery{To: now.Add(time.Hour)}}, now)
+	require.Equal(t, now, q.To)
+	require.Equal(t, now.Add(-defaultStatsRange), q.From)
+	require.Equal(t, defaultStatsTopK, q.TopK)
+	require.Equal(t, float64(defaultFlappingThreshold), q.FlappingThreshold)
+}
+
+func TestQueryStats(t *testing.T) {
+	to := time.Now().Truncate(time.Second)
+	from := to.Add(-time.Hour)
+	rule := models.RuleGen.With(models.RuleMuts.WithOrgID(1), withUID("my-rule"), models.RuleMuts.WithTitle("my-title")).GenerateRef()
+	canReadAll := &acfakes.FakeRuleService{
+		CanReadAllRulesFunc: func(context.Context, identity.Requester) (bool, error) {
+			return true, nil
+		},
+	}
+
+	t.Run("annotation backend", func(t *testing.T) {
+		rules := fakes.NewRuleStore(t)
+		rules.Rules[1] = []*models.AlertRule{rule}
+		store := &itemsAnnotationStore{items: []*annotations.ItemDTO{
+			{AlertID: rule.ID, Time: from.Add(10 * time.Minute).UnixMilli(), Text: "my-title {instance=1, team=a} - A=1.000000", PrevState: "Normal", NewState: "Alerting"},
+			{AlertID: rule.ID, Time: from.Add(20 * time.Minute).UnixMilli(), Text: "my-title {instance=1, team=a} - A=0.000000", PrevState: "Alerting", NewState: "Normal"},
+			{AlertID: rule.ID, Time: from.Add(30 * time.Minute).UnixMilli(), Text: "my-title {instance=2, team=b} - No data", PrevState: "Normal", NewState: "NoData"},
+			// annotation of a rule the user cannot read
+			{AlertID: rule.ID + 1, Time: from.Add(30 * time.Minute).UnixMilli(), Text: "other {} - Error", PrevState: "Normal", NewState: "Error"},
+		}}
+		met := metrics.NewHistorianMetrics(prometheus.NewRegistry(), metrics.Subsystem)
+		anns := NewAnnotationBackend(log.NewNopLogger(), store, rules, met, canReadAll)
+
+		stats, err := anns.QueryStats(context.Background(), models.HistoryStatsQuery{
+			HistoryQuery: models.HistoryQuery{OrgID: 1, From: from, To: to, Labels: map[string]string{"team": "a"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "alert", store.lastQuery.Type)
+		require.Equal(t, int64(defaultStatsLimit), store.lastQuery.Limit)
+		require.False(t, stats.Truncated)
+
+		require.Equal(t, 2, stats.Transitions)
+		require.Len(t, stats.Rules, 1)
+		require.Equal(t, rule.UID, stats.Rules[0].RuleUID)
+		require.Equal(t, 10*time.Minute, stats.Rules[0].MeanTimeToResolve)
+		require.Len(t, stats.TopNoisy, 1)
+		require.Equal(t, map[string]string{"instance": "1", "team": "a"}, stats.TopNoisy[0].Labels)
+
+		stats, err = anns.QueryStats(context.Background(), models.HistoryStatsQuery{
+			HistoryQuery: models.HistoryQuery{OrgID: 1, From: from, To: to, Limit: len(store.items)},
+		})
+		require.NoError(t, err)
+		require.True(t, stats.Truncated)
+	})
+
+	t.Run("loki backend", func(t *testing.T) {
+		entry := func(ts time.Time, previous, current string, lbls map[string]string) Sample {
+			line, err := json.Marshal(LokiEntry{SchemaVersion: 1, Previous: previous, Current: current, RuleUID: rule.UID, RuleTitle: rule.Title, InstanceLabels: lbls})
+			require.NoError(t, err)
+			return Sample{T: ts, V: string(line)}
+		}
+		body, err := json.Marshal(QueryRes{Data: QueryData{Result: []Stream{
+			{
+				Stream: map[string]string{FolderUIDLabel: rule.NamespaceUID},
+				Values: []Sample{
+					entry(from.Add(10*time.Minute), "Normal", "Alerting", map[string]string{"instance": "1"}),
+					entry(from.Add(40*time.Minute), "Alerting", "Normal", map[string]string{"instance": "1"}),
+				},
+			},
+		}}})
+		require.NoError(t, err)
+		req := NewFakeRequester().WithResponse(&http.Response{
+			Status:        "200 OK",
+			StatusCode:    200,
+			Body:          io.NopCloser(bytes.NewBuffer(body)),
+			ContentLength: int64(len(body)),
+			Header:        make(http.Header),
+		})
+		url, _ := url.Parse("http://some.url")
+		cfg := LokiConfig{
+			WritePathURL: url,
+			ReadPathURL:  url,
+			Encoder:      JsonEncoder{},
+			MaxQuerySize: 65536,
+		}
+		met := metrics.NewHistorianMetrics(prometheus.NewRegistry(), metrics.Subsystem)
+		loki := NewRemoteLokiBackend(log.NewNopLogger(), cfg, req, met, tracing.InitializeTracerForTest(), fakes.NewRuleStore(t), canReadAll)
+
+		stats, err := loki.QueryStats(context.Background(), models.HistoryStatsQuery{
+			HistoryQuery: models.HistoryQuery{OrgID: 1, From: from, To: to},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "5000", req.lastRequest.URL.Query().Get("limit"))
+		require.False(t, stats.Truncated)
+
+		require.Equal(t, 2, stats.Transitions)
+		require.Len(t, stats.Rules, 1)
+		require.Equal(t, rule.UID, stats.Rules[0].RuleUID)
+		require.Equal(t, 1, stats.Rules[0].Firings)
+		require.Equal(t, 30*time.Minute, stats.Rules[0].MeanTimeToResolve)
+		require.Equal(t, map[string]time.Duration{
+			"Normal":   30 * time.Minute,
+			"Alerting": 30 * time.Minute,
+		}, stats.Rules[0].TimeInState)
+	})
+}
+
+type itemsAnnotationStore struct {
+	items     []*annotations.ItemDTO
+	lastQuery *annotations.ItemQuery
+}
+
+func (s *itemsAnnotationStore) Find(_ context.Context, query *annotations.ItemQuery) ([]*annotations.ItemDTO, error) {
+	s.lastQuery = query
+	return s.items, nil
+}
+
+func (s *itemsAnnotationStore) Save(context.Context, *PanelKey, []annotations.Item, int64, log.Logger) error {
+	return nil
+}
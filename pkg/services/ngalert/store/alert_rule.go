@@ -179,6 +179,7 @@ func (st DBstore) InsertAlertRules(ctx context.Context, rules []ngmodels.AlertRu
 				Labels:               r.Labels,
 				Record:               r.Record,
 				NotificationSettings: r.NotificationSettings,
+				Dependencies:         r.Dependencies,
 			})
 		}
 		if len(newRules) > 0 {
@@ -260,6 +261,7 @@ func (st DBstore) UpdateAlertRules(ctx context.Context, rules []ngmodels.UpdateR
 				Annotations:          r.New.Annotations,
 				Labels:               r.New.Labels,
 				NotificationSettings: r.New.NotificationSettings,
+				Dependencies:         r.New.Dependencies,
 			})
 		}
 		if len(ruleVersions) > 0 {
@@ -746,6 +746,20 @@ func TestIntegrationInsertAlertRules(t *testing.T) {
 		})
 	})
 
+	t.Run("inserted rules keep their dependencies", func(t *testing.T) {
+		dependencies := []models.RuleDependency{
+			{RuleUID: rules[0].UID, Condition: models.DependencyConditionNotFiring},
+			{RuleUID: rules[5].UID},
+		}
+		rule := gen.With(models.RuleGen.WithDependencies(dependencies...)).Generate()
+		_, err := store.InsertAlertRules(context.Background(), []models.AlertRule{rule})
+		require.NoError(t, err)
+
+		dbRule, err := store.GetAlertRuleByUID(context.Background(), &models.GetAlertRuleByUIDQuery{OrgID: orgID, UID: rule.UID})
+		require.NoError(t, err)
+		require.Equal(t, dependencies, dbRule.Dependencies)
+	})
+
 	t.Run("fail to insert rules with same ID", func(t *testing.T) {
 		_, err = store.InsertAlertRules(context.Background(), []models.AlertRule{rules[0]})
 		require.ErrorIs(t, err, models.ErrAlertRuleConflictBase)
@@ -5,20 +5,23 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana/pkg/infra/kvstore"
 )
 
 type FakeKVStore struct {
-	Mtx   sync.Mutex
-	Store map[int64]map[string]map[string]string
+	Mtx      sync.Mutex
+	Store    map[int64]map[string]map[string]string
+	Versions map[kvstore.Key]int64
 }
 
 func NewFakeKVStore(t *testing.T) *FakeKVStore {
 	t.Helper()
 
 	return &FakeKVStore{
-		Store: map[int64]map[string]map[string]string{},
+		Store:    map[int64]map[string]map[string]string{},
+		Versions: map[kvstore.Key]int64{},
 	}
 }
 
@@ -44,6 +47,11 @@ func (fkv *FakeKVStore) Get(_ context.Context, orgId int64, namespace string, ke
 func (fkv *FakeKVStore) Set(_ context.Context, orgId int64, namespace string, key string, value string) error {
 	fkv.Mtx.Lock()
 	defer fkv.Mtx.Unlock()
+	fkv.set(orgId, namespace, key, value)
+	return nil
+}
+
+func (fkv *FakeKVStore) set(orgId int64, namespace string, key string, value string) {
 	org, ok := fkv.Store[orgId]
 	if !ok {
 		fkv.Store[orgId] = map[string]map[string]string{}
@@ -54,8 +62,7 @@ func (fkv *FakeKVStore) Set(_ context.Context, orgId int64, namespace string, ke
 	}
 
 	fkv.Store[orgId][namespace][key] = value
-
-	return nil
+	fkv.Versions[kvstore.Key{OrgId: orgId, Namespace: namespace, Key: key}]++
 }
 func (fkv *FakeKVStore) Del(_ context.Context, orgId int64, namespace string, key string) error {
 	fkv.Mtx.Lock()
@@ -70,6 +77,7 @@ func (fkv *FakeKVStore) Del(_ context.Context, orgId int64, namespace string, ke
 	}
 
 	delete(fkv.Store[orgId][namespace], key)
+	delete(fkv.Versions, kvstore.Key{OrgId: orgId, Namespace: namespace, Key: key})
 
 	return nil
 }
@@ -120,3 +128,44 @@ func (fkv *FakeKVStore) GetAll(ctx context.Context, orgId int64, namespace strin
 	}
 	return all, nil
 }
+
+// SetWithTTL sets the value, the ttl is ignored.
+func (fkv *FakeKVStore) SetWithTTL(ctx context.Context, orgId int64, namespace string, key string, value string, _ time.Duration) error {
+	return fkv.Set(ctx, orgId, namespace, key, value)
+}
+
+func (fkv *FakeKVStore) GetWithVersion(ctx context.Context, orgId int64, namespace string, key string) (kvstore.VersionedValue, bool, error) {
+	v, ok, err := fkv.Get(ctx, orgId, namespace, key)
+	if !ok || err != nil {
+		return kvstore.VersionedValue{}, ok, err
+	}
+	fkv.Mtx.Lock()
+	defer fkv.Mtx.Unlock()
+	return kvstore.VersionedValue{Value: v, Version: fkv.Versions[kvstore.Key{OrgId: orgId, Namespace: namespace, Key: key}]}, true, nil
+}
+
+// CompareAndSwap sets the value if the version matches, the ttl is ignored.
+func (fkv *FakeKVStore) CompareAndSwap(_ context.Context, orgId int64, namespace string, key string, version int64, value string, _ time.Duration) (int64, bool, error) {
+	fkv.Mtx.Lock()
+	defer fkv.Mtx.Unlock()
+	k := kvstore.Key{OrgId: orgId, Namespace: namespace, Key: key}
+	if fkv.Versions[k] != version {
+		return 0, false, nil
+	}
+	fkv.set(orgId, namespace, key, value)
+	return fkv.Versions[k], true, nil
+}
+
+// Watch returns a channel that is closed when ctx is done, changes are not streamed.
+func (fkv *FakeKVStore) Watch(ctx context.Context, _ int64, _ string, _ string) (<-chan kvstore.Event, error) {
+	events := make(chan kvstore.Event)
+	go func() {
+		<-ctx.Done()
+		close(events)
+	}()
+	return events, nil
+}
+
+func (fkv *FakeKVStore) DeleteExpired(context.Context) (int64, error) {
+	return 0, nil
+}
@@ -0,0 +1,196 @@
+package writer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/httpclient"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const influxDBBackendType = "influxdb"
+
+// The largest part of an error response that is included in the returned error.
+const maxInfluxDBErrorBody = 1024
+
+// InfluxDBWriter writes recording rule results in the InfluxDB line protocol.
+// It works with the v1 and v2 write APIs, as the URL includes the write path and the target database or bucket.
+type InfluxDBWriter struct {
+	client  *http.Client
+	url     string
+	token   string
+	clock   clock.Clock
+	logger  log.Logger
+	metrics *metrics.RemoteWriter
+}
+
+func NewInfluxDBWriter(
+	settings setting.RecordingRuleSettings,
+	httpClientProvider HttpClientProvider,
+	clock clock.Clock,
+	tracer tracing.Tracer,
+	l log.Logger,
+	metrics *metrics.RemoteWriter,
+) (*InfluxDBWriter, error) {
+	if err := validateInfluxDBSettings(settings); err != nil {
+		return nil, err
+	}
+
+	timeouts := httpclient.DefaultTimeoutOptions
+	timeouts.Timeout = settings.Timeout
+
+	cl, err := httpClientProvider.New(httpclient.Options{
+		Middlewares: []httpclient.Middleware{
+			httpclient.TracingMiddleware(tracer),
+		},
+		BasicAuth: createAuthOpts(settings.InfluxDB.BasicAuthUsername, settings.InfluxDB.BasicAuthPassword),
+		Timeouts:  &timeouts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &InfluxDBWriter{
+		client:  cl,
+		url:     settings.InfluxDB.URL,
+		token:   settings.InfluxDB.Token,
+		clock:   clock,
+		logger:  l,
+		metrics: metrics,
+	}, nil
+}
+
+func validateInfluxDBSettings(settings setting.RecordingRuleSettings) error {
+	s := settings.InfluxDB
+	if s.BasicAuthUsername != "" && s.BasicAuthPassword == "" {
+		return fmt.Errorf("basic auth password is required if username is set")
+	}
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid URL: scheme and host are required")
+	}
+
+	if settings.Timeout <= 0 {
+		return fmt.Errorf("timeout must be greater than 0")
+	}
+
+	return nil
+}
+
+// Write writes the given frames to the InfluxDB write endpoint.
+func (w InfluxDBWriter) Write(ctx context.Context, name string, t time.Time, frames data.Frames, extraLabels map[string]string) error {
+	l := w.logger.FromContext(ctx)
+	ruleKey, found := models.RuleKeyFromContext(ctx)
+	if !found {
+		// sanity check, this should never happen
+		return fmt.Errorf("rule key not found in context")
+	}
+	lvs := []string{fmt.Sprint(ruleKey.OrgID), influxDBBackendType}
+
+	points, err := PointsFromFrames(name, t, frames, extraLabels)
+	if err != nil {
+		return err
+	}
+
+	body, skipped := lineProtocol(points)
+	if skipped > 0 {
+		// line protocol does not support NaN and infinite values
+		l.Debug("Skipped points with values that cannot be written", "name", name, "count", skipped)
+	}
+	if body.Len() == 0 {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("User-Agent", "grafana-recording-rule")
+	if w.token != "" {
+		req.Header.Set("Authorization", "Token "+w.token)
+	}
+
+	l.Debug("Writing metric", "name", name)
+	writeStart := w.clock.Now()
+	res, err := w.client.Do(req)
+	w.metrics.WriteDuration.WithLabelValues(lvs...).Observe(w.clock.Now().Sub(writeStart).Seconds())
+	if err != nil {
+		w.metrics.WritesTotal.WithLabelValues(append(lvs, "0")...).Inc()
+		return fmt.Errorf("failed to write points: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	w.metrics.WritesTotal.WithLabelValues(append(lvs, fmt.Sprint(res.StatusCode))...).Inc()
+	if res.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, maxInfluxDBErrorBody))
+		return fmt.Errorf("failed to write points: unexpected status code %d: %s", res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	return nil
+}
+
+// lineProtocol encodes the points with a single field named value and nanosecond timestamps.
+// Points with NaN or infinite values are skipped, their count is returned.
+func lineProtocol(points []Point) (*bytes.Buffer, int) {
+	buf := &bytes.Buffer{}
+	skipped := 0
+	for _, p := range points {
+		if math.IsNaN(p.Metric.V) || math.IsInf(p.Metric.V, 0) {
+			skipped++
+			continue
+		}
+
+		buf.WriteString(measurementEscaper.Replace(p.Name))
+
+		// tags sorted by key are faster to write
+		keys := make([]string, 0, len(p.Labels))
+		for k, v := range p.Labels {
+			// empty tag values are not allowed
+			if v != "" {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			buf.WriteByte(',')
+			buf.WriteString(tagEscaper.Replace(k))
+			buf.WriteByte('=')
+			buf.WriteString(tagEscaper.Replace(p.Labels[k]))
+		}
+
+		buf.WriteString(" value=")
+		buf.WriteString(strconv.FormatFloat(p.Metric.V, 'g', -1, 64))
+		buf.WriteByte(' ')
+		buf.WriteString(strconv.FormatInt(p.Metric.T.UnixNano(), 10))
+		buf.WriteByte('\n')
+	}
+	return buf, skipped
+}
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `, "\n", `\n`)
+	tagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `, "\n", `\n`)
+)
@@ -0,0 +1,144 @@
+package writer
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/httpclient"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestValidateInfluxDBSettings(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		settings setting.RecordingRuleSettings
+		err      bool
+	}{
+		{
+			name: "missing host",
+			settings: setting.RecordingRuleSettings{
+				InfluxDB: setting.RecordingRuleInfluxDBSettings{URL: "/api/v2/write"},
+				Timeout:  10,
+			},
+			err: true,
+		},
+		{
+			name: "missing password",
+			settings: setting.RecordingRuleSettings{
+				InfluxDB: setting.RecordingRuleInfluxDBSettings{URL: "http://localhost:8086/write?db=test", BasicAuthUsername: "user"},
+				Timeout:  10,
+			},
+			err: true,
+		},
+		{
+			name: "timeout is 0",
+			settings: setting.RecordingRuleSettings{
+				InfluxDB: setting.RecordingRuleInfluxDBSettings{URL: "http://localhost:8086/write?db=test"},
+			},
+			err: true,
+		},
+		{
+			name: "valid settings",
+			settings: setting.RecordingRuleSettings{
+				InfluxDB: setting.RecordingRuleInfluxDBSettings{URL: "http://localhost:8086/api/v2/write?org=test&bucket=test", Token: "token"},
+				Timeout:  10,
+			},
+			err: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateInfluxDBSettings(tc.settings)
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLineProtocol(t *testing.T) {
+	now := time.Unix(1700000000, 5)
+	points := []Point{
+		{Name: "test metric", Labels: map[string]string{"b": "x,y", "a": "1=2", "empty": ""}, Metric: Metric{T: now, V: 1.5}},
+		{Name: "test", Labels: map[string]string{}, Metric: Metric{T: now, V: math.NaN()}},
+		{Name: "test", Labels: map[string]string{"c d": "e"}, Metric: Metric{T: now, V: 2}},
+	}
+
+	body, skipped := lineProtocol(points)
+	require.Equal(t, 1, skipped)
+	require.Equal(t, "test\\ metric,a=1\\=2,b=x\\,y value=1.5 1700000000000000005\n"+
+		"test,c\\ d=e value=2 1700000000000000005\n", body.String())
+}
+
+func TestInfluxDBWriter_Write(t *testing.T) {
+	var lastBody, lastAuth string
+	status := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v2/write", r.URL.Path)
+		require.Equal(t, "test", r.URL.Query().Get("bucket"))
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		lastBody = string(b)
+		lastAuth = r.Header.Get("Authorization")
+		w.WriteHeader(status)
+		if status != http.StatusNoContent {
+			_, _ = w.Write([]byte(`{"code":"invalid","message":"partial write"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	writer, err := NewInfluxDBWriter(setting.RecordingRuleSettings{
+		InfluxDB: setting.RecordingRuleInfluxDBSettings{
+			URL:   srv.URL + "/api/v2/write?org=test&bucket=test",
+			Token: "secret",
+		},
+		Timeout: time.Second,
+	}, httpclient.NewProvider(httpclient.ProviderOptions{Middlewares: []httpclient.Middleware{}}), clock.New(), tracing.InitializeTracerForTest(), log.New("test"), metrics.NewRemoteWriterMetrics(prometheus.NewRegistry()))
+	require.NoError(t, err)
+
+	ctx := ngmodels.WithRuleKey(context.Background(), ngmodels.GenerateRuleKey(1))
+	now := time.Now()
+	series := []map[string]string{{"foo": "1"}, {"foo": "2"}}
+	frames := frameGenFromLabels(t, data.FrameTypeNumericWide, series)
+
+	t.Run("error when frames are empty", func(t *testing.T) {
+		err := writer.Write(ctx, "test", now, data.Frames{data.NewFrame("test")}, map[string]string{})
+		require.Error(t, err)
+	})
+
+	t.Run("writes expected lines", func(t *testing.T) {
+		err := writer.Write(ctx, "test", now, frames, map[string]string{"extra": "label"})
+		require.NoError(t, err)
+		require.Equal(t, "Token secret", lastAuth)
+
+		points, err := PointsFromFrames("test", now, frames, map[string]string{"extra": "label"})
+		require.NoError(t, err)
+		expected, _ := lineProtocol(points)
+		require.Equal(t, expected.String(), lastBody)
+		require.Contains(t, lastBody, "test,extra=label,foo=1 value=")
+	})
+
+	t.Run("include response body when write fails", func(t *testing.T) {
+		status = http.StatusBadRequest
+		t.Cleanup(func() { status = http.StatusNoContent })
+
+		err := writer.Write(ctx, "test", now, frames, map[string]string{})
+		require.ErrorContains(t, err, "unexpected status code 400")
+		require.ErrorContains(t, err, "partial write")
+	})
+}
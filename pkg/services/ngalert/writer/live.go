@@ -0,0 +1,107 @@
+package writer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/grafana-plugin-sdk-go/live"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/live/managedstream"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const liveBackendType = "live"
+
+// LiveNamespace is the namespace of the managed streams the recorded metrics are pushed to.
+// A metric is pushed to the channel stream/recording_rules/<metric>.
+const LiveNamespace = "recording_rules"
+
+// ManagedStreamGetter gets the Grafana Live managed streams, it is implemented by managedstream.Runner.
+type ManagedStreamGetter interface {
+	GetOrCreateStream(orgID int64, scope string, namespace string) (*managedstream.NamespaceStream, error)
+}
+
+// LiveWriter pushes recording rule results to Grafana Live managed streams, in the org of the rule.
+// Every write pushes a single row frame with a value field per series.
+type LiveWriter struct {
+	streams ManagedStreamGetter
+	clock   clock.Clock
+	logger  log.Logger
+	metrics *metrics.RemoteWriter
+}
+
+func NewLiveWriter(streams ManagedStreamGetter, clock clock.Clock, l log.Logger, metrics *metrics.RemoteWriter) *LiveWriter {
+	return &LiveWriter{
+		streams: streams,
+		clock:   clock,
+		logger:  l,
+		metrics: metrics,
+	}
+}
+
+// LivePath returns the path of the channel a metric is pushed to. Colons are not allowed in channel paths.
+func LivePath(name string) string {
+	return strings.ReplaceAll(name, ":", "_")
+}
+
+// Write pushes the given frames to the managed stream of the metric.
+func (w LiveWriter) Write(ctx context.Context, name string, t time.Time, frames data.Frames, extraLabels map[string]string) error {
+	l := w.logger.FromContext(ctx)
+	ruleKey, found := models.RuleKeyFromContext(ctx)
+	if !found {
+		// sanity check, this should never happen
+		return fmt.Errorf("rule key not found in context")
+	}
+	lvs := []string{fmt.Sprint(ruleKey.OrgID), liveBackendType}
+
+	points, err := PointsFromFrames(name, t, frames, extraLabels)
+	if err != nil {
+		return err
+	}
+	if len(points) == 0 {
+		return nil
+	}
+
+	channel := live.Channel{Scope: live.ScopeStream, Namespace: LiveNamespace, Path: LivePath(name)}
+	if !channel.IsValid() {
+		return fmt.Errorf("metric %q cannot be used as a live channel path", name)
+	}
+
+	fields := make([]*data.Field, 0, len(points)+1)
+	fields = append(fields, data.NewField("time", nil, []time.Time{t}))
+	for _, p := range points {
+		fields = append(fields, data.NewField("value", p.Labels, []float64{p.Metric.V}))
+	}
+	frame := data.NewFrame(name, fields...)
+
+	l.Debug("Writing metric", "name", name)
+	writeStart := w.clock.Now()
+	writeErr := w.push(ctx, ruleKey.OrgID, channel.Path, frame)
+	w.metrics.WriteDuration.WithLabelValues(lvs...).Observe(w.clock.Now().Sub(writeStart).Seconds())
+
+	// use the same status codes as the SQL backend
+	status := sqlStatusOK
+	if writeErr != nil {
+		status = sqlStatusError
+	}
+	w.metrics.WritesTotal.WithLabelValues(append(lvs, status)...).Inc()
+
+	if writeErr != nil {
+		return fmt.Errorf("failed to push frame: %w", writeErr)
+	}
+	return nil
+}
+
+func (w LiveWriter) push(ctx context.Context, orgID int64, path string, frame *data.Frame) error {
+	stream, err := w.streams.GetOrCreateStream(orgID, live.ScopeStream, LiveNamespace)
+	if err != nil {
+		return err
+	}
+	return stream.Push(ctx, path, frame)
+}
@@ -0,0 +1,72 @@
+package writer
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/live/managedstream"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+type published struct {
+	orgID   int64
+	channel string
+	data    []byte
+}
+
+func TestLiveWriter_Write(t *testing.T) {
+	var last *published
+	runner := managedstream.NewRunner(func(orgID int64, channel string, data []byte) error {
+		last = &published{orgID: orgID, channel: channel, data: data}
+		return nil
+	}, nil, managedstream.NewMemoryFrameCache())
+
+	writer := NewLiveWriter(runner, clock.New(), log.New("test"), metrics.NewRemoteWriterMetrics(prometheus.NewRegistry()))
+	ctx := ngmodels.WithRuleKey(context.Background(), ngmodels.AlertRuleKey{OrgID: 3, UID: "rule"})
+	now := time.Now()
+	series := []map[string]string{{"foo": "1"}, {"foo": "2"}}
+	frames := frameGenFromLabels(t, data.FrameTypeNumericWide, series)
+
+	t.Run("error when frames are empty", func(t *testing.T) {
+		err := writer.Write(ctx, "test", now, data.Frames{data.NewFrame("test")}, map[string]string{})
+		require.Error(t, err)
+	})
+
+	t.Run("pushes a frame to the stream of the metric", func(t *testing.T) {
+		err := writer.Write(ctx, "job:requests:rate5m", now, frames, map[string]string{"extra": "label"})
+		require.NoError(t, err)
+		require.NotNil(t, last)
+		require.Equal(t, int64(3), last.orgID)
+		require.Equal(t, "stream/recording_rules/job_requests_rate5m", last.channel)
+
+		frame := &data.Frame{}
+		require.NoError(t, json.Unmarshal(last.data, frame))
+		require.Equal(t, "job:requests:rate5m", frame.Name)
+		require.Len(t, frame.Fields, len(series)+1)
+		require.Equal(t, 1, frame.Rows())
+		for i, labels := range series {
+			expected := data.Labels{"extra": "label"}
+			for k, v := range labels {
+				expected[k] = v
+			}
+			require.Equal(t, expected, frame.Fields[i+1].Labels)
+		}
+
+		channels, err := runner.GetManagedChannels(3)
+		require.NoError(t, err)
+		found := false
+		for _, ch := range channels {
+			found = found || ch.Channel == "stream/recording_rules/job_requests_rate5m"
+		}
+		require.True(t, found)
+	})
+}
@@ -0,0 +1,35 @@
+package writer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// Writer writes the result of a recording rule evaluation.
+type Writer interface {
+	Write(ctx context.Context, name string, t time.Time, frames data.Frames, extraLabels map[string]string) error
+}
+
+// Router sends the result of a recording rule evaluation to the writer of the rule target.
+// The target is read from the context, see models.WithRecordTarget.
+type Router struct {
+	writers map[models.RecordTarget]Writer
+}
+
+func NewRouter(writers map[models.RecordTarget]Writer) *Router {
+	return &Router{writers: writers}
+}
+
+func (r *Router) Write(ctx context.Context, name string, t time.Time, frames data.Frames, extraLabels map[string]string) error {
+	target := models.RecordTargetFromContext(ctx)
+	w, ok := r.writers[target]
+	if !ok {
+		return fmt.Errorf("recording rule target %q is not configured", target)
+	}
+	return w.Write(ctx, name, t, frames, extraLabels)
+}
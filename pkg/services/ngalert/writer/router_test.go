@@ -0,0 +1,35 @@
+package writer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestRouter_Write(t *testing.T) {
+	var written []ngmodels.RecordTarget
+	fakeFor := func(target ngmodels.RecordTarget) Writer {
+		return FakeWriter{WriteFunc: func(ctx context.Context, name string, t time.Time, frames data.Frames, extraLabels map[string]string) error {
+			written = append(written, target)
+			return nil
+		}}
+	}
+	router := NewRouter(map[ngmodels.RecordTarget]Writer{
+		ngmodels.RecordTargetPrometheus: fakeFor(ngmodels.RecordTargetPrometheus),
+		ngmodels.RecordTargetLive:       fakeFor(ngmodels.RecordTargetLive),
+	})
+
+	ctx := context.Background()
+	require.NoError(t, router.Write(ctx, "test", time.Now(), nil, nil))
+	require.NoError(t, router.Write(ngmodels.WithRecordTarget(ctx, ngmodels.RecordTargetLive), "test", time.Now(), nil, nil))
+	require.NoError(t, router.Write(ngmodels.WithRecordTarget(ctx, ngmodels.RecordTargetPrometheus), "test", time.Now(), nil, nil))
+	require.Equal(t, []ngmodels.RecordTarget{ngmodels.RecordTargetPrometheus, ngmodels.RecordTargetLive, ngmodels.RecordTargetPrometheus}, written)
+
+	err := router.Write(ngmodels.WithRecordTarget(ctx, ngmodels.RecordTargetSQL), "test", time.Now(), nil, nil)
+	require.ErrorContains(t, err, `recording rule target "sql" is not configured`)
+}
@@ -0,0 +1,275 @@
+package writer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	_ "github.com/lib/pq"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const sqlBackendType = "sql"
+
+// The SQL backends do not have status codes, these keep the writes metric comparable with the HTTP backends.
+const (
+	sqlStatusOK    = "200"
+	sqlStatusError = "500"
+)
+
+// A table name, optionally prefixed by a schema.
+var sqlTableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// DataSourceGetter gets the SQL data source and its password.
+type DataSourceGetter interface {
+	GetDataSource(ctx context.Context, query *datasources.GetDataSourceQuery) (*datasources.DataSource, error)
+	DecryptedPassword(ctx context.Context, ds *datasources.DataSource) (string, error)
+}
+
+// SQLWriter inserts recording rule results into a table of a MySQL or PostgreSQL data source.
+// The table must have the columns time, metric, labels and value. The labels are written as a JSON object.
+// Only the host, database, credentials and, for PostgreSQL, the SSL mode of the data source are used.
+type SQLWriter struct {
+	datasources   DataSourceGetter
+	datasourceUID string
+	table         string
+	timeout       time.Duration
+	open          func(driverName, dsn string) (*sql.DB, error)
+
+	mtx sync.Mutex
+	// open connections by org, data sources are per org
+	conns map[int64]*sqlConn
+
+	clock   clock.Clock
+	logger  log.Logger
+	metrics *metrics.RemoteWriter
+}
+
+type sqlConn struct {
+	version int
+	dialect sqlDialect
+	db      *sql.DB
+}
+
+func NewSQLWriter(
+	settings setting.RecordingRuleSettings,
+	datasources DataSourceGetter,
+	clock clock.Clock,
+	l log.Logger,
+	metrics *metrics.RemoteWriter,
+) (*SQLWriter, error) {
+	if err := validateSQLSettings(settings); err != nil {
+		return nil, err
+	}
+
+	return &SQLWriter{
+		datasources:   datasources,
+		datasourceUID: settings.SQL.DatasourceUID,
+		table:         settings.SQL.Table,
+		timeout:       settings.Timeout,
+		open:          sql.Open,
+		conns:         make(map[int64]*sqlConn),
+		clock:         clock,
+		logger:        l,
+		metrics:       metrics,
+	}, nil
+}
+
+func validateSQLSettings(settings setting.RecordingRuleSettings) error {
+	if settings.SQL.DatasourceUID == "" {
+		return fmt.Errorf("data source UID is required")
+	}
+
+	if !sqlTableName.MatchString(settings.SQL.Table) {
+		return fmt.Errorf("invalid table name %q", settings.SQL.Table)
+	}
+
+	if settings.Timeout <= 0 {
+		return fmt.Errorf("timeout must be greater than 0")
+	}
+
+	return nil
+}
+
+// Write inserts the given frames in the table, in a single statement.
+func (w *SQLWriter) Write(ctx context.Context, name string, t time.Time, frames data.Frames, extraLabels map[string]string) error {
+	l := w.logger.FromContext(ctx)
+	ruleKey, found := models.RuleKeyFromContext(ctx)
+	if !found {
+		// sanity check, this should never happen
+		return fmt.Errorf("rule key not found in context")
+	}
+	lvs := []string{fmt.Sprint(ruleKey.OrgID), sqlBackendType}
+
+	points, err := PointsFromFrames(name, t, frames, extraLabels)
+	if err != nil {
+		return err
+	}
+	if len(points) == 0 {
+		return nil
+	}
+
+	conn, err := w.conn(ctx, ruleKey.OrgID)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := conn.dialect.insert(w.table, points)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	l.Debug("Writing metric", "name", name)
+	writeStart := w.clock.Now()
+	_, writeErr := conn.db.ExecContext(ctx, query, args...)
+	w.metrics.WriteDuration.WithLabelValues(lvs...).Observe(w.clock.Now().Sub(writeStart).Seconds())
+
+	status := sqlStatusOK
+	if writeErr != nil {
+		status = sqlStatusError
+	}
+	w.metrics.WritesTotal.WithLabelValues(append(lvs, status)...).Inc()
+
+	if writeErr != nil {
+		return fmt.Errorf("failed to insert points: %w", writeErr)
+	}
+	return nil
+}
+
+// conn returns the connection to the data source of the org, it is opened again when the data source changes.
+func (w *SQLWriter) conn(ctx context.Context, orgID int64) (*sqlConn, error) {
+	ds, err := w.datasources.GetDataSource(ctx, &datasources.GetDataSourceQuery{
+		UID:   w.datasourceUID,
+		OrgID: orgID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data source %q: %w", w.datasourceUID, err)
+	}
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if conn, ok := w.conns[orgID]; ok {
+		if conn.version == ds.Version {
+			return conn, nil
+		}
+		_ = conn.db.Close()
+		delete(w.conns, orgID)
+	}
+
+	password, err := w.datasources.DecryptedPassword(ctx, ds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the password of data source %q: %w", ds.UID, err)
+	}
+
+	dialect, dsn, err := sqlDataSourceDSN(ds, password)
+	if err != nil {
+		return nil, err
+	}
+	db, err := w.open(dialect.driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &sqlConn{version: ds.Version, dialect: dialect, db: db}
+	w.conns[orgID] = conn
+	return conn, nil
+}
+
+type sqlDialect struct {
+	driverName  string
+	placeholder func(n int) string
+}
+
+var (
+	mysqlDialect = sqlDialect{
+		driverName:  "mysql",
+		placeholder: func(int) string { return "?" },
+	}
+	postgresDialect = sqlDialect{
+		driverName:  "postgres",
+		placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+	}
+)
+
+// insert returns a statement that inserts all the points with its arguments.
+func (d sqlDialect) insert(table string, points []Point) (string, []any, error) {
+	sb := strings.Builder{}
+	sb.WriteString("INSERT INTO ")
+	sb.WriteString(table)
+	sb.WriteString(" (time, metric, labels, value) VALUES ")
+
+	args := make([]any, 0, len(points)*4)
+	for i, p := range points {
+		labels, err := json.Marshal(p.Labels)
+		if err != nil {
+			return "", nil, err
+		}
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(")
+		for j := 1; j <= 4; j++ {
+			if j > 1 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(d.placeholder(len(args) + j))
+		}
+		sb.WriteString(")")
+		args = append(args, p.Metric.T.UTC(), p.Name, string(labels), p.Metric.V)
+	}
+	return sb.String(), args, nil
+}
+
+func sqlDataSourceDSN(ds *datasources.DataSource, password string) (sqlDialect, string, error) {
+	database := ds.Database
+	if ds.JsonData != nil {
+		database = ds.JsonData.Get("database").MustString(database)
+	}
+
+	switch ds.Type {
+	case datasources.DS_MYSQL:
+		cfg := mysql.NewConfig()
+		cfg.User = ds.User
+		cfg.Passwd = password
+		cfg.Net = "tcp"
+		cfg.Addr = ds.URL
+		// a path is a unix socket
+		if strings.HasPrefix(ds.URL, "/") {
+			cfg.Net = "unix"
+		}
+		cfg.DBName = database
+		return mysqlDialect, cfg.FormatDSN(), nil
+	case datasources.DS_POSTGRES, "postgres":
+		sslMode := "verify-full"
+		if ds.JsonData != nil {
+			sslMode = ds.JsonData.Get("sslmode").MustString(sslMode)
+		}
+		u := &url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(ds.User, password),
+			Host:     ds.URL,
+			Path:     "/" + database,
+			RawQuery: url.Values{"sslmode": []string{sslMode}}.Encode(),
+		}
+		return postgresDialect, u.String(), nil
+	}
+	return sqlDialect{}, "", fmt.Errorf("data source %q of type %q is not a MySQL or PostgreSQL data source", ds.UID, ds.Type)
+}
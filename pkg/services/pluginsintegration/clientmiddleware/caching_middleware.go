@@ -32,6 +32,9 @@ func NewCachingMiddlewareWithFeatureManager(cachingService caching.CachingServic
 	if err := prometheus.Register(QueryCachingRequestHistogram); err != nil {
 		log.Error("Error registering prometheus collector 'QueryRequestHistogram'", "error", err)
 	}
+	if err := prometheus.Register(ShouldCacheQueryHistogram); err != nil {
+		log.Error("Error registering prometheus collector 'ShouldCacheQueryHistogram'", "error", err)
+	}
 	if err := prometheus.Register(ResourceCachingRequestHistogram); err != nil {
 		log.Error("Error registering prometheus collector 'ResourceRequestHistogram'", "error", err)
 	}
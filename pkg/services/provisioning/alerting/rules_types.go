@@ -76,6 +76,7 @@ type AlertRuleV1 struct {
 	IsPaused             values.BoolValue        `json:"isPaused" yaml:"isPaused"`
 	NotificationSettings *NotificationSettingsV1 `json:"notification_settings" yaml:"notification_settings"`
 	Record               *RecordV1               `json:"record" yaml:"record"`
+	Dependencies         []RuleDependencyV1      `json:"dependencies" yaml:"dependencies"`
 }
 
 func (rule *AlertRuleV1) mapToModel(orgID int64) (models.AlertRule, error) {
@@ -147,6 +148,13 @@ func (rule *AlertRuleV1) mapToModel(orgID int64) (models.AlertRule, error) {
 		}
 		alertRule.Record = &record
 	}
+	for _, dependencyV1 := range rule.Dependencies {
+		dependency, err := dependencyV1.mapToModel()
+		if err != nil {
+			return models.AlertRule{}, fmt.Errorf("rule '%s' failed to parse: %w", alertRule.Title, err)
+		}
+		alertRule.Dependencies = append(alertRule.Dependencies, dependency)
+	}
 	return alertRule, nil
 }
 
@@ -258,11 +266,32 @@ func (nsV1 *NotificationSettingsV1) mapToModel() (models.NotificationSettings, e
 type RecordV1 struct {
 	Metric values.StringValue `json:"metric" yaml:"metric"`
 	From   values.StringValue `json:"from" yaml:"from"`
+	Target values.StringValue `json:"target" yaml:"target"`
 }
 
 func (record *RecordV1) mapToModel() (models.Record, error) {
 	return models.Record{
 		Metric: record.Metric.Value(),
 		From:   record.From.Value(),
+		Target: models.RecordTarget(record.Target.Value()),
+	}, nil
+}
+
+type RuleDependencyV1 struct {
+	RuleUID   values.StringValue `json:"rule_uid" yaml:"rule_uid"`
+	Condition values.StringValue `json:"condition" yaml:"condition"`
+}
+
+func (dependency *RuleDependencyV1) mapToModel() (models.RuleDependency, error) {
+	if dependency.RuleUID.Value() == "" {
+		return models.RuleDependency{}, fmt.Errorf("dependency has no rule UID set")
+	}
+	condition := models.DependencyCondition(strings.TrimSpace(dependency.Condition.Value()))
+	if !condition.IsValid() {
+		return models.RuleDependency{}, fmt.Errorf("unknown dependency condition '%s'", condition)
+	}
+	return models.RuleDependency{
+		RuleUID:   dependency.RuleUID.Value(),
+		Condition: condition,
 	}, nil
 }
@@ -199,6 +199,29 @@ func TestRules(t *testing.T) {
 		require.Len(t, ruleMapped.NotificationSettings, 1)
 		require.Equal(t, models.NotificationSettings{Receiver: "test-receiver"}, ruleMapped.NotificationSettings[0])
 	})
+	t.Run("a rule with dependencies should map them correctly", func(t *testing.T) {
+		rule := validRuleV1(t)
+		rule.Dependencies = []RuleDependencyV1{
+			{RuleUID: stringToStringValue("upstream_1")},
+			{RuleUID: stringToStringValue("upstream_2"), Condition: stringToStringValue("not_firing")},
+		}
+		ruleMapped, err := rule.mapToModel(1)
+		require.NoError(t, err)
+		require.Equal(t, []models.RuleDependency{
+			{RuleUID: "upstream_1"},
+			{RuleUID: "upstream_2", Condition: models.DependencyConditionNotFiring},
+		}, ruleMapped.Dependencies)
+	})
+	t.Run("a rule with an invalid dependency should error", func(t *testing.T) {
+		rule := validRuleV1(t)
+		rule.Dependencies = []RuleDependencyV1{{RuleUID: stringToStringValue("upstream"), Condition: stringToStringValue("pending")}}
+		_, err := rule.mapToModel(1)
+		require.ErrorContains(t, err, "unknown dependency condition")
+
+		rule.Dependencies = []RuleDependencyV1{{Condition: stringToStringValue("firing")}}
+		_, err = rule.mapToModel(1)
+		require.ErrorContains(t, err, "no rule UID")
+	})
 }
 
 func TestNotificationsSettingsV1MapToModel(t *testing.T) {
@@ -16,6 +16,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/licensing"
 	"github.com/grafana/grafana/pkg/services/publicdashboards"
+	"github.com/grafana/grafana/pkg/services/publicdashboards/metric"
 	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	"github.com/grafana/grafana/pkg/services/publicdashboards/validation"
 	"github.com/grafana/grafana/pkg/setting"
@@ -31,6 +32,7 @@ type Api struct {
 	features      featuremgmt.FeatureToggles
 	license       licensing.Licensing
 	log           log.Logger
+	metricService *metric.Service
 	routeRegister routing.RouteRegister
 }
 
@@ -42,6 +44,7 @@ func ProvideApi(
 	md publicdashboards.Middleware,
 	cfg *setting.Cfg,
 	license licensing.Licensing,
+	metricService *metric.Service,
 ) *Api {
 	api := &Api{
 		PublicDashboardService: pd,
@@ -51,6 +54,7 @@ func ProvideApi(
 		features:               features,
 		license:                license,
 		log:                    log.New("publicdashboards.api"),
+		metricService:          metricService,
 		routeRegister:          rr,
 	}
 
@@ -49,7 +49,7 @@ func RequiresExistingAccessToken(publicDashboardService publicdashboards.Service
 			c.JsonApiErr(http.StatusBadRequest, "Invalid access token", nil)
 		}
 
-		// Check that the access token references an enabled public dashboard
+		// Check that the access token references an enabled and not expired public dashboard
 		exists, err := publicDashboardService.ExistsEnabledByAccessToken(c.Req.Context(), accessToken)
 		if err != nil {
 			c.JsonApiErr(http.StatusInternalServerError, "Failed to query access token", nil)
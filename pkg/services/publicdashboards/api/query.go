@@ -9,6 +9,7 @@ import (
 	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/api/response"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/publicdashboards/metric"
 	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	"github.com/grafana/grafana/pkg/services/publicdashboards/validation"
 	"github.com/grafana/grafana/pkg/web"
@@ -32,6 +33,7 @@ func (api *Api) ViewPublicDashboard(c *contextmodel.ReqContext) response.Respons
 	}
 
 	dto, err := api.PublicDashboardService.GetPublicDashboardForView(c.Req.Context(), accessToken)
+	api.metricService.RecordAccess(metric.ResourceDashboard, err)
 	if err != nil {
 		return response.Err(err)
 	}
@@ -50,6 +52,7 @@ func (api *Api) ViewPublicDashboard(c *contextmodel.ReqContext) response.Respons
 // 404: panelNotFoundPublicError
 // 404: notFoundPublicError
 // 403: forbiddenPublicError
+// 429: tooManyRequestsPublicError
 // 500: internalServerPublicError
 func (api *Api) QueryPublicDashboard(c *contextmodel.ReqContext) response.Response {
 	accessToken := web.Params(c.Req)[":accessToken"]
@@ -68,6 +71,7 @@ func (api *Api) QueryPublicDashboard(c *contextmodel.ReqContext) response.Respon
 	}
 
 	resp, err := api.PublicDashboardService.GetQueryDataResponse(c.Req.Context(), c.SkipDSCache, reqDTO, panelId, accessToken)
+	api.metricService.RecordAccess(metric.ResourceQuery, err)
 	if err != nil {
 		return response.Err(err)
 	}
@@ -85,6 +89,7 @@ func (api *Api) QueryPublicDashboard(c *contextmodel.ReqContext) response.Respon
 // 404: notFoundPublicError
 // 401: unauthorisedPublicError
 // 403: forbiddenPublicError
+// 429: tooManyRequestsPublicError
 // 500: internalServerPublicError
 func (api *Api) GetPublicAnnotations(c *contextmodel.ReqContext) response.Response {
 	accessToken := web.Params(c.Req)[":accessToken"]
@@ -98,6 +103,7 @@ func (api *Api) GetPublicAnnotations(c *contextmodel.ReqContext) response.Respon
 	}
 
 	annotations, err := api.PublicDashboardService.FindAnnotations(c.Req.Context(), reqDTO, accessToken)
+	api.metricService.RecordAccess(metric.ResourceAnnotations, err)
 	if err != nil {
 		return response.Err(err)
 	}
@@ -244,6 +244,14 @@ func TestAPIQueryPublicDashboard(t *testing.T) {
 		resp := callAPI(server, http.MethodPost, getValidQueryPath(validAccessToken), strings.NewReader("{}"), t)
 		require.Equal(t, http.StatusInternalServerError, resp.Code)
 	})
+
+	t.Run("Status code is 429 when the query rate limit is exceeded", func(t *testing.T) {
+		server, fakeDashboardService := setup(true)
+		fakeDashboardService.On("GetQueryDataResponse", mock.Anything, true, mock.Anything, int64(2), validAccessToken).Return(nil, ErrQueryRateLimitExceeded.Errorf(""))
+
+		resp := callAPI(server, http.MethodPost, getValidQueryPath(validAccessToken), strings.NewReader("{}"), t)
+		require.Equal(t, http.StatusTooManyRequests, resp.Code)
+	})
 }
 
 func getValidQueryPath(accessToken string) string {
@@ -397,6 +405,16 @@ func TestAPIGetAnnotations(t *testing.T) {
 			To:                    "123",
 			ExpectedServiceCalled: true,
 		},
+		{
+			Name:                  "will return 429 when the query rate limit is exceeded",
+			ExpectedHttpResponse:  http.StatusTooManyRequests,
+			Annotations:           nil,
+			ServiceError:          ErrQueryRateLimitExceeded.Errorf(""),
+			AccessToken:           validAccessToken,
+			From:                  "123",
+			To:                    "123",
+			ExpectedServiceCalled: true,
+		},
 		{
 			Name:                  "will return 400 when has an incorrect Access Token",
 			ExpectedHttpResponse:  http.StatusBadRequest,
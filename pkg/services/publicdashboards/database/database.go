@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/infra/log"
@@ -169,13 +170,13 @@ func (d *PublicDashboardStoreImpl) FindByDashboardUid(ctx context.Context, orgId
 	return publicDashboard, nil
 }
 
-// ExistsEnabledByDashboardUid Responds true if there is an enabled public dashboard for a dashboard uid
+// ExistsEnabledByDashboardUid Responds true if there is an enabled and not expired public dashboard for a dashboard uid
 func (d *PublicDashboardStoreImpl) ExistsEnabledByDashboardUid(ctx context.Context, dashboardUid string) (bool, error) {
 	hasPublicDashboard := false
 	err := d.sqlStore.WithDbSession(ctx, func(dbSession *db.Session) error {
-		sql := "SELECT COUNT(*) FROM dashboard_public WHERE dashboard_uid=? AND is_enabled=true"
+		sql := "SELECT COUNT(*) FROM dashboard_public WHERE dashboard_uid=? AND is_enabled=true AND (expires_at IS NULL OR expires_at > ?)"
 
-		result, err := dbSession.SQL(sql, dashboardUid).Count()
+		result, err := dbSession.SQL(sql, dashboardUid, formatTime(time.Now())).Count()
 		if err != nil {
 			return err
 		}
@@ -187,13 +188,13 @@ func (d *PublicDashboardStoreImpl) ExistsEnabledByDashboardUid(ctx context.Conte
 	return hasPublicDashboard, err
 }
 
-// ExistsEnabledByAccessToken Responds true if the accessToken exists and the public dashboard is enabled
+// ExistsEnabledByAccessToken Responds true if the accessToken exists and the public dashboard is enabled and not expired
 func (d *PublicDashboardStoreImpl) ExistsEnabledByAccessToken(ctx context.Context, accessToken string) (bool, error) {
 	hasPublicDashboard := false
 	err := d.sqlStore.WithDbSession(ctx, func(dbSession *db.Session) error {
-		sql := "SELECT COUNT(*) FROM dashboard_public WHERE access_token=? AND is_enabled=true"
+		sql := "SELECT COUNT(*) FROM dashboard_public WHERE access_token=? AND is_enabled=true AND (expires_at IS NULL OR expires_at > ?)"
 
-		result, err := dbSession.SQL(sql, accessToken).Count()
+		result, err := dbSession.SQL(sql, accessToken, formatTime(time.Now())).Count()
 		if err != nil {
 			return err
 		}
@@ -247,14 +248,22 @@ func (d *PublicDashboardStoreImpl) Update(ctx context.Context, cmd SavePublicDas
 			return err
 		}
 
-		sqlResult, err := sess.Exec("UPDATE dashboard_public SET is_enabled = ?, annotations_enabled = ?, time_selection_enabled = ?, share = ?, time_settings = ?, updated_by = ?, updated_at = ? WHERE uid = ?",
+		var expiresAt any
+		if cmd.PublicDashboard.ExpiresAt != nil {
+			expiresAt = formatTime(*cmd.PublicDashboard.ExpiresAt)
+		}
+
+		sqlResult, err := sess.Exec("UPDATE dashboard_public SET is_enabled = ?, annotations_enabled = ?, time_selection_enabled = ?, share = ?, time_settings = ?, expires_at = ?, query_rate_limit = ?, max_time_range_seconds = ?, updated_by = ?, updated_at = ? WHERE uid = ?",
 			cmd.PublicDashboard.IsEnabled,
 			cmd.PublicDashboard.AnnotationsEnabled,
 			cmd.PublicDashboard.TimeSelectionEnabled,
 			cmd.PublicDashboard.Share,
 			string(timeSettingsJSON),
+			expiresAt,
+			cmd.PublicDashboard.QueryRateLimit,
+			cmd.PublicDashboard.MaxTimeRangeSeconds,
 			cmd.PublicDashboard.UpdatedBy,
-			cmd.PublicDashboard.UpdatedAt.UTC().Format("2006-01-02 15:04:05"),
+			formatTime(cmd.PublicDashboard.UpdatedAt),
 			cmd.PublicDashboard.Uid)
 
 		if err != nil {
@@ -296,6 +305,20 @@ func (d *PublicDashboardStoreImpl) FindByFolder(ctx context.Context, orgId int64
 	return pubdashes, nil
 }
 
+// FindExpired Returns the public dashboards that expired before the given time
+func (d *PublicDashboardStoreImpl) FindExpired(ctx context.Context, before time.Time) ([]*PublicDashboard, error) {
+	var pubdashes []*PublicDashboard
+
+	err := d.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.SQL("SELECT * FROM dashboard_public WHERE expires_at IS NOT NULL AND expires_at <= ?", formatTime(before)).Find(&pubdashes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pubdashes, nil
+}
+
 func (d *PublicDashboardStoreImpl) GetMetrics(ctx context.Context) (*Metrics, error) {
 	metrics := &Metrics{
 		TotalPublicDashboards: []*TotalPublicDashboard{},
@@ -309,3 +332,8 @@ func (d *PublicDashboardStoreImpl) GetMetrics(ctx context.Context) (*Metrics, er
 
 	return metrics, nil
 }
+
+// formatTime formats a time the way it is stored in datetime columns
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
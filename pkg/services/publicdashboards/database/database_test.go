@@ -232,6 +232,30 @@ func TestIntegrationExistsEnabledByAccessToken(t *testing.T) {
 		require.False(t, res)
 	})
 
+	t.Run("ExistsEnabledByAccessToken will return false when public dashboard expired", func(t *testing.T) {
+		setup()
+
+		expiresAt := time.Now().Add(-time.Minute)
+		_, err := publicdashboardStore.Create(context.Background(), SavePublicDashboardCommand{
+			PublicDashboard: PublicDashboard{
+				IsEnabled:    true,
+				Uid:          "abc123",
+				DashboardUid: savedDashboard.UID,
+				OrgId:        savedDashboard.OrgID,
+				CreatedAt:    time.Now(),
+				CreatedBy:    7,
+				AccessToken:  "accessToken",
+				ExpiresAt:    &expiresAt,
+			},
+		})
+		require.NoError(t, err)
+
+		res, err := publicdashboardStore.ExistsEnabledByAccessToken(context.Background(), "accessToken")
+		require.NoError(t, err)
+
+		require.False(t, res)
+	})
+
 	t.Run("ExistsEnabledByAccessToken will return false when no public dashboard has matching access token", func(t *testing.T) {
 		setup()
 
@@ -586,6 +610,7 @@ func TestIntegrationUpdatePublicDashboard(t *testing.T) {
 		require.NoError(t, err)
 		assert.EqualValues(t, affectedRows, 1)
 
+		expiresAt := time.Now().Add(time.Hour).UTC().Round(time.Second)
 		updatedPublicDashboard := PublicDashboard{
 			Uid:                  pdUid,
 			DashboardUid:         savedDashboard.UID,
@@ -595,6 +620,9 @@ func TestIntegrationUpdatePublicDashboard(t *testing.T) {
 			TimeSelectionEnabled: true,
 			Share:                EmailShareType,
 			TimeSettings:         &TimeSettings{From: "now-8", To: "now"},
+			ExpiresAt:            &expiresAt,
+			QueryRateLimit:       60,
+			MaxTimeRangeSeconds:  86400,
 			UpdatedAt:            time.Now().UTC().Round(time.Second),
 			UpdatedBy:            8,
 		}
@@ -616,6 +644,10 @@ func TestIntegrationUpdatePublicDashboard(t *testing.T) {
 		assert.Equal(t, updatedPublicDashboard.AnnotationsEnabled, pdRetrieved.AnnotationsEnabled)
 		assert.Equal(t, updatedPublicDashboard.TimeSelectionEnabled, pdRetrieved.TimeSelectionEnabled)
 		assert.Equal(t, updatedPublicDashboard.Share, pdRetrieved.Share)
+		require.NotNil(t, pdRetrieved.ExpiresAt)
+		assert.True(t, expiresAt.Equal(*pdRetrieved.ExpiresAt))
+		assert.Equal(t, updatedPublicDashboard.QueryRateLimit, pdRetrieved.QueryRateLimit)
+		assert.Equal(t, updatedPublicDashboard.MaxTimeRangeSeconds, pdRetrieved.MaxTimeRangeSeconds)
 
 		// not updated dashboard shouldn't have changed
 		pdNotUpdatedRetrieved, err := publicdashboardStore.FindByDashboardUid(context.Background(), anotherSavedDashboard.OrgID, anotherSavedDashboard.UID)
@@ -788,6 +820,43 @@ func TestFindByFolder(t *testing.T) {
 	})
 }
 
+func TestIntegrationFindExpired(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	sqlStore, cfg := db.InitTestReplDBWithCfg(t)
+	dashboardStore, err := dashboardsDB.ProvideDashboardStore(sqlStore, cfg, featuremgmt.WithFeatures(), tagimpl.ProvideService(sqlStore), quotatest.New(false, nil))
+	require.NoError(t, err)
+	pubdashStore := ProvideStore(sqlStore, cfg, featuremgmt.WithFeatures())
+
+	now := time.Now()
+	create := func(uid string, expiresAt *time.Time) {
+		dashboard := insertTestDashboard(t, dashboardStore, "Dashboard "+uid, 1, "", false, PublicShareType)
+		_, err := pubdashStore.Create(context.Background(), SavePublicDashboardCommand{
+			PublicDashboard: PublicDashboard{
+				Uid:          uid,
+				DashboardUid: dashboard.UID,
+				OrgId:        dashboard.OrgID,
+				IsEnabled:    true,
+				CreatedAt:    now,
+				AccessToken:  uid + "token",
+				ExpiresAt:    expiresAt,
+			},
+		})
+		require.NoError(t, err)
+	}
+	expired := now.Add(-time.Hour)
+	notExpired := now.Add(time.Hour)
+	create("expired", &expired)
+	create("notexpired", &notExpired)
+	create("neverexpires", nil)
+
+	pubdashes, err := pubdashStore.FindExpired(context.Background(), now)
+	require.NoError(t, err)
+	require.Len(t, pubdashes, 1)
+	assert.Equal(t, "expired", pubdashes[0].Uid)
+}
+
 func TestGetMetrics(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
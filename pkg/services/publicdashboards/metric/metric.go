@@ -8,6 +8,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/publicdashboards"
+	"github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -35,15 +36,41 @@ func ProvideService(
 }
 
 func (s *Service) registerMetrics(prom prometheus.Registerer) error {
-	err := prom.Register(s.Metrics.PublicDashboardsAmount)
-	var alreadyRegisterErr prometheus.AlreadyRegisteredError
-	if errors.As(err, &alreadyRegisterErr) {
-		if alreadyRegisterErr.ExistingCollector == alreadyRegisterErr.NewCollector {
-			err = nil
+	for _, collector := range []prometheus.Collector{s.Metrics.PublicDashboardsAmount, s.Metrics.PublicDashboardsAccessCount} {
+		err := prom.Register(collector)
+		var alreadyRegisterErr prometheus.AlreadyRegisteredError
+		if errors.As(err, &alreadyRegisterErr) {
+			if alreadyRegisterErr.ExistingCollector == alreadyRegisterErr.NewCollector {
+				err = nil
+			}
 		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecordAccess counts a request to a resource of a public dashboard, labeled by the outcome of the request.
+// It does nothing on a nil service, so the API can be used without metrics.
+func (s *Service) RecordAccess(resource string, err error) {
+	if s == nil {
+		return
+	}
+
+	status := AccessStatusSuccess
+	switch {
+	case err == nil:
+	case errors.Is(err, models.ErrPublicDashboardExpired):
+		status = AccessStatusExpired
+	case errors.Is(err, models.ErrQueryRateLimitExceeded):
+		status = AccessStatusRateLimited
+	default:
+		status = AccessStatusFailure
 	}
 
-	return err
+	s.Metrics.PublicDashboardsAccessCount.WithLabelValues(resource, status).Inc()
 }
 
 func (s *Service) Run(ctx context.Context) error {
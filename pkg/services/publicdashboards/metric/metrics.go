@@ -8,8 +8,21 @@ const (
 	namespace = "grafana"
 )
 
+// Resources and statuses used as labels of the access count metric
+const (
+	ResourceDashboard   = "dashboard"
+	ResourceQuery       = "query"
+	ResourceAnnotations = "annotations"
+
+	AccessStatusSuccess     = "success"
+	AccessStatusExpired     = "expired"
+	AccessStatusRateLimited = "rate_limited"
+	AccessStatusFailure     = "failure"
+)
+
 type Metrics struct {
-	PublicDashboardsAmount *prometheus.GaugeVec
+	PublicDashboardsAmount      *prometheus.GaugeVec
+	PublicDashboardsAccessCount *prometheus.CounterVec
 }
 
 func newMetrics() *Metrics {
@@ -19,5 +32,10 @@ func newMetrics() *Metrics {
 			Name:      "public_dashboards_amount",
 			Help:      "Total amount of public dashboards",
 		}, []string{"is_enabled", "share_type"}),
+		PublicDashboardsAccessCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "public_dashboards_access_total",
+			Help:      "Total amount of requests to public dashboards by resource and status",
+		}, []string{"resource", "status"}),
 	}
 }
@@ -23,6 +23,13 @@ var (
 	ErrDashboardIsPublic                   = errutil.BadRequest("publicdashboards.dashboardIsPublic", errutil.WithPublicMessage("Dashboard is already public"))
 	ErrPublicDashboardUidExists            = errutil.BadRequest("publicdashboards.uidExists", errutil.WithPublicMessage("Dashboard Uid already exists"))
 	ErrPublicDashboardAccessTokenExists    = errutil.BadRequest("publicdashboards.accessTokenExists", errutil.WithPublicMessage("Dashboard Access Token already exists"))
+	ErrInvalidExpiresAt                    = errutil.BadRequest("publicdashboards.invalidExpiresAt", errutil.WithPublicMessage("Expiration time should be in the future"))
+	ErrInvalidQueryRateLimit               = errutil.BadRequest("publicdashboards.invalidQueryRateLimit", errutil.WithPublicMessage("queryRateLimit should be greater than or equal to 0"))
+	ErrInvalidMaxTimeRange                 = errutil.BadRequest("publicdashboards.invalidMaxTimeRange", errutil.WithPublicMessage("maxTimeRangeSeconds should be greater than or equal to 0"))
+	ErrTimeRangeTooLarge                   = errutil.BadRequest("publicdashboards.timeRangeTooLarge", errutil.WithPublicMessage("Time range exceeds the maximum allowed"))
 
 	ErrPublicDashboardNotEnabled = errutil.Forbidden("publicdashboards.notEnabled", errutil.WithPublicMessage("Dashboard paused"))
+	ErrPublicDashboardExpired    = errutil.Forbidden("publicdashboards.expired", errutil.WithPublicMessage("Dashboard expired"))
+
+	ErrQueryRateLimitExceeded = errutil.TooManyRequests("publicdashboards.queryRateLimitExceeded", errutil.WithPublicMessage("Too many queries, try again later"))
 )
@@ -54,6 +54,17 @@ type PublicDashboard struct {
 	AnnotationsEnabled   bool          `json:"annotationsEnabled" xorm:"annotations_enabled"`
 	Share                ShareType     `json:"share" xorm:"share"`
 	Recipients           []EmailDTO    `json:"recipients,omitempty" xorm:"-"`
+	// ExpiresAt is the time after which the public dashboard can no longer be accessed. Never expires when nil.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" xorm:"expires_at"`
+	// QueryRateLimit is the maximum number of panel and annotation queries per minute. Unlimited when 0.
+	QueryRateLimit int64 `json:"queryRateLimit" xorm:"query_rate_limit"`
+	// MaxTimeRangeSeconds is the maximum time range that can be queried. Unlimited when 0.
+	MaxTimeRangeSeconds int64 `json:"maxTimeRangeSeconds" xorm:"max_time_range_seconds"`
+}
+
+// IsExpired returns true if the public dashboard has an expiration time before t
+func (pd PublicDashboard) IsExpired(t time.Time) bool {
+	return pd.ExpiresAt != nil && !pd.ExpiresAt.After(t)
 }
 
 type PublicDashboardDTO struct {
@@ -63,6 +74,10 @@ type PublicDashboardDTO struct {
 	IsEnabled            *bool     `json:"isEnabled"`
 	AnnotationsEnabled   *bool     `json:"annotationsEnabled"`
 	Share                ShareType `json:"share"`
+	// ExpiresAt a zero time removes the expiration time
+	ExpiresAt           *time.Time `json:"expiresAt"`
+	QueryRateLimit      *int64     `json:"queryRateLimit"`
+	MaxTimeRangeSeconds *int64     `json:"maxTimeRangeSeconds"`
 }
 
 type EmailDTO struct {
@@ -32,6 +32,15 @@ func (pd *PublicDashboardServiceImpl) FindAnnotations(ctx context.Context, reqDT
 		return []models.AnnotationEvent{}, nil
 	}
 
+	if !pd.queryLimiter.allow(pub, time.Now()) {
+		pd.log.Warn("Public dashboard query rate limit exceeded", "publicDashboardUid", pub.Uid, "queryRateLimit", pub.QueryRateLimit)
+		return nil, models.ErrQueryRateLimitExceeded.Errorf("FindAnnotations: query rate limit of public dashboard %s exceeded", pub.Uid)
+	}
+
+	if err := validation.ValidateAnnotationsQueryRequest(reqDTO, pub); err != nil {
+		return nil, err
+	}
+
 	annoDto, err := UnmarshalDashboardAnnotations(dash.Data)
 	if err != nil {
 		return nil, models.ErrInternalServerError.Errorf("FindAnnotations: failed to unmarshal dashboard annotations: %w", err)
@@ -130,6 +139,11 @@ func (pd *PublicDashboardServiceImpl) GetQueryDataResponse(ctx context.Context,
 		return nil, err
 	}
 
+	if !pd.queryLimiter.allow(publicDashboard, time.Now()) {
+		pd.log.Warn("Public dashboard query rate limit exceeded", "publicDashboardUid", publicDashboard.Uid, "queryRateLimit", publicDashboard.QueryRateLimit)
+		return nil, models.ErrQueryRateLimitExceeded.Errorf("GetQueryDataResponse: query rate limit of public dashboard %s exceeded", publicDashboard.Uid)
+	}
+
 	metricReq, err := pd.GetMetricRequest(ctx, dashboard, publicDashboard, panelId, queryDto)
 	if err != nil {
 		return nil, err
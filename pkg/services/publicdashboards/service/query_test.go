@@ -726,6 +726,40 @@ func TestGetQueryDataResponse(t *testing.T) {
 		resp, _ := service.GetQueryDataResponse(context.Background(), true, publicDashboardQueryDTO, 1, pubdashDto.AccessToken)
 		require.NotNil(t, resp)
 	})
+
+	t.Run("Returns error when the query rate limit is exceeded", func(t *testing.T) {
+		customPanels := []interface{}{
+			map[string]interface{}{
+				"id": 1,
+				"datasource": map[string]interface{}{
+					"uid": "ds1",
+				},
+				"targets": []interface{}{map[string]interface{}{"refId": "A"}},
+			}}
+
+		dashboard := insertTestDashboard(t, dashboardStore, "testDashWithRateLimit", 1, 0, "", true, []map[string]interface{}{}, customPanels)
+		fakeDashboardService.On("GetDashboard", mock.Anything, mock.Anything, mock.Anything).Return(dashboard, nil)
+
+		isEnabled := true
+		queryRateLimit := int64(1)
+		dto := &SavePublicDashboardDTO{
+			DashboardUid: dashboard.UID,
+			UserId:       7,
+			OrgID:        dashboard.OrgID,
+			PublicDashboard: &PublicDashboardDTO{
+				IsEnabled:      &isEnabled,
+				QueryRateLimit: &queryRateLimit,
+			},
+		}
+		pubdashDto, err := service.Create(context.Background(), SignedInUser, dto)
+		require.NoError(t, err)
+
+		_, err = service.GetQueryDataResponse(context.Background(), true, publicDashboardQueryDTO, 1, pubdashDto.AccessToken)
+		require.NoError(t, err)
+
+		_, err = service.GetQueryDataResponse(context.Background(), true, publicDashboardQueryDTO, 1, pubdashDto.AccessToken)
+		require.ErrorIs(t, err, ErrQueryRateLimitExceeded)
+	})
 }
 
 func TestFindAnnotations(t *testing.T) {
@@ -995,6 +1029,23 @@ func TestFindAnnotations(t *testing.T) {
 		assert.Empty(t, items)
 	})
 
+	t.Run("test will error when the query rate limit is exceeded", func(t *testing.T) {
+		dashboard := dashboards.NewDashboard("test")
+		pubdash := &PublicDashboard{Uid: "uid1", IsEnabled: true, OrgId: 1, DashboardUid: dashboard.UID, AnnotationsEnabled: true, QueryRateLimit: 1}
+		fakeStore := &FakePublicDashboardStore{}
+		fakeStore.On("FindByAccessToken", mock.Anything, mock.AnythingOfType("string")).Return(pubdash, nil)
+		fakeDashboardService := &dashboards.FakeDashboardService{}
+		fakeDashboardService.On("GetDashboard", mock.Anything, mock.Anything, mock.Anything).Return(dashboard, nil)
+		service, _, _ := newPublicDashboardServiceImpl(t, fakeStore, fakeDashboardService, nil)
+
+		_, err := service.FindAnnotations(context.Background(), AnnotationsQueryDTO{}, "abc123")
+		require.NoError(t, err)
+
+		items, err := service.FindAnnotations(context.Background(), AnnotationsQueryDTO{}, "abc123")
+		require.ErrorIs(t, err, ErrQueryRateLimitExceeded)
+		require.Nil(t, items)
+	})
+
 	t.Run("test will error when annotations repo returns an error", func(t *testing.T) {
 		grafanaAnnotation := DashAnnotation{
 			Datasource: CreateDatasource("grafana", "grafana"),
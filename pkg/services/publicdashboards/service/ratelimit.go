@@ -0,0 +1,57 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
+)
+
+// queryRateLimiter limits the panel and annotation queries of each public dashboard to its QueryRateLimit per minute.
+// Limits are kept in memory, so they apply per Grafana instance. The zero value is ready to use.
+type queryRateLimiter struct {
+	mu       sync.Mutex
+	limiters map[string]*queryLimiter
+}
+
+type queryLimiter struct {
+	limit   int64
+	limiter *rate.Limiter
+}
+
+// allow reports whether a query of the public dashboard may run now
+func (l *queryRateLimiter) allow(pd *PublicDashboard, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if pd.QueryRateLimit <= 0 {
+		delete(l.limiters, pd.Uid)
+		return true
+	}
+
+	if l.limiters == nil {
+		l.limiters = make(map[string]*queryLimiter)
+	}
+
+	// the limiter is recreated when the limit of the public dashboard changed
+	ql, ok := l.limiters[pd.Uid]
+	if !ok || ql.limit != pd.QueryRateLimit {
+		ql = &queryLimiter{
+			limit:   pd.QueryRateLimit,
+			limiter: rate.NewLimiter(rate.Limit(float64(pd.QueryRateLimit)/time.Minute.Seconds()), int(pd.QueryRateLimit)),
+		}
+		l.limiters[pd.Uid] = ql
+	}
+
+	return ql.limiter.AllowN(now, 1)
+}
+
+// remove drops the limiter of a deleted public dashboard
+func (l *queryRateLimiter) remove(uid string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.limiters, uid)
+}
@@ -42,6 +42,7 @@ type PublicDashboardServiceImpl struct {
 	serviceWrapper     publicdashboards.ServiceWrapper
 	dashboardService   dashboards.DashboardService
 	license            licensing.Licensing
+	queryLimiter       queryRateLimiter
 }
 
 var LogPrefix = "publicdashboards.service"
@@ -162,6 +163,10 @@ func (pd *PublicDashboardServiceImpl) FindEnabledPublicDashboardAndDashboardByAc
 		return nil, nil, ErrPublicDashboardNotEnabled.Errorf("FindEnabledPublicDashboardAndDashboardByAccessToken: Public dashboard is not enabled accessToken: %s", accessToken)
 	}
 
+	if pubdash.IsExpired(time.Now()) {
+		return nil, nil, ErrPublicDashboardExpired.Errorf("FindEnabledPublicDashboardAndDashboardByAccessToken: Public dashboard expired accessToken: %s", accessToken)
+	}
+
 	if !pd.license.FeatureEnabled(FeaturePublicDashboardsEmailSharing) && pubdash.Share == EmailShareType {
 		return nil, nil, ErrPublicDashboardNotFound.Errorf("FindEnabledPublicDashboardAndDashboardByAccessToken: Dashboard not found accessToken: %s", accessToken)
 	}
@@ -405,6 +410,26 @@ func (pd *PublicDashboardServiceImpl) DeleteByDashboard(ctx context.Context, das
 	return pd.serviceWrapper.Delete(ctx, pubdash.Uid)
 }
 
+// DeleteExpired deletes the public dashboards whose expiration time has passed and returns how many were deleted
+func (pd *PublicDashboardServiceImpl) DeleteExpired(ctx context.Context) (int64, error) {
+	pubdashes, err := pd.store.FindExpired(ctx, time.Now())
+	if err != nil {
+		return 0, ErrInternalServerError.Errorf("DeleteExpired: failed to find expired public dashboards: %w", err)
+	}
+
+	var deleted int64
+	for _, pubdash := range pubdashes {
+		if err := pd.serviceWrapper.Delete(ctx, pubdash.Uid); err != nil {
+			return deleted, err
+		}
+		pd.queryLimiter.remove(pubdash.Uid)
+		deleted++
+		pd.log.Info("Expired public dashboard deleted", "publicDashboardUid", pubdash.Uid, "dashboardUid", pubdash.DashboardUid)
+	}
+
+	return deleted, nil
+}
+
 // intervalMS and maxQueryData values are being calculated on the frontend for regular dashboards
 // we are doing the same for public dashboards but because this access would be public, we need a way to keep this
 // values inside reasonable bounds to avoid an attack that could hit data sources with a small interval and a big
@@ -514,6 +539,9 @@ func (pd *PublicDashboardServiceImpl) newCreatePublicDashboard(ctx context.Conte
 		TimeSelectionEnabled: timeSelectionEnabled,
 		TimeSettings:         &TimeSettings{},
 		Share:                share,
+		ExpiresAt:            returnExpiresAtOrDefault(dto.PublicDashboard.ExpiresAt, nil),
+		QueryRateLimit:       returnValueOrDefault(dto.PublicDashboard.QueryRateLimit, 0),
+		MaxTimeRangeSeconds:  returnValueOrDefault(dto.PublicDashboard.MaxTimeRangeSeconds, 0),
 		CreatedBy:            dto.UserId,
 		CreatedAt:            now,
 		UpdatedBy:            dto.UserId,
@@ -540,15 +568,30 @@ func newUpdatePublicDashboard(dto *SavePublicDashboardDTO, pd *PublicDashboard)
 		TimeSelectionEnabled: timeSelectionEnabled,
 		TimeSettings:         pd.TimeSettings,
 		Share:                share,
+		ExpiresAt:            returnExpiresAtOrDefault(pubdashDTO.ExpiresAt, pd.ExpiresAt),
+		QueryRateLimit:       returnValueOrDefault(pubdashDTO.QueryRateLimit, pd.QueryRateLimit),
+		MaxTimeRangeSeconds:  returnValueOrDefault(pubdashDTO.MaxTimeRangeSeconds, pd.MaxTimeRangeSeconds),
 		UpdatedBy:            dto.UserId,
 		UpdatedAt:            time.Now(),
 	}
 }
 
-func returnValueOrDefault(value *bool, defaultValue bool) bool {
+func returnValueOrDefault[T any](value *T, defaultValue T) T {
 	if value != nil {
 		return *value
 	}
 
 	return defaultValue
 }
+
+// returnExpiresAtOrDefault returns the default when no expiration time is given, and no expiration time when it is zero
+func returnExpiresAtOrDefault(value *time.Time, defaultValue *time.Time) *time.Time {
+	if value == nil {
+		return defaultValue
+	}
+	if value.IsZero() {
+		return nil
+	}
+
+	return value
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/apimachinery/errutil"
 	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
 	"github.com/grafana/grafana/pkg/services/authz/zanzana"
 	"github.com/grafana/grafana/pkg/services/dashboards"
@@ -551,6 +552,17 @@ func TestGetEnabledPublicDashboard(t *testing.T) {
 			ErrResp:  ErrPublicDashboardNotFound,
 			DashResp: nil,
 		},
+		{
+			Name:        "returns ErrPublicDashboardExpired when expiration time has passed",
+			AccessToken: "abc123",
+			StoreResp: &storeResp{
+				pd:  &PublicDashboard{AccessToken: "abcdToken", IsEnabled: true, ExpiresAt: util.Pointer(time.Now().Add(-time.Minute))},
+				d:   &dashboards.Dashboard{UID: "mydashboard"},
+				err: nil,
+			},
+			ErrResp:  ErrPublicDashboardExpired,
+			DashResp: nil,
+		},
 	}
 
 	for _, test := range testCases {
@@ -1666,6 +1678,54 @@ func TestDeleteByDashboard(t *testing.T) {
 	})
 }
 
+func TestDeleteExpired(t *testing.T) {
+	t.Run("will delete expired pubdashes", func(t *testing.T) {
+		store := NewFakePublicDashboardStore(t)
+		pd := &PublicDashboardServiceImpl{store: store, serviceWrapper: ProvideServiceWrapper(store), log: log.New(LogPrefix)}
+		pubdash1 := &PublicDashboard{Uid: "1", OrgId: 1, DashboardUid: "dash1"}
+		pubdash2 := &PublicDashboard{Uid: "2", OrgId: 1, DashboardUid: "dash2"}
+		store.On("FindExpired", mock.Anything, mock.Anything).Return([]*PublicDashboard{pubdash1, pubdash2}, nil)
+		store.On("Delete", mock.Anything, "1").Return(int64(1), nil)
+		store.On("Delete", mock.Anything, "2").Return(int64(1), nil)
+
+		deleted, err := pd.DeleteExpired(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+	})
+
+	t.Run("will return error when finding expired pubdashes fails", func(t *testing.T) {
+		store := NewFakePublicDashboardStore(t)
+		pd := &PublicDashboardServiceImpl{store: store, serviceWrapper: ProvideServiceWrapper(store), log: log.New(LogPrefix)}
+		store.On("FindExpired", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+
+		_, err := pd.DeleteExpired(context.Background())
+		assert.ErrorIs(t, err, ErrInternalServerError)
+	})
+}
+
+func TestNewUpdatePublicDashboardLimits(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	existing := &PublicDashboard{Uid: "1", ExpiresAt: &expiresAt, QueryRateLimit: 60, MaxTimeRangeSeconds: 3600}
+
+	t.Run("keeps persisted values when not given", func(t *testing.T) {
+		updated := newUpdatePublicDashboard(&SavePublicDashboardDTO{PublicDashboard: &PublicDashboardDTO{}}, existing)
+		assert.Equal(t, &expiresAt, updated.ExpiresAt)
+		assert.Equal(t, int64(60), updated.QueryRateLimit)
+		assert.Equal(t, int64(3600), updated.MaxTimeRangeSeconds)
+	})
+
+	t.Run("updates and clears values", func(t *testing.T) {
+		updated := newUpdatePublicDashboard(&SavePublicDashboardDTO{PublicDashboard: &PublicDashboardDTO{
+			ExpiresAt:           &time.Time{},
+			QueryRateLimit:      util.Pointer(int64(0)),
+			MaxTimeRangeSeconds: util.Pointer(int64(60)),
+		}}, existing)
+		assert.Nil(t, updated.ExpiresAt)
+		assert.Equal(t, int64(0), updated.QueryRateLimit)
+		assert.Equal(t, int64(60), updated.MaxTimeRangeSeconds)
+	})
+}
+
 func TestGenerateAccessToken(t *testing.T) {
 	accessToken, err := GenerateAccessToken()
 
@@ -1,6 +1,8 @@
 package validation
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
 	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
@@ -13,6 +15,19 @@ func ValidatePublicDashboard(dto *SavePublicDashboardDTO) error {
 		return ErrInvalidShareType.Errorf("ValidateSavePublicDashboard: invalid share type")
 	}
 
+	// a zero expiration time removes the expiration, so only other values must be in the future
+	if dto.PublicDashboard.ExpiresAt != nil && !dto.PublicDashboard.ExpiresAt.IsZero() && !dto.PublicDashboard.ExpiresAt.After(time.Now()) {
+		return ErrInvalidExpiresAt.Errorf("ValidateSavePublicDashboard: expiration time is in the past")
+	}
+
+	if dto.PublicDashboard.QueryRateLimit != nil && *dto.PublicDashboard.QueryRateLimit < 0 {
+		return ErrInvalidQueryRateLimit.Errorf("ValidateSavePublicDashboard: query rate limit is negative")
+	}
+
+	if dto.PublicDashboard.MaxTimeRangeSeconds != nil && *dto.PublicDashboard.MaxTimeRangeSeconds < 0 {
+		return ErrInvalidMaxTimeRange.Errorf("ValidateSavePublicDashboard: max time range is negative")
+	}
+
 	return nil
 }
 
@@ -28,19 +43,36 @@ func ValidateQueryPublicDashboardRequest(req PublicDashboardQueryDTO, pd *Public
 	if pd.TimeSelectionEnabled {
 		timeRange := gtime.NewTimeRange(req.TimeRange.From, req.TimeRange.To)
 
-		_, err := timeRange.ParseFrom()
+		from, err := timeRange.ParseFrom()
 		if err != nil {
 			return ErrInvalidTimeRange.Errorf("ValidateQueryPublicDashboardRequest: time range from is invalid")
 		}
-		_, err = timeRange.ParseTo()
+		to, err := timeRange.ParseTo()
 		if err != nil {
 			return ErrInvalidTimeRange.Errorf("ValidateQueryPublicDashboardRequest: time range to is invalid")
 		}
+
+		if exceedsMaxTimeRange(pd, to.Sub(from)) {
+			return ErrTimeRangeTooLarge.Errorf("ValidateQueryPublicDashboardRequest: time range exceeds %d seconds", pd.MaxTimeRangeSeconds)
+		}
+	}
+
+	return nil
+}
+
+func ValidateAnnotationsQueryRequest(req AnnotationsQueryDTO, pd *PublicDashboard) error {
+	if exceedsMaxTimeRange(pd, time.Duration(req.To-req.From)*time.Millisecond) {
+		return ErrTimeRangeTooLarge.Errorf("ValidateAnnotationsQueryRequest: time range exceeds %d seconds", pd.MaxTimeRangeSeconds)
 	}
 
 	return nil
 }
 
+// exceedsMaxTimeRange checks the duration against the max time range of the public dashboard, if it has one
+func exceedsMaxTimeRange(pd *PublicDashboard, d time.Duration) bool {
+	return pd.MaxTimeRangeSeconds > 0 && d > time.Duration(pd.MaxTimeRangeSeconds)*time.Second
+}
+
 // IsValidAccessToken asserts that an accessToken is a valid uuid
 func IsValidAccessToken(token string) bool {
 	_, err := uuid.Parse(token)
@@ -2,6 +2,7 @@ package validation
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	"github.com/stretchr/testify/assert"
@@ -22,6 +23,30 @@ func TestValidatePublicDashboard(t *testing.T) {
 		err := ValidatePublicDashboard(dto)
 		require.Error(t, err)
 	})
+
+	t.Run("Returns error when expiration time is in the past", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+		dto := &SavePublicDashboardDTO{DashboardUid: "abc123", UserId: 1, PublicDashboard: &PublicDashboardDTO{ExpiresAt: &expiresAt}}
+
+		err := ValidatePublicDashboard(dto)
+		require.ErrorIs(t, err, ErrInvalidExpiresAt)
+	})
+
+	t.Run("Returns no error when expiration time is zero", func(t *testing.T) {
+		dto := &SavePublicDashboardDTO{DashboardUid: "abc123", UserId: 1, PublicDashboard: &PublicDashboardDTO{ExpiresAt: &time.Time{}}}
+
+		err := ValidatePublicDashboard(dto)
+		require.NoError(t, err)
+	})
+
+	t.Run("Returns error when limits are negative", func(t *testing.T) {
+		negative := int64(-1)
+		dto := &SavePublicDashboardDTO{DashboardUid: "abc123", UserId: 1, PublicDashboard: &PublicDashboardDTO{QueryRateLimit: &negative}}
+		require.ErrorIs(t, ValidatePublicDashboard(dto), ErrInvalidQueryRateLimit)
+
+		dto = &SavePublicDashboardDTO{DashboardUid: "abc123", UserId: 1, PublicDashboard: &PublicDashboardDTO{MaxTimeRangeSeconds: &negative}}
+		require.ErrorIs(t, ValidatePublicDashboard(dto), ErrInvalidMaxTimeRange)
+	})
 }
 
 func TestValidateQueryPublicDashboardRequest(t *testing.T) {
@@ -129,6 +154,38 @@ func TestValidateQueryPublicDashboardRequest(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Returns no error when time range is within max time range",
+			args: args{
+				req: PublicDashboardQueryDTO{
+					TimeRange: TimeRangeDTO{
+						From: "now-1h",
+						To:   "now",
+					},
+				},
+				pd: &PublicDashboard{
+					TimeSelectionEnabled: true,
+					MaxTimeRangeSeconds:  3600,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Returns validation error when time range exceeds max time range",
+			args: args{
+				req: PublicDashboardQueryDTO{
+					TimeRange: TimeRangeDTO{
+						From: "now-7d",
+						To:   "now",
+					},
+				},
+				pd: &PublicDashboard{
+					TimeSelectionEnabled: true,
+					MaxTimeRangeSeconds:  3600,
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -139,6 +196,14 @@ func TestValidateQueryPublicDashboardRequest(t *testing.T) {
 	}
 }
 
+func TestValidateAnnotationsQueryRequest(t *testing.T) {
+	pd := &PublicDashboard{MaxTimeRangeSeconds: 3600}
+
+	require.NoError(t, ValidateAnnotationsQueryRequest(AnnotationsQueryDTO{From: 0, To: 3600000}, pd))
+	require.ErrorIs(t, ValidateAnnotationsQueryRequest(AnnotationsQueryDTO{From: 0, To: 3600001}, pd), ErrTimeRangeTooLarge)
+	require.NoError(t, ValidateAnnotationsQueryRequest(AnnotationsQueryDTO{From: 0, To: 3600001}, &PublicDashboard{}))
+}
+
 func TestValidAccessToken(t *testing.T) {
 	t.Run("true", func(t *testing.T) {
 		uuid := "da82510c2aa64d78a2e87fef36c58e89"
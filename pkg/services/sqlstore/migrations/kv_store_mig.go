@@ -32,3 +32,16 @@ func addKVStoreMySQLValueTypeLongTextMigration(mg *Migrator) {
 	mg.AddMigration("alter kv_store.value to longtext", NewRawSQLMigration("").
 		Mysql("ALTER TABLE kv_store MODIFY value LONGTEXT NOT NULL;"))
 }
+
+// addKVStoreVersionExpiresMigration adds the version used for compare-and-swap and the expiry time of the kv_store items.
+// Existing items start at version 1, as version 0 is used for items that do not exist.
+func addKVStoreVersionExpiresMigration(mg *Migrator) {
+	kvStore := Table{Name: "kv_store"}
+
+	mg.AddMigration("add version column to kv_store", NewAddColumnMigration(kvStore, &Column{
+		Name: "version", Type: DB_BigInt, Nullable: false, Default: "1",
+	}))
+	mg.AddMigration("add expires column to kv_store", NewAddColumnMigration(kvStore, &Column{
+		Name: "expires", Type: DB_BigInt, Nullable: true,
+	}))
+}
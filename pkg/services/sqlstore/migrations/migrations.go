@@ -125,6 +125,14 @@ func (oss *OSSMigrations) AddMigration(mg *Migrator) {
 	ualert.AddRecordingRuleColumns(mg)
 
 	ualert.AddStateResolvedAtColumns(mg)
+
+	addKVStoreVersionExpiresMigration(mg)
+
+	ualert.AddRuleDependenciesColumns(mg)
+
+	ualert.AddStateHistoryTable(mg)
+
+	addLivePipelineMigrations(mg)
 }
 
 func addStarMigrations(mg *Migrator) {
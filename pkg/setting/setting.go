@@ -423,6 +423,11 @@ type Cfg struct {
 	// LiveAllowedOrigins is a set of origins accepted by Live. If not provided
 	// then Live uses AppURL as the only allowed origin.
 	LiveAllowedOrigins []string
+	// LivePipelineEnabled enables Live pipeline channel rules and their HTTP API.
+	LivePipelineEnabled bool
+	// LivePipelineStorage is a storage of Live pipeline channel rules and write
+	// configs, "file" (default) or "database" to share them between instances.
+	LivePipelineStorage string
 
 	// Grafana.com URL, used for OAuth redirect.
 	GrafanaComURL string
@@ -448,6 +453,10 @@ type Cfg struct {
 
 	Search SearchSettings
 
+	Caching CachingSettings
+
+	ServerLock ServerLockSettings
+
 	SecureSocksDSProxy SecureSocksDSProxySettings
 
 	// SAML Auth
@@ -1239,6 +1248,8 @@ func (cfg *Cfg) parseINIFile(iniFile *ini.File) error {
 
 	cfg.Storage = readStorageSettings(iniFile)
 	cfg.Search = readSearchSettings(iniFile)
+	cfg.Caching = readCachingSettings(iniFile)
+	cfg.ServerLock = readServerLockSettings(iniFile)
 
 	var err error
 	cfg.SecureSocksDSProxy, err = readSecureSocksDSProxySettings(iniFile)
@@ -2001,6 +2012,14 @@ func (cfg *Cfg) readLiveSettings(iniFile *ini.File) error {
 	cfg.LiveHAEngineAddress = section.Key("ha_engine_address").MustString("127.0.0.1:6379")
 	cfg.LiveHAEnginePassword = section.Key("ha_engine_password").MustString("")
 
+	cfg.LivePipelineEnabled = section.Key("pipeline_enabled").MustBool(false)
+	cfg.LivePipelineStorage = section.Key("pipeline_storage").MustString("file")
+	switch cfg.LivePipelineStorage {
+	case "file", "database":
+	default:
+		return fmt.Errorf("unsupported live pipeline storage type: %s", cfg.LivePipelineStorage)
+	}
+
 	allowedOrigins := section.Key("allowed_origins").MustString("")
 	origins := strings.Split(allowedOrigins, ",")
 
@@ -0,0 +1,30 @@
+package setting
+
+import (
+	"time"
+
+	"gopkg.in/ini.v1"
+)
+
+type CachingSettings struct {
+	// Enabled turns on query and resource caching for data sources
+	Enabled bool
+	// TTL is the default time to live of cached query results. Data sources can override it
+	// with the `queryCachingTTL` (milliseconds) option in their JSON data.
+	TTL time.Duration
+	// ResourcesTTL is the time to live of cached resource responses.
+	ResourcesTTL time.Duration
+	// MaxValueSize is the maximum size in bytes of a single cached response.
+	MaxValueSize int
+}
+
+func readCachingSettings(iniFile *ini.File) CachingSettings {
+	s := CachingSettings{}
+
+	cachingSection := iniFile.Section("caching")
+	s.Enabled = cachingSection.Key("enabled").MustBool(false)
+	s.TTL = cachingSection.Key("ttl").MustDuration(5 * time.Minute)
+	s.ResourcesTTL = cachingSection.Key("resources_ttl").MustDuration(5 * time.Minute)
+	s.MaxValueSize = cachingSection.Key("max_value_mb").MustInt(10) * 1024 * 1024
+	return s
+}
@@ -0,0 +1,29 @@
+package setting
+
+import (
+	"gopkg.in/ini.v1"
+)
+
+const (
+	ServerLockBackendDatabase = "database"
+	ServerLockBackendRedis    = "redis"
+)
+
+type ServerLockSettings struct {
+	// Backend is where the locks of the server lock service are stored, "database" or "redis".
+	Backend string
+	// RedisConnStr is the connection string of the redis server, in the same format as the remote cache one.
+	RedisConnStr string
+	// RedisPrefix is prepended to the redis keys of the locks.
+	RedisPrefix string
+}
+
+func readServerLockSettings(iniFile *ini.File) ServerLockSettings {
+	s := ServerLockSettings{}
+
+	section := iniFile.Section("server_lock")
+	s.Backend = valueAsString(section, "backend", ServerLockBackendDatabase)
+	s.RedisConnStr = valueAsString(section, "redis_connstr", "")
+	s.RedisPrefix = valueAsString(section, "redis_prefix", "serverlock:")
+	return s
+}
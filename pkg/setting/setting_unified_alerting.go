@@ -64,6 +64,7 @@ const (
 	stateHistoryDefaultEnabled     = true
 	lokiDefaultMaxQueryLength      = 721 * time.Hour // 30d1h, matches the default value in Loki
 	defaultRecordingRequestTimeout = 10 * time.Second
+	defaultRecordingSQLTable       = "grafana_recorded_metrics"
 	lokiDefaultMaxQuerySize        = 65536 // 64kb
 )
 
@@ -129,6 +130,24 @@ type RecordingRuleSettings struct {
 	BasicAuthPassword string
 	CustomHeaders     map[string]string
 	Timeout           time.Duration
+
+	// InfluxDB is the target of the recording rules with an influxdb target.
+	InfluxDB RecordingRuleInfluxDBSettings
+	// SQL is the target of the recording rules with a sql target.
+	SQL RecordingRuleSQLSettings
+}
+
+type RecordingRuleInfluxDBSettings struct {
+	// URL including the write path and the database, or org and bucket, query parameters.
+	URL               string
+	Token             string
+	BasicAuthUsername string
+	BasicAuthPassword string
+}
+
+type RecordingRuleSQLSettings struct {
+	DatasourceUID string
+	Table         string
 }
 
 // RemoteAlertmanagerSettings contains the configuration needed
@@ -168,6 +187,8 @@ type UnifiedAlertingStateHistorySettings struct {
 	MultiPrimary          string
 	MultiSecondaries      []string
 	ExternalLabels        map[string]string
+	// SQLMaxAge is how long the state transitions written by the "sql" backend are kept. Zero keeps them forever.
+	SQLMaxAge time.Duration
 }
 
 // IsEnabled returns true if UnifiedAlertingSettings.Enabled is either nil or true.
@@ -417,6 +438,10 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 		MultiSecondaries:      splitTrim(stateHistory.Key("secondaries").MustString(""), ","),
 		ExternalLabels:        stateHistoryLabels.KeysHash(),
 	}
+	uaCfgStateHistory.SQLMaxAge, err = gtime.ParseDuration(valueAsString(stateHistory, "sql_max_age", "0"))
+	if err != nil {
+		return err
+	}
 	uaCfg.StateHistory = uaCfgStateHistory
 
 	rr := iniFile.Section("recording_rules")
@@ -434,6 +459,20 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 		uaCfgRecordingRules.CustomHeaders[key.Name()] = key.Value()
 	}
 
+	rrInflux := iniFile.Section("recording_rules.influxdb")
+	uaCfgRecordingRules.InfluxDB = RecordingRuleInfluxDBSettings{
+		URL:               rrInflux.Key("url").MustString(""),
+		Token:             rrInflux.Key("token").MustString(""),
+		BasicAuthUsername: rrInflux.Key("basic_auth_username").MustString(""),
+		BasicAuthPassword: rrInflux.Key("basic_auth_password").MustString(""),
+	}
+
+	rrSQL := iniFile.Section("recording_rules.sql")
+	uaCfgRecordingRules.SQL = RecordingRuleSQLSettings{
+		DatasourceUID: rrSQL.Key("datasource_uid").MustString(""),
+		Table:         rrSQL.Key("table").MustString(defaultRecordingSQLTable),
+	}
+
 	uaCfg.RecordingRules = uaCfgRecordingRules
 
 	uaCfg.MaxStateSaveConcurrency = ua.Key("max_state_save_concurrency").MustInt(1)
@@ -0,0 +1,84 @@
+package resource
+
+import (
+	context "context"
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/apimachinery/utils"
+)
+
+// BatchBackend can be implemented by a StorageBackend to support the BatchWrite and Export requests.
+type BatchBackend interface {
+	// WriteEvents writes the events in order, either all of them or none.
+	// Returns the resource version of the last event
+	WriteEvents(context.Context, []WriteEvent) (int64, error)
+
+	// Export calls the callback for every value in the requested namespace, ordered by key then
+	// resource version. Only the latest values are returned, unless the history is requested
+	Export(context.Context, *ExportRequest, func(*ExportResponse) error) error
+}
+
+// batchEvent validates a value sent to BatchWrite, with the same checks as a single write.
+func (s *server) batchEvent(ctx context.Context, req *BatchWriteRequest) (*WriteEvent, error) {
+	if req.Key == nil || req.Key.Group == "" || req.Key.Resource == "" || req.Key.Namespace == "" || req.Key.Name == "" {
+		return nil, apierrors.NewBadRequest("batch values require a full key")
+	}
+	if len(req.Value) == 0 {
+		return nil, apierrors.NewBadRequest("missing value")
+	}
+
+	switch req.Action {
+	case WatchEvent_ADDED, WatchEvent_MODIFIED:
+		event, err := s.newEvent(ctx, req.Key, req.Value, nil)
+		if err != nil {
+			return nil, err
+		}
+		event.Type = req.Action
+		return event, nil
+	case WatchEvent_DELETED:
+		return s.batchDeletedEvent(ctx, req.Key, req.Value)
+	}
+	return nil, apierrors.NewBadRequest(fmt.Sprintf("unsupported batch action %s", req.Action))
+}
+
+// batchDeletedEvent validates a deletion marker sent to BatchWrite. The marker is not a resource of the key,
+// so only its metadata is checked against the key, along with the same access checks as a single write.
+func (s *server) batchDeletedEvent(ctx context.Context, key *ResourceKey, value []byte) (*WriteEvent, error) {
+	user, err := identity.GetRequester(ctx)
+	if err != nil {
+		return nil, ErrUserNotFoundInContext
+	}
+	marker := &DeletedMarker{}
+	err = json.Unmarshal(value, marker)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(
+			fmt.Sprintf("unable to read deletion marker, %v", err))
+	}
+	obj, err := utils.MetaAccessor(marker)
+	if err != nil {
+		return nil, err
+	}
+	if obj.GetDeletionTimestamp() == nil {
+		return nil, apierrors.NewBadRequest("deletion marker must have a deletion timestamp")
+	}
+	if key.Namespace != obj.GetNamespace() {
+		return nil, apierrors.NewBadRequest("key/namespace do not match")
+	}
+	if key.Name != obj.GetName() {
+		return nil, apierrors.NewBadRequest(
+			fmt.Sprintf("key/name do not match (key: %s, name: %s)", key.Name, obj.GetName()))
+	}
+	err = s.canWrite(ctx, user, obj)
+	if err != nil {
+		return nil, err
+	}
+	return &WriteEvent{
+		Type:  WatchEvent_DELETED,
+		Key:   key,
+		Value: value,
+	}, nil
+}
@@ -0,0 +1,202 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob/memblob"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+)
+
+func TestBatchExportAndWrite(t *testing.T) {
+	testUserA := &identity.StaticRequester{
+		Type:           identity.TypeUser,
+		Login:          "testuser",
+		UserID:         123,
+		UserUID:        "u123",
+		OrgRole:        identity.RoleAdmin,
+		IsGrafanaAdmin: true, // can do anything
+	}
+	ctx := identity.WithRequester(context.Background(), testUserA)
+
+	newClient := func(t *testing.T) ResourceStoreClient {
+		store, err := NewCDKBackend(ctx, CDKBackendOptions{
+			Bucket: memblob.OpenBucket(nil),
+		})
+		require.NoError(t, err)
+		server, err := NewResourceServer(ResourceServerOptions{
+			Backend: store,
+		})
+		require.NoError(t, err)
+		return NewLocalResourceStoreClient(server)
+	}
+	value := func(name, namespace, title string) []byte {
+		return []byte(fmt.Sprintf(`{
+			"apiVersion": "playlist.grafana.app/v0alpha1",
+			"kind": "Playlist",
+			"metadata": {
+				"name": %q,
+				"namespace": %q
+			},
+			"spec": {
+				"title": %q
+			}
+		}`, name, namespace, title))
+	}
+	key := func(name, namespace string) *ResourceKey {
+		return &ResourceKey{
+			Group:     "playlist.grafana.app",
+			Resource:  "playlists",
+			Namespace: namespace,
+			Name:      name,
+		}
+	}
+	export := func(t *testing.T, client ResourceStoreClient, req *ExportRequest) []*ExportResponse {
+		stream, err := client.Export(ctx, req)
+		require.NoError(t, err)
+		var items []*ExportResponse
+		for {
+			item, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return items
+			}
+			require.NoError(t, err)
+			items = append(items, item)
+		}
+	}
+	batchWrite := func(t *testing.T, client ResourceStoreClient, items []*BatchWriteRequest) *BatchWriteResponse {
+		stream, err := client.BatchWrite(ctx)
+		require.NoError(t, err)
+		for _, item := range items {
+			require.NoError(t, stream.Send(item))
+		}
+		rsp, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		return rsp
+	}
+
+	source := newClient(t)
+	for _, name := range []string{"a", "b"} {
+		created, err := source.Create(ctx, &CreateRequest{Key: key(name, "default"), Value: value(name, "default", "v1")})
+		require.NoError(t, err)
+		require.Nil(t, created.Error)
+	}
+	found, err := source.Read(ctx, &ReadRequest{Key: key("a", "default")})
+	require.NoError(t, err)
+	updated, err := source.Update(ctx, &UpdateRequest{Key: key("a", "default"), Value: value("a", "default", "v2"), ResourceVersion: found.ResourceVersion})
+	require.NoError(t, err)
+	require.Nil(t, updated.Error)
+	deleted, err := source.Delete(ctx, &DeleteRequest{Key: key("b", "default")})
+	require.NoError(t, err)
+	require.Nil(t, deleted.Error)
+	created, err := source.Create(ctx, &CreateRequest{Key: key("c", "other"), Value: value("c", "other", "v1")})
+	require.NoError(t, err)
+	require.Nil(t, created.Error)
+
+	t.Run("export the latest values", func(t *testing.T) {
+		items := export(t, source, &ExportRequest{Key: &ResourceKey{Namespace: "default"}})
+		require.Len(t, items, 1)
+		require.Equal(t, "a", items[0].Key.Name)
+		require.Equal(t, WatchEvent_ADDED, items[0].Action)
+		require.Equal(t, updated.ResourceVersion, items[0].ResourceVersion)
+	})
+
+	t.Run("export the history", func(t *testing.T) {
+		items := export(t, source, &ExportRequest{Key: &ResourceKey{Namespace: "default"}, WithHistory: true})
+		actions := make([]string, 0, len(items))
+		for _, item := range items {
+			actions = append(actions, item.Key.Name+":"+item.Action.String())
+		}
+		require.Equal(t, []string{"a:ADDED", "a:MODIFIED", "b:ADDED", "b:DELETED"}, actions)
+	})
+
+	t.Run("export requires a namespace", func(t *testing.T) {
+		stream, err := source.Export(ctx, &ExportRequest{Key: &ResourceKey{}})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Error(t, err)
+	})
+
+	t.Run("replay the history into another store", func(t *testing.T) {
+		target := newClient(t)
+		var items []*BatchWriteRequest
+		for _, item := range export(t, source, &ExportRequest{Key: &ResourceKey{Namespace: "default"}, WithHistory: true}) {
+			items = append(items, &BatchWriteRequest{Key: item.Key, Action: item.Action, Value: item.Value})
+		}
+
+		rsp := batchWrite(t, target, items)
+		require.Nil(t, rsp.Error)
+		require.Equal(t, int64(4), rsp.Written)
+		require.True(t, rsp.ResourceVersion > 0)
+
+		found, err := target.Read(ctx, &ReadRequest{Key: key("a", "default")})
+		require.NoError(t, err)
+		require.Nil(t, found.Error)
+		require.Contains(t, string(found.Value), `"v2"`)
+
+		found, err = target.Read(ctx, &ReadRequest{Key: key("b", "default")})
+		require.NoError(t, err)
+		require.NotNil(t, found.Error)
+		require.Equal(t, int32(404), found.Error.Code)
+	})
+
+	t.Run("invalid values write nothing", func(t *testing.T) {
+		target := newClient(t)
+		rsp := batchWrite(t, target, []*BatchWriteRequest{
+			{Key: key("a", "default"), Action: WatchEvent_ADDED, Value: value("a", "default", "v1")},
+			{Key: key("b", "default"), Action: WatchEvent_ADDED, Value: value("b", "other", "v1")},
+		})
+		require.NotNil(t, rsp.Error)
+		require.Contains(t, rsp.Error.Message, "playlists/default/b")
+		require.Zero(t, rsp.Written)
+
+		found, err := target.Read(ctx, &ReadRequest{Key: key("a", "default")})
+		require.NoError(t, err)
+		require.NotNil(t, found.Error)
+	})
+	t.Run("deletion markers are validated", func(t *testing.T) {
+		marker := func(name, namespace, folder string) []byte {
+			return []byte(fmt.Sprintf(`{
+				"apiVersion": "common.grafana.app/v0alpha1",
+				"kind": "DeletedMarker",
+				"metadata": {
+					"name": %q,
+					"namespace": %q,
+					"deletionTimestamp": "2024-01-01T00:00:00Z",
+					"annotations": {"grafana.app/folder": %q}
+				}
+			}`, name, namespace, folder))
+		}
+		testCases := []struct {
+			name  string
+			value []byte
+		}{
+			{name: "not a marker", value: []byte("not json")},
+			{name: "marker of another name", value: marker("a", "default", "")},
+			{name: "marker of another namespace", value: marker("b", "other", "")},
+			{name: "marker without deletion timestamp", value: value("b", "default", "v1")},
+			{name: "marker in a folder that cannot be written", value: marker("b", "default", "folder")},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				rsp := batchWrite(t, newClient(t), []*BatchWriteRequest{
+					{Key: key("b", "default"), Action: WatchEvent_DELETED, Value: tc.value},
+				})
+				require.NotNil(t, rsp.Error)
+				require.Zero(t, rsp.Written)
+			})
+		}
+
+		rsp := batchWrite(t, newClient(t), []*BatchWriteRequest{
+			{Key: key("b", "default"), Action: WatchEvent_ADDED, Value: value("b", "default", "v1")},
+			{Key: key("b", "default"), Action: WatchEvent_DELETED, Value: marker("b", "default", "")},
+		})
+		require.Nil(t, rsp.Error)
+		require.Equal(t, int64(2), rsp.Written)
+	})
+}
@@ -218,6 +218,114 @@ func (s *cdkBackend) ListIterator(ctx context.Context, req *ListRequest, cb func
 	return resources.listRV, err
 }
 
+// SearchIterator implements SearchBackend.
+func (s *cdkBackend) SearchIterator(ctx context.Context, req *SearchRequest, cb func(ListIterator) error) (int64, error) {
+	resources, err := buildTree(ctx, s, req.Key)
+	if err != nil {
+		return 0, err
+	}
+	err = cb(&cdkSearchIterator{cdkListIterator: resources, terms: PrefilterTerms(req.Query)})
+	return resources.listRV, err
+}
+
+// cdkSearchIterator skips the values that do not contain every term
+type cdkSearchIterator struct {
+	*cdkListIterator
+	terms []string
+}
+
+// Next implements ListIterator.
+func (c *cdkSearchIterator) Next() bool {
+	for c.cdkListIterator.Next() {
+		value := bytes.ToLower(c.currentVal)
+		found := true
+		for _, term := range c.terms {
+			if !bytes.Contains(value, []byte(term)) {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteEvents implements BatchBackend.
+// NOTE: a bucket can not write many values atomically, events written before an error are kept
+func (s *cdkBackend) WriteEvents(ctx context.Context, events []WriteEvent) (int64, error) {
+	var rv int64
+	for _, event := range events {
+		var err error
+		rv, err = s.WriteEvent(ctx, event)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return rv, nil
+}
+
+// Export implements BatchBackend.
+func (s *cdkBackend) Export(ctx context.Context, req *ExportRequest, cb func(*ExportResponse) error) error {
+	resources, err := buildTree(ctx, s, &ResourceKey{Group: req.Key.Group, Resource: req.Key.Resource})
+	if err != nil {
+		return err
+	}
+
+	for _, res := range resources.resources {
+		// group/resource/namespace/name/
+		parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(res.prefix, s.root), "/"), "/")
+		if len(parts) != 4 || parts[2] != req.Key.Namespace {
+			continue
+		}
+		if req.Key.Resource != "" && parts[1] != req.Key.Resource {
+			continue
+		}
+		key := &ResourceKey{
+			Group:     parts[0],
+			Resource:  parts[1],
+			Namespace: parts[2],
+			Name:      parts[3],
+		}
+
+		// versions are sorted by the newest first
+		versions := res.versions[:1]
+		if req.WithHistory {
+			versions = res.versions
+		}
+		deleted := true
+		for i := len(versions) - 1; i >= 0; i-- {
+			raw, err := s.bucket.ReadAll(ctx, versions[i].key)
+			if err != nil {
+				return err
+			}
+
+			item := &ExportResponse{
+				Key:             key,
+				Action:          WatchEvent_MODIFIED,
+				Value:           raw,
+				ResourceVersion: versions[i].rv,
+			}
+			switch {
+			case isDeletedMarker(raw):
+				if !req.WithHistory {
+					continue
+				}
+				item.Action = WatchEvent_DELETED
+				deleted = true
+			case deleted || !req.WithHistory:
+				item.Action = WatchEvent_ADDED
+				deleted = false
+			}
+			if err := cb(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (s *cdkBackend) WatchWriteEvents(ctx context.Context) (<-chan *WrittenEvent, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -5,6 +5,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// The annotation on a DeletedMarker with the resource version that was deleted
+const restoreResourceVersionAnnotation = "RestoreResourceVersion"
+
 // This object is written when an object is deleted
 type DeletedMarker struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -42,3 +42,7 @@ func (n *noopService) History(context.Context, *HistoryRequest) (*HistoryRespons
 func (n *noopService) Origin(context.Context, *OriginRequest) (*OriginResponse, error) {
 	return nil, ErrNotImplementedYet
 }
+
+func (n *noopService) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, ErrNotImplementedYet
+}
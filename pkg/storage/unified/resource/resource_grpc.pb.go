@@ -19,12 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	ResourceStore_Read_FullMethodName   = "/resource.ResourceStore/Read"
-	ResourceStore_Create_FullMethodName = "/resource.ResourceStore/Create"
-	ResourceStore_Update_FullMethodName = "/resource.ResourceStore/Update"
-	ResourceStore_Delete_FullMethodName = "/resource.ResourceStore/Delete"
-	ResourceStore_List_FullMethodName   = "/resource.ResourceStore/List"
-	ResourceStore_Watch_FullMethodName  = "/resource.ResourceStore/Watch"
+	ResourceStore_Read_FullMethodName       = "/resource.ResourceStore/Read"
+	ResourceStore_Create_FullMethodName     = "/resource.ResourceStore/Create"
+	ResourceStore_Update_FullMethodName     = "/resource.ResourceStore/Update"
+	ResourceStore_Delete_FullMethodName     = "/resource.ResourceStore/Delete"
+	ResourceStore_Restore_FullMethodName    = "/resource.ResourceStore/Restore"
+	ResourceStore_List_FullMethodName       = "/resource.ResourceStore/List"
+	ResourceStore_Watch_FullMethodName      = "/resource.ResourceStore/Watch"
+	ResourceStore_BatchWrite_FullMethodName = "/resource.ResourceStore/BatchWrite"
+	ResourceStore_Export_FullMethodName     = "/resource.ResourceStore/Export"
 )
 
 // ResourceStoreClient is the client API for ResourceStore service.
@@ -40,6 +43,9 @@ type ResourceStoreClient interface {
 	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
 	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Write a new version with the value of a historical one.
+	// This also recovers deleted resources
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
 	// The results *may* include values that should not be returned to the user
 	// This will perform best-effort filtering to increase performace.
 	// NOTE: storage.Interface is ultimatly responsible for the final filtering
@@ -48,6 +54,12 @@ type ResourceStoreClient interface {
 	// This will perform best-effort filtering to increase performace.
 	// NOTE: storage.Interface is ultimatly responsible for the final filtering
 	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (ResourceStore_WatchClient, error)
+	// Write many values in a single call, the values are written all together or not at all.
+	// Values are applied in the order they are sent, so history can be replayed
+	BatchWrite(ctx context.Context, opts ...grpc.CallOption) (ResourceStore_BatchWriteClient, error)
+	// Stream every value in a namespace, ordered by key then resource version.
+	// The results can be sent to BatchWrite to copy them into another store
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (ResourceStore_ExportClient, error)
 }
 
 type resourceStoreClient struct {
@@ -98,6 +110,16 @@ func (c *resourceStoreClient) Delete(ctx context.Context, in *DeleteRequest, opt
 	return out, nil
 }
 
+func (c *resourceStoreClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, ResourceStore_Restore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *resourceStoreClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListResponse)
@@ -141,6 +163,74 @@ func (x *resourceStoreWatchClient) Recv() (*WatchEvent, error) {
 	return m, nil
 }
 
+func (c *resourceStoreClient) BatchWrite(ctx context.Context, opts ...grpc.CallOption) (ResourceStore_BatchWriteClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ResourceStore_ServiceDesc.Streams[1], ResourceStore_BatchWrite_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resourceStoreBatchWriteClient{ClientStream: stream}
+	return x, nil
+}
+
+type ResourceStore_BatchWriteClient interface {
+	Send(*BatchWriteRequest) error
+	CloseAndRecv() (*BatchWriteResponse, error)
+	grpc.ClientStream
+}
+
+type resourceStoreBatchWriteClient struct {
+	grpc.ClientStream
+}
+
+func (x *resourceStoreBatchWriteClient) Send(m *BatchWriteRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *resourceStoreBatchWriteClient) CloseAndRecv() (*BatchWriteResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(BatchWriteResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *resourceStoreClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (ResourceStore_ExportClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ResourceStore_ServiceDesc.Streams[2], ResourceStore_Export_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resourceStoreExportClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ResourceStore_ExportClient interface {
+	Recv() (*ExportResponse, error)
+	grpc.ClientStream
+}
+
+type resourceStoreExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *resourceStoreExportClient) Recv() (*ExportResponse, error) {
+	m := new(ExportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ResourceStoreServer is the server API for ResourceStore service.
 // All implementations should embed UnimplementedResourceStoreServer
 // for forward compatibility
@@ -154,6 +244,9 @@ type ResourceStoreServer interface {
 	Create(context.Context, *CreateRequest) (*CreateResponse, error)
 	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// Write a new version with the value of a historical one.
+	// This also recovers deleted resources
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
 	// The results *may* include values that should not be returned to the user
 	// This will perform best-effort filtering to increase performace.
 	// NOTE: storage.Interface is ultimatly responsible for the final filtering
@@ -162,6 +255,12 @@ type ResourceStoreServer interface {
 	// This will perform best-effort filtering to increase performace.
 	// NOTE: storage.Interface is ultimatly responsible for the final filtering
 	Watch(*WatchRequest, ResourceStore_WatchServer) error
+	// Write many values in a single call, the values are written all together or not at all.
+	// Values are applied in the order they are sent, so history can be replayed
+	BatchWrite(ResourceStore_BatchWriteServer) error
+	// Stream every value in a namespace, ordered by key then resource version.
+	// The results can be sent to BatchWrite to copy them into another store
+	Export(*ExportRequest, ResourceStore_ExportServer) error
 }
 
 // UnimplementedResourceStoreServer should be embedded to have forward compatible implementations.
@@ -180,12 +279,21 @@ func (UnimplementedResourceStoreServer) Update(context.Context, *UpdateRequest)
 func (UnimplementedResourceStoreServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedResourceStoreServer) Restore(context.Context, *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
 func (UnimplementedResourceStoreServer) List(context.Context, *ListRequest) (*ListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
 func (UnimplementedResourceStoreServer) Watch(*WatchRequest, ResourceStore_WatchServer) error {
 	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
 }
+func (UnimplementedResourceStoreServer) BatchWrite(ResourceStore_BatchWriteServer) error {
+	return status.Errorf(codes.Unimplemented, "method BatchWrite not implemented")
+}
+func (UnimplementedResourceStoreServer) Export(*ExportRequest, ResourceStore_ExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
 
 // UnsafeResourceStoreServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ResourceStoreServer will
@@ -270,6 +378,24 @@ func _ResourceStore_Delete_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ResourceStore_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceStoreServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceStore_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceStoreServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ResourceStore_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListRequest)
 	if err := dec(in); err != nil {
@@ -309,6 +435,53 @@ func (x *resourceStoreWatchServer) Send(m *WatchEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ResourceStore_BatchWrite_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ResourceStoreServer).BatchWrite(&resourceStoreBatchWriteServer{ServerStream: stream})
+}
+
+type ResourceStore_BatchWriteServer interface {
+	SendAndClose(*BatchWriteResponse) error
+	Recv() (*BatchWriteRequest, error)
+	grpc.ServerStream
+}
+
+type resourceStoreBatchWriteServer struct {
+	grpc.ServerStream
+}
+
+func (x *resourceStoreBatchWriteServer) SendAndClose(m *BatchWriteResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *resourceStoreBatchWriteServer) Recv() (*BatchWriteRequest, error) {
+	m := new(BatchWriteRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ResourceStore_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResourceStoreServer).Export(m, &resourceStoreExportServer{ServerStream: stream})
+}
+
+type ResourceStore_ExportServer interface {
+	Send(*ExportResponse) error
+	grpc.ServerStream
+}
+
+type resourceStoreExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *resourceStoreExportServer) Send(m *ExportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ResourceStore_ServiceDesc is the grpc.ServiceDesc for ResourceStore service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -332,6 +505,10 @@ var ResourceStore_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _ResourceStore_Delete_Handler,
 		},
+		{
+			MethodName: "Restore",
+			Handler:    _ResourceStore_Restore_Handler,
+		},
 		{
 			MethodName: "List",
 			Handler:    _ResourceStore_List_Handler,
@@ -343,11 +520,22 @@ var ResourceStore_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ResourceStore_Watch_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "BatchWrite",
+			Handler:       _ResourceStore_BatchWrite_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _ResourceStore_Export_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "resource.proto",
 }
 
 const (
+	ResourceIndex_Search_FullMethodName  = "/resource.ResourceIndex/Search"
 	ResourceIndex_Read_FullMethodName    = "/resource.ResourceIndex/Read"
 	ResourceIndex_History_FullMethodName = "/resource.ResourceIndex/History"
 	ResourceIndex_Origin_FullMethodName  = "/resource.ResourceIndex/Origin"
@@ -360,6 +548,8 @@ const (
 // Unlike the ResourceStore, this service can be exposed to clients directly
 // It should be implemented with efficient indexes and does not need read-after-write semantics
 type ResourceIndexClient interface {
+	// Search the latest values with full text, field selectors and facets
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
 	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error)
 	// Show resource history (and trash)
 	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
@@ -375,6 +565,16 @@ func NewResourceIndexClient(cc grpc.ClientConnInterface) ResourceIndexClient {
 	return &resourceIndexClient{cc}
 }
 
+func (c *resourceIndexClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, ResourceIndex_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *resourceIndexClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ReadResponse)
@@ -412,6 +612,8 @@ func (c *resourceIndexClient) Origin(ctx context.Context, in *OriginRequest, opt
 // Unlike the ResourceStore, this service can be exposed to clients directly
 // It should be implemented with efficient indexes and does not need read-after-write semantics
 type ResourceIndexServer interface {
+	// Search the latest values with full text, field selectors and facets
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
 	Read(context.Context, *ReadRequest) (*ReadResponse, error)
 	// Show resource history (and trash)
 	History(context.Context, *HistoryRequest) (*HistoryResponse, error)
@@ -423,6 +625,9 @@ type ResourceIndexServer interface {
 type UnimplementedResourceIndexServer struct {
 }
 
+func (UnimplementedResourceIndexServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
 func (UnimplementedResourceIndexServer) Read(context.Context, *ReadRequest) (*ReadResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Read not implemented")
 }
@@ -444,6 +649,24 @@ func RegisterResourceIndexServer(s grpc.ServiceRegistrar, srv ResourceIndexServe
 	s.RegisterService(&ResourceIndex_ServiceDesc, srv)
 }
 
+func _ResourceIndex_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceIndexServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceIndex_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceIndexServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ResourceIndex_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReadRequest)
 	if err := dec(in); err != nil {
@@ -505,6 +728,10 @@ var ResourceIndex_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "resource.ResourceIndex",
 	HandlerType: (*ResourceIndexServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Search",
+			Handler:    _ResourceIndex_Search_Handler,
+		},
 		{
 			MethodName: "Read",
 			Handler:    _ResourceIndex_Read_Handler,
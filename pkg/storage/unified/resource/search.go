@@ -0,0 +1,386 @@
+package resource
+
+import (
+	context "context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/grafana/grafana/pkg/apimachinery/utils"
+)
+
+// SearchBackend can be implemented by a StorageBackend to narrow down the values checked by a search.
+type SearchBackend interface {
+	// SearchIterator iterates the latest values that may match the request.
+	// The values are checked again by the server, so returning values that
+	// do not match (for example, not filtering on fields) is allowed.
+	SearchIterator(context.Context, *SearchRequest, func(ListIterator) error) (int64, error)
+}
+
+// queryTerms splits a full text query into the lower case terms that must all be found.
+func queryTerms(query string) []string {
+	return strings.Fields(strings.ToLower(query))
+}
+
+// PrefilterTerms returns the terms of a full text query that can be looked for in the raw JSON
+// of a value to discard it early. Terms that JSON could escape, or that use LIKE wildcards, are
+// left for the server to check.
+func PrefilterTerms(query string) []string {
+	var terms []string
+	for _, term := range queryTerms(query) {
+		safe := true
+		for _, r := range term {
+			if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '-' {
+				safe = false
+				break
+			}
+		}
+		if safe {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// The fields that can be used to filter, facet and sort search results
+const (
+	SearchFieldName            = "name"
+	SearchFieldTitle           = "title"
+	SearchFieldDescription     = "description"
+	SearchFieldFolder          = "folder"
+	SearchFieldTags            = "tags"
+	SearchFieldKind            = "kind"
+	SearchFieldResourceVersion = "resource_version"
+	SearchFieldScore           = "score"
+)
+
+var (
+	searchFilterFields = map[string]bool{
+		SearchFieldName:        true,
+		SearchFieldTitle:       true,
+		SearchFieldDescription: true,
+		SearchFieldFolder:      true,
+		SearchFieldTags:        true,
+		SearchFieldKind:        true,
+	}
+	searchFacetFields = map[string]bool{
+		SearchFieldFolder: true,
+		SearchFieldTags:   true,
+		SearchFieldKind:   true,
+	}
+	searchSortFields = map[string]bool{
+		SearchFieldName:            true,
+		SearchFieldTitle:           true,
+		SearchFieldFolder:          true,
+		SearchFieldKind:            true,
+		SearchFieldResourceVersion: true,
+		SearchFieldScore:           true,
+	}
+)
+
+// searchDocument holds the indexed fields of a resource
+type searchDocument struct {
+	namespace       string
+	name            string
+	resourceVersion int64
+	title           string
+	description     string
+	folder          string
+	tags            []string
+	kind            string
+	labels          labels.Set
+	score           float64
+}
+
+func newSearchDocument(rv int64, value []byte) (*searchDocument, error) {
+	tmp := &unstructured.Unstructured{}
+	if err := tmp.UnmarshalJSON(value); err != nil {
+		return nil, err
+	}
+	obj, err := utils.MetaAccessor(tmp)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &searchDocument{
+		namespace:       tmp.GetNamespace(),
+		name:            tmp.GetName(),
+		resourceVersion: rv,
+		folder:          obj.GetFolder(),
+		kind:            tmp.GetKind(),
+		labels:          tmp.GetLabels(),
+	}
+	doc.title, _, _ = unstructured.NestedString(tmp.Object, "spec", "title")
+	if doc.title == "" {
+		doc.title = doc.name
+	}
+	doc.description, _, _ = unstructured.NestedString(tmp.Object, "spec", "description")
+	doc.tags, _, _ = unstructured.NestedStringSlice(tmp.Object, "spec", "tags")
+	return doc, nil
+}
+
+// values returns the values of a field, tags can have many values
+func (d *searchDocument) values(field string) []string {
+	switch field {
+	case SearchFieldName:
+		return []string{d.name}
+	case SearchFieldTitle:
+		return []string{d.title}
+	case SearchFieldDescription:
+		return []string{d.description}
+	case SearchFieldFolder:
+		return []string{d.folder}
+	case SearchFieldTags:
+		return d.tags
+	case SearchFieldKind:
+		return []string{d.kind}
+	}
+	return nil
+}
+
+type searchSort struct {
+	field string
+	desc  bool
+}
+
+// searchQuery is a validated SearchRequest
+type searchQuery struct {
+	terms  []string
+	labels labels.Selector
+	fields []*Requirement
+	facets []string
+	sort   []searchSort
+	limit  int64
+	offset int64
+}
+
+func newSearchQuery(req *SearchRequest) (*searchQuery, error) {
+	q := &searchQuery{
+		terms:  queryTerms(req.Query),
+		labels: labels.Everything(),
+		facets: req.Facets,
+		limit:  req.Limit,
+	}
+	if q.limit < 1 {
+		q.limit = 50 // default max 50 hits in a page
+	}
+
+	if req.NextPageToken != "" {
+		offset, err := strconv.ParseInt(req.NextPageToken, 10, 64)
+		if err != nil || offset < 0 {
+			return nil, apierrors.NewBadRequest("invalid next page token")
+		}
+		q.offset = offset
+	}
+
+	for _, r := range req.Labels {
+		requirement, err := labels.NewRequirement(r.Key, selection.Operator(r.Operator), r.Values)
+		if err != nil {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid label requirement: %v", err))
+		}
+		q.labels = q.labels.Add(*requirement)
+	}
+
+	for _, r := range req.Fields {
+		if !searchFilterFields[r.Key] {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("unsupported search field %q", r.Key))
+		}
+		switch selection.Operator(r.Operator) {
+		case selection.Equals, selection.DoubleEquals, selection.NotEquals:
+			if len(r.Values) != 1 {
+				return nil, apierrors.NewBadRequest(fmt.Sprintf("operator %q on field %q expects a single value", r.Operator, r.Key))
+			}
+		case selection.In, selection.NotIn:
+			if len(r.Values) == 0 {
+				return nil, apierrors.NewBadRequest(fmt.Sprintf("operator %q on field %q expects values", r.Operator, r.Key))
+			}
+		case selection.Exists, selection.DoesNotExist:
+		default:
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("unsupported operator %q on field %q", r.Operator, r.Key))
+		}
+		q.fields = append(q.fields, r)
+	}
+
+	for _, f := range req.Facets {
+		if !searchFacetFields[f] {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("unsupported facet %q", f))
+		}
+	}
+
+	for _, s := range req.Sort {
+		field := strings.TrimPrefix(s, "-")
+		if !searchSortFields[field] {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("unsupported sort field %q", field))
+		}
+		q.sort = append(q.sort, searchSort{field: field, desc: strings.HasPrefix(s, "-")})
+	}
+	if len(q.sort) == 0 {
+		if len(q.terms) > 0 {
+			q.sort = append(q.sort, searchSort{field: SearchFieldScore, desc: true})
+		}
+		q.sort = append(q.sort, searchSort{field: SearchFieldTitle})
+	}
+	return q, nil
+}
+
+// matches checks the document against the query, and sets its score
+func (q *searchQuery) matches(doc *searchDocument) bool {
+	if !q.labels.Matches(doc.labels) {
+		return false
+	}
+	for _, r := range q.fields {
+		if !matchesField(r, doc.values(r.Key)) {
+			return false
+		}
+	}
+
+	if len(q.terms) == 0 {
+		return true
+	}
+	title := strings.ToLower(doc.title)
+	description := strings.ToLower(doc.description)
+	doc.score = 0
+	for _, term := range q.terms {
+		inTitle := strings.Contains(title, term)
+		inDescription := strings.Contains(description, term)
+		if !inTitle && !inDescription {
+			return false
+		}
+		// title matches are worth more
+		if inTitle {
+			doc.score += 2
+		}
+		if inDescription {
+			doc.score++
+		}
+	}
+	if title == strings.Join(q.terms, " ") {
+		doc.score += float64(len(q.terms))
+	}
+	return true
+}
+
+func matchesField(r *Requirement, values []string) bool {
+	found := false
+	for _, v := range values {
+		for _, want := range r.Values {
+			if v == want {
+				found = true
+			}
+		}
+	}
+
+	switch selection.Operator(r.Operator) {
+	case selection.Equals, selection.DoubleEquals, selection.In:
+		return found
+	case selection.NotEquals, selection.NotIn:
+		return !found
+	case selection.Exists, selection.DoesNotExist:
+		exists := false
+		for _, v := range values {
+			if v != "" {
+				exists = true
+			}
+		}
+		return exists == (selection.Operator(r.Operator) == selection.Exists)
+	}
+	return false
+}
+
+func (q *searchQuery) less(a, b *searchDocument) bool {
+	for _, s := range q.sort {
+		var c int
+		switch s.field {
+		case SearchFieldScore:
+			c = compareFloat(a.score, b.score)
+		case SearchFieldResourceVersion:
+			c = compareFloat(float64(a.resourceVersion), float64(b.resourceVersion))
+		default:
+			c = strings.Compare(strings.ToLower(a.values(s.field)[0]), strings.ToLower(b.values(s.field)[0]))
+		}
+		if c != 0 {
+			return (c < 0) != s.desc
+		}
+	}
+	// stable order for paging
+	if a.namespace != b.namespace {
+		return a.namespace < b.namespace
+	}
+	return a.name < b.name
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// result sorts the matching documents and writes the requested page and facets to the response
+func (q *searchQuery) result(key *ResourceKey, docs []*searchDocument, rsp *SearchResponse) {
+	rsp.TotalHits = int64(len(docs))
+
+	if len(q.facets) > 0 {
+		rsp.Facets = make(map[string]*SearchFacet, len(q.facets))
+		for _, field := range q.facets {
+			counts := map[string]int64{}
+			for _, doc := range docs {
+				for _, v := range doc.values(field) {
+					counts[v]++
+				}
+			}
+			facet := &SearchFacet{Terms: make([]*FacetTerm, 0, len(counts))}
+			for term, count := range counts {
+				facet.Terms = append(facet.Terms, &FacetTerm{Term: term, Count: count})
+			}
+			sort.Slice(facet.Terms, func(i, j int) bool {
+				a, b := facet.Terms[i], facet.Terms[j]
+				if a.Count != b.Count {
+					return a.Count > b.Count
+				}
+				return a.Term < b.Term
+			})
+			rsp.Facets[field] = facet
+		}
+	}
+
+	sort.Slice(docs, func(i, j int) bool {
+		return q.less(docs[i], docs[j])
+	})
+
+	if q.offset >= int64(len(docs)) {
+		return
+	}
+	end := q.offset + q.limit
+	if end < int64(len(docs)) {
+		rsp.NextPageToken = strconv.FormatInt(end, 10)
+	} else {
+		end = int64(len(docs))
+	}
+	for _, doc := range docs[q.offset:end] {
+		rsp.Hits = append(rsp.Hits, &SearchHit{
+			Key: &ResourceKey{
+				Namespace: doc.namespace,
+				Group:     key.Group,
+				Resource:  key.Resource,
+				Name:      doc.name,
+			},
+			ResourceVersion: doc.resourceVersion,
+			Title:           doc.title,
+			Description:     doc.description,
+			Folder:          doc.folder,
+			Tags:            doc.tags,
+			Kind:            doc.kind,
+			Score:           doc.score,
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob/memblob"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+)
+
+func TestSearch(t *testing.T) {
+	testUserA := &identity.StaticRequester{
+		Type:           identity.TypeUser,
+		Login:          "testuser",
+		UserID:         123,
+		UserUID:        "u123",
+		OrgRole:        identity.RoleAdmin,
+		IsGrafanaAdmin: true, // can do anything
+	}
+	ctx := identity.WithRequester(context.Background(), testUserA)
+
+	store, err := NewCDKBackend(ctx, CDKBackendOptions{
+		Bucket: memblob.OpenBucket(nil),
+	})
+	require.NoError(t, err)
+
+	server, err := NewResourceServer(ResourceServerOptions{
+		Backend:     store,
+		WriteAccess: WriteAccessHooks{Folder: func(context.Context, identity.Requester, string) bool { return true }},
+	})
+	require.NoError(t, err)
+
+	dashboards := []struct {
+		name, title, description, folder, tags, team string
+	}{
+		{"a", "CPU usage", "Usage of the CPU per host", "infra", `["linux", "prod"]`, "ops"},
+		{"b", "Memory usage", "Memory per host, not the CPU", "infra", `["linux"]`, "ops"},
+		{"c", "Sales", "Sales per region", "business", `["prod"]`, "sales"},
+		{"d", "CPU", "", "", `[]`, "ops"},
+	}
+	for _, d := range dashboards {
+		annotations := ""
+		if d.folder != "" {
+			annotations = fmt.Sprintf(`"annotations": {"grafana.app/folder": %q},`, d.folder)
+		}
+		raw := []byte(fmt.Sprintf(`{
+			"apiVersion": "dashboard.grafana.app/v0alpha1",
+			"kind": "Dashboard",
+			"metadata": {
+				"name": %q,
+				"namespace": "default",
+				%s
+				"labels": {"team": %q}
+			},
+			"spec": {"title": %q, "description": %q, "tags": %s}
+		}`, d.name, annotations, d.team, d.title, d.description, d.tags))
+
+		created, err := server.Create(ctx, &CreateRequest{
+			Value: raw,
+			Key: &ResourceKey{
+				Group:     "dashboard.grafana.app",
+				Resource:  "dashboards",
+				Namespace: "default",
+				Name:      d.name,
+			},
+		})
+		require.NoError(t, err)
+		require.Nil(t, created.Error)
+	}
+
+	key := &ResourceKey{Group: "dashboard.grafana.app", Resource: "dashboards", Namespace: "default"}
+	names := func(rsp *SearchResponse) []string {
+		res := make([]string, 0, len(rsp.Hits))
+		for _, hit := range rsp.Hits {
+			res = append(res, hit.Key.Name)
+		}
+		return res
+	}
+
+	t.Run("full text is ranked by score, exact titles first", func(t *testing.T) {
+		rsp, err := server.Search(ctx, &SearchRequest{Key: key, Query: "cpu"})
+		require.NoError(t, err)
+		require.Nil(t, rsp.Error)
+		require.Equal(t, []string{"d", "a", "b"}, names(rsp))
+		require.Equal(t, int64(3), rsp.TotalHits)
+		require.Equal(t, "CPU usage", rsp.Hits[1].Title)
+		require.Equal(t, "infra", rsp.Hits[1].Folder)
+		require.Equal(t, []string{"linux", "prod"}, rsp.Hits[1].Tags)
+		require.Equal(t, "Dashboard", rsp.Hits[1].Kind)
+	})
+
+	t.Run("every term must match", func(t *testing.T) {
+		rsp, err := server.Search(ctx, &SearchRequest{Key: key, Query: "CPU host"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, names(rsp))
+	})
+
+	t.Run("fields and labels", func(t *testing.T) {
+		rsp, err := server.Search(ctx, &SearchRequest{
+			Key: key,
+			Fields: []*Requirement{
+				{Key: "tags", Operator: "=", Values: []string{"prod"}},
+			},
+			Labels: []*Requirement{
+				{Key: "team", Operator: "=", Values: []string{"ops"}},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a"}, names(rsp))
+
+		rsp, err = server.Search(ctx, &SearchRequest{
+			Key: key,
+			Fields: []*Requirement{
+				{Key: "folder", Operator: "!", Values: nil},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"d"}, names(rsp))
+	})
+
+	t.Run("facets count all the hits", func(t *testing.T) {
+		rsp, err := server.Search(ctx, &SearchRequest{Key: key, Facets: []string{"folder", "tags"}, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, rsp.Hits, 1)
+		require.Equal(t, []*FacetTerm{{Term: "infra", Count: 2}, {Term: "", Count: 1}, {Term: "business", Count: 1}}, rsp.Facets["folder"].Terms)
+		require.Equal(t, []*FacetTerm{{Term: "linux", Count: 2}, {Term: "prod", Count: 2}}, rsp.Facets["tags"].Terms)
+	})
+
+	t.Run("sort and paging", func(t *testing.T) {
+		req := &SearchRequest{Key: key, Sort: []string{"-name"}, Limit: 3}
+		rsp, err := server.Search(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, []string{"d", "c", "b"}, names(rsp))
+		require.Equal(t, "3", rsp.NextPageToken)
+
+		req.NextPageToken = rsp.NextPageToken
+		rsp, err = server.Search(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a"}, names(rsp))
+		require.Empty(t, rsp.NextPageToken)
+		require.Equal(t, int64(4), rsp.TotalHits)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, req := range []*SearchRequest{
+			{Key: &ResourceKey{Group: key.Group}},
+			{Key: key, Fields: []*Requirement{{Key: "spec.panels", Operator: "=", Values: []string{"x"}}}},
+			{Key: key, Facets: []string{"title"}},
+			{Key: key, Sort: []string{"description"}},
+			{Key: key, NextPageToken: "abc"},
+		} {
+			rsp, err := server.Search(ctx, req)
+			require.NoError(t, err)
+			require.NotNil(t, rsp.Error)
+			require.Equal(t, int32(400), rsp.Error.Code)
+		}
+	})
+}
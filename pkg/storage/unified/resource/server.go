@@ -3,9 +3,12 @@ package resource
 import (
 	context "context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -279,24 +282,33 @@ func (s *server) newEvent(ctx context.Context, key *ResourceKey, value, oldValue
 		return nil, err
 	}
 
+	err = s.canWrite(ctx, user, obj)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// canWrite checks that the user can write to the folder and origin of the object.
+func (s *server) canWrite(ctx context.Context, user identity.Requester, obj utils.GrafanaMetaAccessor) error {
 	folder := obj.GetFolder()
 	if folder != "" {
-		err = s.access.CanWriteFolder(ctx, user, folder)
+		err := s.access.CanWriteFolder(ctx, user, folder)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 	origin, err := obj.GetOriginInfo()
 	if err != nil {
-		return nil, apierrors.NewBadRequest("invalid origin info")
+		return apierrors.NewBadRequest("invalid origin info")
 	}
 	if origin != nil {
 		err = s.access.CanWriteOrigin(ctx, user, origin.Name)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
-	return event, nil
+	return nil
 }
 
 func (s *server) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
@@ -429,7 +441,7 @@ func (s *server) Delete(ctx context.Context, req *DeleteRequest) (*DeleteRespons
 		Kind:       "DeletedMarker",
 		APIVersion: "common.grafana.app/v0alpha1", // ?? or can we stick this in common?
 	}
-	marker.Annotations["RestoreResourceVersion"] = fmt.Sprintf("%d", event.PreviousRV)
+	obj.SetAnnotation(restoreResourceVersionAnnotation, fmt.Sprintf("%d", event.PreviousRV))
 	event.Value, err = json.Marshal(marker)
 	if err != nil {
 		return nil, apierrors.NewBadRequest(
@@ -443,6 +455,120 @@ func (s *server) Delete(ctx context.Context, req *DeleteRequest) (*DeleteRespons
 	return rsp, nil
 }
 
+func (s *server) Restore(ctx context.Context, req *RestoreRequest) (*RestoreResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "storage_server.Restore")
+	defer span.End()
+
+	if err := s.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	rsp := &RestoreResponse{}
+	if req.Key == nil || req.Key.Resource == "" || req.Key.Name == "" {
+		rsp.Error = NewBadRequestError("restore requires a full key")
+		return rsp, nil
+	}
+	if req.ResourceVersion < 1 {
+		rsp.Error = NewBadRequestError("restore requires the resource version to restore")
+		return rsp, nil
+	}
+
+	historic := s.readVersion(ctx, req.Key, req.ResourceVersion)
+	if historic.Error != nil {
+		rsp.Error = historic.Error
+		return rsp, nil
+	}
+
+	// Restoring a deletion restores the value it deleted
+	if isDeletedMarker(historic.Value) {
+		marker := &DeletedMarker{}
+		err := json.Unmarshal(historic.Value, marker)
+		if err != nil {
+			return nil, err
+		}
+		rv, err := strconv.ParseInt(marker.Annotations[restoreResourceVersionAnnotation], 10, 64)
+		if err != nil || rv < 1 {
+			rsp.Error = NewBadRequestError("the deletion marker does not have a version to restore")
+			return rsp, nil
+		}
+		historic = s.readVersion(ctx, req.Key, rv)
+		if historic.Error != nil {
+			rsp.Error = historic.Error
+			return rsp, nil
+		}
+	}
+	rsp.RestoredResourceVersion = historic.ResourceVersion
+
+	// The latest value is missing when the resource has been deleted
+	var oldValue []byte
+	latest := s.backend.ReadResource(ctx, &ReadRequest{Key: req.Key})
+	if latest.Error != nil && latest.Error.Code != http.StatusNotFound {
+		rsp.Error = latest.Error
+		return rsp, nil
+	}
+	if latest.Error == nil && len(latest.Value) > 0 {
+		if req.CurrentResourceVersion > 0 && latest.ResourceVersion != req.CurrentResourceVersion {
+			rsp.Error = AsErrorResult(ErrOptimisticLockingFailed)
+			return rsp, nil
+		}
+		oldValue = latest.Value
+	}
+
+	value, err := s.restoredValue(ctx, historic.Value)
+	if err != nil {
+		rsp.Error = AsErrorResult(err)
+		return rsp, nil
+	}
+
+	event, err := s.newEvent(ctx, req.Key, value, oldValue)
+	if err != nil {
+		rsp.Error = AsErrorResult(err)
+		return rsp, nil
+	}
+	if oldValue != nil {
+		event.PreviousRV = latest.ResourceVersion
+	}
+
+	rsp.ResourceVersion, err = s.backend.WriteEvent(ctx, *event)
+	if err != nil {
+		rsp.Error = AsErrorResult(err)
+	}
+	return rsp, nil
+}
+
+// readVersion reads the value saved with exactly the given resource version
+func (s *server) readVersion(ctx context.Context, key *ResourceKey, rv int64) *ReadResponse {
+	rsp := s.backend.ReadResource(ctx, &ReadRequest{Key: key, ResourceVersion: rv})
+	if rsp.Error == nil && rsp.ResourceVersion != rv {
+		// backends may fallback to the closest version
+		return &ReadResponse{Error: NewNotFoundError(key)}
+	}
+	return rsp
+}
+
+// restoredValue updates the metadata of a historical value so it can be written as the latest one
+func (s *server) restoredValue(ctx context.Context, value []byte) ([]byte, error) {
+	requester, err := identity.GetRequester(ctx)
+	if err != nil {
+		return nil, ErrUserNotFoundInContext
+	}
+	tmp := &unstructured.Unstructured{}
+	err = tmp.UnmarshalJSON(value)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := utils.MetaAccessor(tmp)
+	if err != nil {
+		return nil, err
+	}
+	now := time.UnixMilli(s.now())
+	obj.SetResourceVersion("")
+	obj.SetDeletionTimestamp(nil)
+	obj.SetUpdatedTimestamp(&now)
+	obj.SetUpdatedBy(requester.GetUID())
+	return tmp.MarshalJSON()
+}
+
 func (s *server) Read(ctx context.Context, req *ReadRequest) (*ReadResponse, error) {
 	if err := s.Init(ctx); err != nil {
 		return nil, err
@@ -587,6 +713,72 @@ func (s *server) Watch(req *WatchRequest, srv ResourceStore_WatchServer) error {
 	}
 }
 
+func (s *server) BatchWrite(stream ResourceStore_BatchWriteServer) error {
+	ctx, span := s.tracer.Start(stream.Context(), "storage_server.BatchWrite")
+	defer span.End()
+
+	if err := s.Init(ctx); err != nil {
+		return err
+	}
+
+	batch, ok := s.backend.(BatchBackend)
+	if !ok {
+		return ErrNotImplementedYet
+	}
+
+	rsp := &BatchWriteResponse{}
+	var events []WriteEvent
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		event, err := s.batchEvent(ctx, req)
+		if err != nil {
+			rsp.Error = AsErrorResult(err)
+			if req.Key != nil {
+				rsp.Error.Message = fmt.Sprintf("%s/%s/%s: %s", req.Key.Resource, req.Key.Namespace, req.Key.Name, rsp.Error.Message)
+			}
+			return stream.SendAndClose(rsp)
+		}
+		events = append(events, *event)
+	}
+
+	if len(events) > 0 {
+		rv, err := batch.WriteEvents(ctx, events)
+		if err != nil {
+			rsp.Error = AsErrorResult(err)
+			return stream.SendAndClose(rsp)
+		}
+		rsp.Written = int64(len(events))
+		rsp.ResourceVersion = rv
+	}
+	return stream.SendAndClose(rsp)
+}
+
+func (s *server) Export(req *ExportRequest, srv ResourceStore_ExportServer) error {
+	ctx, span := s.tracer.Start(srv.Context(), "storage_server.Export")
+	defer span.End()
+
+	if err := s.Init(ctx); err != nil {
+		return err
+	}
+
+	if req.Key == nil || req.Key.Namespace == "" {
+		return apierrors.NewBadRequest("export requires a namespace")
+	}
+
+	batch, ok := s.backend.(BatchBackend)
+	if !ok {
+		return ErrNotImplementedYet
+	}
+	return batch.Export(ctx, req, srv.Send)
+}
+
 // History implements ResourceServer.
 func (s *server) History(ctx context.Context, req *HistoryRequest) (*HistoryResponse, error) {
 	if err := s.Init(ctx); err != nil {
@@ -603,6 +795,65 @@ func (s *server) Origin(ctx context.Context, req *OriginRequest) (*OriginRespons
 	return s.index.Origin(ctx, req)
 }
 
+// Search implements ResourceServer.
+func (s *server) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "storage_server.Search")
+	defer span.End()
+
+	if err := s.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	rsp := &SearchResponse{}
+	if req.Key == nil || req.Key.Group == "" || req.Key.Resource == "" {
+		rsp.Error = NewBadRequestError("missing group or resource")
+		return rsp, nil
+	}
+	query, err := newSearchQuery(req)
+	if err != nil {
+		rsp.Error = AsErrorResult(err)
+		return rsp, nil
+	}
+
+	var docs []*searchDocument
+	collect := func(iter ListIterator) error {
+		for iter.Next() {
+			if err := iter.Error(); err != nil {
+				return err
+			}
+
+			// TODO: add authz filters
+
+			doc, err := newSearchDocument(iter.ResourceVersion(), iter.Value())
+			if err != nil {
+				s.log.Warn("skipping invalid value in search", "namespace", iter.Namespace(), "name", iter.Name(), "error", err)
+				continue
+			}
+			if query.matches(doc) {
+				docs = append(docs, doc)
+			}
+		}
+		return iter.Error()
+	}
+
+	var rv int64
+	if backend, ok := s.backend.(SearchBackend); ok {
+		rv, err = backend.SearchIterator(ctx, req, collect)
+	} else {
+		rv, err = s.backend.ListIterator(ctx, &ListRequest{
+			Options: &ListOptions{Key: req.Key, Labels: req.Labels},
+		}, collect)
+	}
+	if err != nil {
+		rsp.Error = AsErrorResult(err)
+		return rsp, nil
+	}
+
+	rsp.ResourceVersion = rv
+	query.result(req.Key, docs, rsp)
+	return rsp, nil
+}
+
 // IsHealthy implements ResourceServer.
 func (s *server) IsHealthy(ctx context.Context, req *HealthCheckRequest) (*HealthCheckResponse, error) {
 	if err := s.Init(ctx); err != nil {
@@ -217,4 +217,81 @@ func TestSimpleServer(t *testing.T) {
 			ResourceVersion: created.ResourceVersion})
 		require.ErrorIs(t, err, ErrOptimisticLockingFailed)
 	})
+
+	t.Run("playlist restore and undelete", func(t *testing.T) {
+		key := &ResourceKey{
+			Group:     "playlist.grafana.app",
+			Resource:  "rrrr", // can be anything :(
+			Namespace: "default",
+			Name:      "restored",
+		}
+		value := func(title string) []byte {
+			return []byte(fmt.Sprintf(`{
+				"apiVersion": "playlist.grafana.app/v0alpha1",
+				"kind": "Playlist",
+				"metadata": {
+					"name": "restored",
+					"namespace": "default"
+				},
+				"spec": {
+					"title": %q,
+					"interval": "5m"
+				}
+			}`, title))
+		}
+		readTitle := func() string {
+			found, err := server.Read(ctx, &ReadRequest{Key: key})
+			require.NoError(t, err)
+			require.Nil(t, found.Error)
+			tmp := &unstructured.Unstructured{}
+			require.NoError(t, tmp.UnmarshalJSON(found.Value))
+			title, _, _ := unstructured.NestedString(tmp.Object, "spec", "title")
+			return title
+		}
+
+		created, err := server.Create(ctx, &CreateRequest{Key: key, Value: value("v1")})
+		require.NoError(t, err)
+		require.Nil(t, created.Error)
+
+		updated, err := server.Update(ctx, &UpdateRequest{Key: key, Value: value("v2"), ResourceVersion: created.ResourceVersion})
+		require.NoError(t, err)
+		require.Nil(t, updated.Error)
+
+		// Restore the first version
+		restored, err := server.Restore(ctx, &RestoreRequest{Key: key, ResourceVersion: created.ResourceVersion})
+		require.NoError(t, err)
+		require.Nil(t, restored.Error)
+		require.True(t, restored.ResourceVersion > updated.ResourceVersion)
+		require.Equal(t, created.ResourceVersion, restored.RestoredResourceVersion)
+		require.Equal(t, "v1", readTitle())
+
+		// The current version must match when set
+		rsp, err := server.Restore(ctx, &RestoreRequest{Key: key, ResourceVersion: updated.ResourceVersion, CurrentResourceVersion: updated.ResourceVersion})
+		require.NoError(t, err)
+		require.NotNil(t, rsp.Error)
+
+		// Unknown versions are not found
+		rsp, err = server.Restore(ctx, &RestoreRequest{Key: key, ResourceVersion: restored.ResourceVersion + 100})
+		require.NoError(t, err)
+		require.NotNil(t, rsp.Error)
+
+		// Undelete the second version
+		deleted, err := server.Delete(ctx, &DeleteRequest{Key: key, ResourceVersion: restored.ResourceVersion})
+		require.NoError(t, err)
+		require.Nil(t, deleted.Error)
+
+		restored, err = server.Restore(ctx, &RestoreRequest{Key: key, ResourceVersion: updated.ResourceVersion})
+		require.NoError(t, err)
+		require.Nil(t, restored.Error)
+		require.True(t, restored.ResourceVersion > deleted.ResourceVersion)
+		require.Equal(t, "v2", readTitle())
+
+		// A full key and version are required
+		rsp, err = server.Restore(ctx, &RestoreRequest{Key: &ResourceKey{Group: key.Group, Resource: key.Resource}, ResourceVersion: 1})
+		require.NoError(t, err)
+		require.Equal(t, int32(400), rsp.Error.Code)
+		rsp, err = server.Restore(ctx, &RestoreRequest{Key: key})
+		require.NoError(t, err)
+		require.Equal(t, int32(400), rsp.Error.Code)
+	})
 }
@@ -126,173 +126,208 @@ func (b *backend) WriteEvent(ctx context.Context, event resource.WriteEvent) (in
 	}
 }
 
-func (b *backend) create(ctx context.Context, event resource.WriteEvent) (int64, error) {
-	ctx, span := b.tracer.Start(ctx, trace_prefix+"Create")
+// WriteEvents implements resource.BatchBackend, the events are written in a single transaction.
+func (b *backend) WriteEvents(ctx context.Context, events []resource.WriteEvent) (int64, error) {
+	ctx, span := b.tracer.Start(ctx, trace_prefix+"WriteEvents")
 	defer span.End()
 	var newVersion int64
-	guid := uuid.New().String()
 	err := b.db.WithTx(ctx, ReadCommitted, func(ctx context.Context, tx db.Tx) error {
-		// TODO: Set the Labels
-
-		// 1. Insert into resource
-		if _, err := dbutil.Exec(ctx, tx, sqlResourceInsert, sqlResourceRequest{
-			SQLTemplate: sqltemplate.New(b.dialect),
-			WriteEvent:  event,
-			GUID:        guid,
-		}); err != nil {
-			return fmt.Errorf("insert into resource: %w", err)
+		for _, event := range events {
+			var err error
+			switch event.Type {
+			case resource.WatchEvent_ADDED:
+				newVersion, err = b.createTx(ctx, tx, event)
+			case resource.WatchEvent_MODIFIED:
+				newVersion, err = b.updateTx(ctx, tx, event)
+			case resource.WatchEvent_DELETED:
+				newVersion, err = b.deleteTx(ctx, tx, event)
+			default:
+				err = fmt.Errorf("unsupported event type")
+			}
+			if err != nil {
+				return fmt.Errorf("write %s/%s/%s: %w", event.Key.Resource, event.Key.Namespace, event.Key.Name, err)
+			}
 		}
+		return nil
+	})
+	return newVersion, err
+}
 
-		// 2. Insert into resource history
-		if _, err := dbutil.Exec(ctx, tx, sqlResourceHistoryInsert, sqlResourceRequest{
-			SQLTemplate: sqltemplate.New(b.dialect),
-			WriteEvent:  event,
-			GUID:        guid,
-		}); err != nil {
-			return fmt.Errorf("insert into resource history: %w", err)
-		}
+func (b *backend) create(ctx context.Context, event resource.WriteEvent) (int64, error) {
+	ctx, span := b.tracer.Start(ctx, trace_prefix+"Create")
+	defer span.End()
+	var newVersion int64
+	err := b.db.WithTx(ctx, ReadCommitted, func(ctx context.Context, tx db.Tx) error {
+		var err error
+		newVersion, err = b.createTx(ctx, tx, event)
+		return err
+	})
+	return newVersion, err
+}
 
-		// 3. TODO: Rebuild the whole folder tree structure if we're creating a folder
+func (b *backend) createTx(ctx context.Context, tx db.Tx, event resource.WriteEvent) (int64, error) {
+	guid := uuid.New().String()
+	// TODO: Set the Labels
+
+	// 1. Insert into resource
+	if _, err := dbutil.Exec(ctx, tx, sqlResourceInsert, sqlResourceRequest{
+		SQLTemplate: sqltemplate.New(b.dialect),
+		WriteEvent:  event,
+		GUID:        guid,
+	}); err != nil {
+		return 0, fmt.Errorf("insert into resource: %w", err)
+	}
 
-		// 4. Atomically increment resource version for this kind
-		rv, err := resourceVersionAtomicInc(ctx, tx, b.dialect, event.Key)
-		if err != nil {
-			return fmt.Errorf("increment resource version: %w", err)
-		}
+	// 2. Insert into resource history
+	if _, err := dbutil.Exec(ctx, tx, sqlResourceHistoryInsert, sqlResourceRequest{
+		SQLTemplate: sqltemplate.New(b.dialect),
+		WriteEvent:  event,
+		GUID:        guid,
+	}); err != nil {
+		return 0, fmt.Errorf("insert into resource history: %w", err)
+	}
 
-		// 5. Update the RV in both resource and resource_history
-		if _, err = dbutil.Exec(ctx, tx, sqlResourceHistoryUpdateRV, sqlResourceUpdateRVRequest{
-			SQLTemplate:     sqltemplate.New(b.dialect),
-			GUID:            guid,
-			ResourceVersion: rv,
-		}); err != nil {
-			return fmt.Errorf("update resource_history rv: %w", err)
-		}
+	// 3. TODO: Rebuild the whole folder tree structure if we're creating a folder
 
-		if _, err = dbutil.Exec(ctx, tx, sqlResourceUpdateRV, sqlResourceUpdateRVRequest{
-			SQLTemplate:     sqltemplate.New(b.dialect),
-			GUID:            guid,
-			ResourceVersion: rv,
-		}); err != nil {
-			return fmt.Errorf("update resource rv: %w", err)
-		}
-		newVersion = rv
+	// 4. Atomically increment resource version for this kind
+	rv, err := resourceVersionAtomicInc(ctx, tx, b.dialect, event.Key)
+	if err != nil {
+		return 0, fmt.Errorf("increment resource version: %w", err)
+	}
 
-		return nil
-	})
+	// 5. Update the RV in both resource and resource_history
+	if _, err = dbutil.Exec(ctx, tx, sqlResourceHistoryUpdateRV, sqlResourceUpdateRVRequest{
+		SQLTemplate:     sqltemplate.New(b.dialect),
+		GUID:            guid,
+		ResourceVersion: rv,
+	}); err != nil {
+		return 0, fmt.Errorf("update resource_history rv: %w", err)
+	}
 
-	return newVersion, err
+	if _, err = dbutil.Exec(ctx, tx, sqlResourceUpdateRV, sqlResourceUpdateRVRequest{
+		SQLTemplate:     sqltemplate.New(b.dialect),
+		GUID:            guid,
+		ResourceVersion: rv,
+	}); err != nil {
+		return 0, fmt.Errorf("update resource rv: %w", err)
+	}
+	return rv, nil
 }
 
 func (b *backend) update(ctx context.Context, event resource.WriteEvent) (int64, error) {
 	ctx, span := b.tracer.Start(ctx, trace_prefix+"Update")
 	defer span.End()
 	var newVersion int64
-	guid := uuid.New().String()
 	err := b.db.WithTx(ctx, ReadCommitted, func(ctx context.Context, tx db.Tx) error {
-		// TODO: Set the Labels
-
-		// 1. Update resource
-		_, err := dbutil.Exec(ctx, tx, sqlResourceUpdate, sqlResourceRequest{
-			SQLTemplate: sqltemplate.New(b.dialect),
-			WriteEvent:  event,
-			GUID:        guid,
-		})
-		if err != nil {
-			return fmt.Errorf("initial resource update: %w", err)
-		}
+		var err error
+		newVersion, err = b.updateTx(ctx, tx, event)
+		return err
+	})
+	return newVersion, err
+}
 
-		// 2. Insert into resource history
-		if _, err := dbutil.Exec(ctx, tx, sqlResourceHistoryInsert, sqlResourceRequest{
-			SQLTemplate: sqltemplate.New(b.dialect),
-			WriteEvent:  event,
-			GUID:        guid,
-		}); err != nil {
-			return fmt.Errorf("insert into resource history: %w", err)
-		}
+func (b *backend) updateTx(ctx context.Context, tx db.Tx, event resource.WriteEvent) (int64, error) {
+	guid := uuid.New().String()
+	// TODO: Set the Labels
 
-		// 3. TODO: Rebuild the whole folder tree structure if we're creating a folder
+	// 1. Update resource
+	_, err := dbutil.Exec(ctx, tx, sqlResourceUpdate, sqlResourceRequest{
+		SQLTemplate: sqltemplate.New(b.dialect),
+		WriteEvent:  event,
+		GUID:        guid,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("initial resource update: %w", err)
+	}
 
-		// 4. Atomically increment resource version for this kind
-		rv, err := resourceVersionAtomicInc(ctx, tx, b.dialect, event.Key)
-		if err != nil {
-			return fmt.Errorf("increment resource version: %w", err)
-		}
+	// 2. Insert into resource history
+	if _, err := dbutil.Exec(ctx, tx, sqlResourceHistoryInsert, sqlResourceRequest{
+		SQLTemplate: sqltemplate.New(b.dialect),
+		WriteEvent:  event,
+		GUID:        guid,
+	}); err != nil {
+		return 0, fmt.Errorf("insert into resource history: %w", err)
+	}
 
-		// 5. Update the RV in both resource and resource_history
-		if _, err = dbutil.Exec(ctx, tx, sqlResourceHistoryUpdateRV, sqlResourceUpdateRVRequest{
-			SQLTemplate:     sqltemplate.New(b.dialect),
-			GUID:            guid,
-			ResourceVersion: rv,
-		}); err != nil {
-			return fmt.Errorf("update history rv: %w", err)
-		}
+	// 3. TODO: Rebuild the whole folder tree structure if we're creating a folder
 
-		if _, err = dbutil.Exec(ctx, tx, sqlResourceUpdateRV, sqlResourceUpdateRVRequest{
-			SQLTemplate:     sqltemplate.New(b.dialect),
-			GUID:            guid,
-			ResourceVersion: rv,
-		}); err != nil {
-			return fmt.Errorf("update resource rv: %w", err)
-		}
-		newVersion = rv
+	// 4. Atomically increment resource version for this kind
+	rv, err := resourceVersionAtomicInc(ctx, tx, b.dialect, event.Key)
+	if err != nil {
+		return 0, fmt.Errorf("increment resource version: %w", err)
+	}
 
-		return nil
-	})
+	// 5. Update the RV in both resource and resource_history
+	if _, err = dbutil.Exec(ctx, tx, sqlResourceHistoryUpdateRV, sqlResourceUpdateRVRequest{
+		SQLTemplate:     sqltemplate.New(b.dialect),
+		GUID:            guid,
+		ResourceVersion: rv,
+	}); err != nil {
+		return 0, fmt.Errorf("update history rv: %w", err)
+	}
 
-	return newVersion, err
+	if _, err = dbutil.Exec(ctx, tx, sqlResourceUpdateRV, sqlResourceUpdateRVRequest{
+		SQLTemplate:     sqltemplate.New(b.dialect),
+		GUID:            guid,
+		ResourceVersion: rv,
+	}); err != nil {
+		return 0, fmt.Errorf("update resource rv: %w", err)
+	}
+	return rv, nil
 }
 
 func (b *backend) delete(ctx context.Context, event resource.WriteEvent) (int64, error) {
 	ctx, span := b.tracer.Start(ctx, trace_prefix+"Delete")
 	defer span.End()
 	var newVersion int64
-	guid := uuid.New().String()
-
 	err := b.db.WithTx(ctx, ReadCommitted, func(ctx context.Context, tx db.Tx) error {
-		// TODO: Set the Labels
-
-		// 1. delete from resource
-		_, err := dbutil.Exec(ctx, tx, sqlResourceDelete, sqlResourceRequest{
-			SQLTemplate: sqltemplate.New(b.dialect),
-			WriteEvent:  event,
-			GUID:        guid,
-		})
-		if err != nil {
-			return fmt.Errorf("delete resource: %w", err)
-		}
+		var err error
+		newVersion, err = b.deleteTx(ctx, tx, event)
+		return err
+	})
+	return newVersion, err
+}
 
-		// 2. Add event to resource history
-		if _, err := dbutil.Exec(ctx, tx, sqlResourceHistoryInsert, sqlResourceRequest{
-			SQLTemplate: sqltemplate.New(b.dialect),
-			WriteEvent:  event,
-			GUID:        guid,
-		}); err != nil {
-			return fmt.Errorf("insert into resource history: %w", err)
-		}
+func (b *backend) deleteTx(ctx context.Context, tx db.Tx, event resource.WriteEvent) (int64, error) {
+	guid := uuid.New().String()
+	// TODO: Set the Labels
 
-		// 3. TODO: Rebuild the whole folder tree structure if we're creating a folder
+	// 1. delete from resource
+	_, err := dbutil.Exec(ctx, tx, sqlResourceDelete, sqlResourceRequest{
+		SQLTemplate: sqltemplate.New(b.dialect),
+		WriteEvent:  event,
+		GUID:        guid,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("delete resource: %w", err)
+	}
 
-		// 4. Atomically increment resource version for this kind
-		rv, err := resourceVersionAtomicInc(ctx, tx, b.dialect, event.Key)
-		if err != nil {
-			return fmt.Errorf("increment resource version: %w", err)
-		}
+	// 2. Add event to resource history
+	if _, err := dbutil.Exec(ctx, tx, sqlResourceHistoryInsert, sqlResourceRequest{
+		SQLTemplate: sqltemplate.New(b.dialect),
+		WriteEvent:  event,
+		GUID:        guid,
+	}); err != nil {
+		return 0, fmt.Errorf("insert into resource history: %w", err)
+	}
 
-		// 5. Update the RV in resource_history
-		if _, err = dbutil.Exec(ctx, tx, sqlResourceHistoryUpdateRV, sqlResourceUpdateRVRequest{
-			SQLTemplate:     sqltemplate.New(b.dialect),
-			GUID:            guid,
-			ResourceVersion: rv,
-		}); err != nil {
-			return fmt.Errorf("update history rv: %w", err)
-		}
-		newVersion = rv
+	// 3. TODO: Rebuild the whole folder tree structure if we're creating a folder
 
-		return nil
-	})
+	// 4. Atomically increment resource version for this kind
+	rv, err := resourceVersionAtomicInc(ctx, tx, b.dialect, event.Key)
+	if err != nil {
+		return 0, fmt.Errorf("increment resource version: %w", err)
+	}
 
-	return newVersion, err
+	// 5. Update the RV in resource_history
+	if _, err = dbutil.Exec(ctx, tx, sqlResourceHistoryUpdateRV, sqlResourceUpdateRVRequest{
+		SQLTemplate:     sqltemplate.New(b.dialect),
+		GUID:            guid,
+		ResourceVersion: rv,
+	}); err != nil {
+		return 0, fmt.Errorf("update history rv: %w", err)
+	}
+	return rv, nil
 }
 
 func (b *backend) ReadResource(ctx context.Context, req *resource.ReadRequest) *resource.ReadResponse {
@@ -494,6 +529,95 @@ func (b *backend) listAtRevision(ctx context.Context, req *resource.ListRequest,
 	return iter.listRV, err
 }
 
+// Export implements resource.BatchBackend.
+func (b *backend) Export(ctx context.Context, req *resource.ExportRequest, cb func(*resource.ExportResponse) error) error {
+	_, span := b.tracer.Start(ctx, trace_prefix+"Export")
+	defer span.End()
+
+	if req.Key == nil || req.Key.Namespace == "" {
+		return fmt.Errorf("missing namespace")
+	}
+
+	return b.db.WithTx(ctx, ReadCommittedRO, func(ctx context.Context, tx db.Tx) error {
+		rows, err := dbutil.QueryRows(ctx, tx, sqlResourceExport, sqlResourceExportRequest{
+			SQLTemplate: sqltemplate.New(b.dialect),
+			Request:     req,
+		})
+		if rows != nil {
+			defer func() {
+				if err := rows.Close(); err != nil {
+					b.log.Warn("export error closing rows", "error", err)
+				}
+			}()
+		}
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			var action int
+			item := &resource.ExportResponse{
+				Key: &resource.ResourceKey{Namespace: req.Key.Namespace},
+			}
+			if err := rows.Scan(&item.ResourceVersion, &item.Key.Group, &item.Key.Resource, &item.Key.Name, &item.Value, &action); err != nil {
+				return err
+			}
+			item.Action = resource.WatchEvent_Type(action)
+			if !req.WithHistory {
+				// the latest values are created in the target store
+				item.Action = resource.WatchEvent_ADDED
+			}
+			if err := cb(item); err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	})
+}
+
+// SearchIterator implements resource.SearchBackend, only the values containing every term are returned.
+func (b *backend) SearchIterator(ctx context.Context, req *resource.SearchRequest, cb func(resource.ListIterator) error) (int64, error) {
+	_, span := b.tracer.Start(ctx, trace_prefix+"Search")
+	defer span.End()
+
+	if req.Key == nil || req.Key.Group == "" || req.Key.Resource == "" {
+		return 0, fmt.Errorf("missing group or resource")
+	}
+
+	iter := &listIter{}
+	err := b.db.WithTx(ctx, ReadCommittedRO, func(ctx context.Context, tx db.Tx) error {
+		var err error
+		iter.listRV, err = fetchLatestRV(ctx, tx, b.dialect, req.Key.Group, req.Key.Resource)
+		if err != nil {
+			return err
+		}
+
+		searchReq := sqlResourceSearchRequest{
+			SQLTemplate: sqltemplate.New(b.dialect),
+			Request:     req,
+		}
+		for _, term := range resource.PrefilterTerms(req.Query) {
+			searchReq.Terms = append(searchReq.Terms, "%"+term+"%")
+		}
+
+		rows, err := dbutil.QueryRows(ctx, tx, sqlResourceSearch, searchReq)
+		if rows != nil {
+			defer func() {
+				if err := rows.Close(); err != nil {
+					b.log.Warn("search error closing rows", "error", err)
+				}
+			}()
+		}
+		if err != nil {
+			return err
+		}
+
+		iter.rows = rows
+		return cb(iter)
+	})
+	return iter.listRV, err
+}
+
 func (b *backend) WatchWriteEvents(ctx context.Context) (<-chan *resource.WrittenEvent, error) {
 	// Get the latest RV
 	since, err := b.listLatestRVs(ctx)
@@ -578,3 +578,137 @@ func TestBackend_delete(t *testing.T) {
 		require.ErrorContains(t, err, "update history rv")
 	})
 }
+
+func TestBackend_SearchIterator(t *testing.T) {
+	t.Parallel()
+	req := &resource.SearchRequest{
+		Key: &resource.ResourceKey{
+			Group:    resKey.Group,
+			Resource: resKey.Resource,
+		},
+		Query: "CPU usage (%)",
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		t.Parallel()
+		b, ctx := setupBackendTest(t)
+
+		b.SQLMock.ExpectBegin()
+		b.QueryWithResult("select resource_version from resource_version", 1, Rows{{int64(5)}})
+		b.QueryWithResult("select resource_version namespace name value from resource lower value like lower value like order by", 4, Rows{
+			{int64(3), "ns", "nm", []byte(`{}`)},
+		})
+		b.SQLMock.ExpectCommit()
+
+		var names []string
+		rv, err := b.SearchIterator(ctx, req, func(iter resource.ListIterator) error {
+			for iter.Next() {
+				names = append(names, iter.Name())
+			}
+			return iter.Error()
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(5), rv)
+		require.Equal(t, []string{"nm"}, names)
+	})
+
+	t.Run("missing group", func(t *testing.T) {
+		t.Parallel()
+		b, ctx := setupBackendTest(t)
+
+		_, err := b.SearchIterator(ctx, &resource.SearchRequest{Key: &resource.ResourceKey{}}, func(resource.ListIterator) error {
+			return nil
+		})
+		require.ErrorContains(t, err, "missing group or resource")
+	})
+}
+
+func TestBackend_WriteEvents(t *testing.T) {
+	t.Parallel()
+	events := []resource.WriteEvent{
+		{Type: resource.WatchEvent_ADDED, Key: resKey},
+		{Type: resource.WatchEvent_DELETED, Key: resKey},
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		t.Parallel()
+		b, ctx := setupBackendTest(t)
+
+		b.SQLMock.ExpectBegin()
+		b.ExecWithResult("insert resource")
+		b.ExecWithResult("insert resource_history")
+		expectSuccessfulResourceVersionAtomicInc(t, b) // returns RV=1
+		b.ExecWithResult("update resource_history")
+		b.ExecWithResult("update resource")
+		b.ExecWithResult("delete resource")
+		b.ExecWithResult("insert resource_history")
+		b.QueryWithResult("select resource_version for update", 1, Rows{{1}})
+		b.ExecWithResult("update resource_version")
+		b.ExecWithResult("update resource_history")
+		b.SQLMock.ExpectCommit()
+
+		v, err := b.WriteEvents(ctx, events)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), v)
+	})
+
+	t.Run("an error rolls back every event", func(t *testing.T) {
+		t.Parallel()
+		b, ctx := setupBackendTest(t)
+
+		b.SQLMock.ExpectBegin()
+		b.ExecWithResult("insert resource")
+		b.ExecWithResult("insert resource_history")
+		expectSuccessfulResourceVersionAtomicInc(t, b)
+		b.ExecWithResult("update resource_history")
+		b.ExecWithResult("update resource")
+		b.ExecWithErr("delete resource", errTest)
+		b.SQLMock.ExpectRollback()
+
+		v, err := b.WriteEvents(ctx, events)
+		require.Zero(t, v)
+		require.ErrorContains(t, err, "write rs/ns/nm: delete resource")
+	})
+}
+
+func TestBackend_Export(t *testing.T) {
+	t.Parallel()
+
+	t.Run("history keeps the actions", func(t *testing.T) {
+		t.Parallel()
+		b, ctx := setupBackendTest(t)
+
+		b.SQLMock.ExpectBegin()
+		b.QueryWithResult("select resource_version group resource name value action from resource_history", 6, Rows{
+			{int64(1), "gr", "rs", "nm", []byte(`{}`), int64(resource.WatchEvent_ADDED)},
+			{int64(2), "gr", "rs", "nm", []byte(`{}`), int64(resource.WatchEvent_DELETED)},
+		})
+		b.SQLMock.ExpectCommit()
+
+		var items []*resource.ExportResponse
+		err := b.Export(ctx, &resource.ExportRequest{
+			Key:         &resource.ResourceKey{Namespace: "ns"},
+			WithHistory: true,
+		}, func(item *resource.ExportResponse) error {
+			items = append(items, item)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		require.Equal(t, "gr", items[1].Key.Group)
+		require.Equal(t, "ns", items[1].Key.Namespace)
+		require.Equal(t, "nm", items[1].Key.Name)
+		require.Equal(t, resource.WatchEvent_ADDED, items[0].Action)
+		require.Equal(t, resource.WatchEvent_DELETED, items[1].Action)
+	})
+
+	t.Run("missing namespace", func(t *testing.T) {
+		t.Parallel()
+		b, ctx := setupBackendTest(t)
+
+		err := b.Export(ctx, &resource.ExportRequest{Key: &resource.ResourceKey{}}, func(*resource.ExportResponse) error {
+			return nil
+		})
+		require.ErrorContains(t, err, "missing namespace")
+	})
+}
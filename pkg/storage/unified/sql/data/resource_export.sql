@@ -0,0 +1,22 @@
+SELECT
+    {{ .Ident "resource_version" }},
+    {{ .Ident "group" }},
+    {{ .Ident "resource" }},
+    {{ .Ident "name" }},
+    {{ .Ident "value" }},
+    {{ .Ident "action" }}
+    {{ if .Request.WithHistory }}
+    FROM {{ .Ident "resource_history" }}
+    {{ else }}
+    FROM {{ .Ident "resource" }}
+    {{ end }}
+    WHERE 1 = 1
+        AND {{ .Ident "namespace" }} = {{ .Arg .Request.Key.Namespace }}
+        {{ if .Request.Key.Group }}
+        AND {{ .Ident "group" }}     = {{ .Arg .Request.Key.Group }}
+        {{ end }}
+        {{ if .Request.Key.Resource }}
+        AND {{ .Ident "resource" }}  = {{ .Arg .Request.Key.Resource }}
+        {{ end }}
+    ORDER BY {{ .Ident "group" }} ASC, {{ .Ident "resource" }} ASC, {{ .Ident "name" }} ASC, {{ .Ident "resource_version" }} ASC
+;
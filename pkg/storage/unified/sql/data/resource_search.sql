@@ -0,0 +1,17 @@
+SELECT
+    {{ .Ident "resource_version" }},
+    {{ .Ident "namespace" }},
+    {{ .Ident "name" }},
+    {{ .Ident "value" }}
+    FROM {{ .Ident "resource" }}
+    WHERE 1 = 1
+        {{ if .Request.Key.Namespace }}
+        AND {{ .Ident "namespace" }} = {{ .Arg .Request.Key.Namespace }}
+        {{ end }}
+        AND {{ .Ident "group" }}     = {{ .Arg .Request.Key.Group }}
+        AND {{ .Ident "resource" }}  = {{ .Arg .Request.Key.Resource }}
+        {{ range .Terms }}
+        AND LOWER({{ $.Ident "value" }}) LIKE {{ $.Arg . }}
+        {{ end }}
+    ORDER BY {{ .Ident "namespace" }} ASC, {{ .Ident "name" }} ASC
+;
@@ -32,6 +32,8 @@ var (
 	sqlResourceUpdate          = mustTemplate("resource_update.sql")
 	sqlResourceRead            = mustTemplate("resource_read.sql")
 	sqlResourceList            = mustTemplate("resource_list.sql")
+	sqlResourceSearch          = mustTemplate("resource_search.sql")
+	sqlResourceExport          = mustTemplate("resource_export.sql")
 	sqlResourceHistoryList     = mustTemplate("resource_history_list.sql")
 	sqlResourceUpdateRV        = mustTemplate("resource_update_rv.sql")
 	sqlResourceHistoryRead     = mustTemplate("resource_history_read.sql")
@@ -121,6 +123,28 @@ func (r sqlResourceListRequest) Validate() error {
 	return nil // TODO
 }
 
+// Search
+type sqlResourceSearchRequest struct {
+	*sqltemplate.SQLTemplate
+	Request *resource.SearchRequest
+	// LIKE patterns the value must match
+	Terms []string
+}
+
+func (r sqlResourceSearchRequest) Validate() error {
+	return nil // TODO
+}
+
+// Export
+type sqlResourceExportRequest struct {
+	*sqltemplate.SQLTemplate
+	Request *resource.ExportRequest
+}
+
+func (r sqlResourceExportRequest) Validate() error {
+	return nil // TODO
+}
+
 type historyListRequest struct {
 	ResourceVersion, Limit, Offset int64
 	Options                        *resource.ListOptions
@@ -169,6 +169,69 @@ func TestQueries(t *testing.T) {
 			},
 		},
 
+		sqlResourceSearch: {
+			{
+				Name: "filter on namespace and terms",
+				Data: &sqlResourceSearchRequest{
+					SQLTemplate: new(sqltemplate.SQLTemplate),
+					Request: &resource.SearchRequest{
+						Key: &resource.ResourceKey{
+							Namespace: "ns",
+							Group:     "group",
+							Resource:  "resource",
+						},
+					},
+					Terms: []string{"%cpu%", "%usage%"},
+				},
+				Expected: expected{
+					"resource_search_mysql_sqlite.sql": dialects{
+						sqltemplate.MySQL,
+						sqltemplate.SQLite,
+					},
+				},
+			},
+		},
+
+		sqlResourceExport: {
+			{
+				Name: "latest values in a namespace",
+				Data: &sqlResourceExportRequest{
+					SQLTemplate: new(sqltemplate.SQLTemplate),
+					Request: &resource.ExportRequest{
+						Key: &resource.ResourceKey{
+							Namespace: "ns",
+						},
+					},
+				},
+				Expected: expected{
+					"resource_export_mysql_sqlite.sql": dialects{
+						sqltemplate.MySQL,
+						sqltemplate.SQLite,
+					},
+				},
+			},
+			{
+				Name: "history of a resource",
+				Data: &sqlResourceExportRequest{
+					SQLTemplate: new(sqltemplate.SQLTemplate),
+					Request: &resource.ExportRequest{
+						Key: &resource.ResourceKey{
+							Namespace: "ns",
+							Group:     "group",
+							Resource:  "resource",
+						},
+						WithHistory: true,
+					},
+				},
+				Expected: expected{
+					"resource_export_history_mysql_sqlite.sql": dialects{
+						sqltemplate.MySQL,
+						sqltemplate.SQLite,
+					},
+				},
+			},
+		},
+
 		sqlResourceHistoryList: {
 			{
 				Name: "single path",
@@ -0,0 +1,5 @@
+SELECT "resource_version", "group", "resource", "name", "value", "action"
+    FROM "resource_history"
+    WHERE 1 = 1 AND "namespace" = ? AND "group" = ? AND "resource" = ?
+    ORDER BY "group" ASC, "resource" ASC, "name" ASC, "resource_version" ASC
+;
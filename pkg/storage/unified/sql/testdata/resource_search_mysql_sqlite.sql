@@ -0,0 +1,6 @@
+SELECT "resource_version", "namespace", "name", "value"
+    FROM "resource"
+    WHERE 1 = 1 AND "namespace" = ? AND "group" = ? AND "resource" = ?
+        AND LOWER("value") LIKE ? AND LOWER("value") LIKE ?
+    ORDER BY "namespace" ASC, "name" ASC
+;
@@ -0,0 +1,53 @@
+package graphite
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+)
+
+// CheckHealth renders a constant series, which only succeeds if Graphite is reachable and can evaluate functions.
+func (s *Service) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
+	logger := logger.FromContext(ctx)
+
+	dsInfo, err := s.getDSInfo(ctx, req.PluginContext)
+	if err != nil {
+		logger.Error("Failed to get data source info", "error", err)
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusUnknown,
+			Message: "Failed to get data source info",
+		}, err
+	}
+
+	params := url.Values{
+		"target": []string{"constantLine(100)"},
+		"from":   []string{"-5min"},
+		"until":  []string{"now"},
+		"format": []string{"json"},
+	}
+	raw, err := s.doResourceRequest(ctx, dsInfo, http.MethodPost, "render", params)
+	if err != nil {
+		logger.Warn("Graphite health check failed", "error", err)
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusError,
+			Message: "Graphite health check failed: " + err.Error(),
+		}, nil
+	}
+
+	var series []TargetResponseDTO
+	if err := json.Unmarshal(raw, &series); err != nil {
+		logger.Warn("Failed to parse Graphite health check response", "error", err)
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusError,
+			Message: "Graphite returned an invalid response: " + err.Error(),
+		}, nil
+	}
+
+	return &backend.CheckHealthResult{
+		Status:  backend.HealthStatusOk,
+		Message: "Data source is working",
+	}, nil
+}
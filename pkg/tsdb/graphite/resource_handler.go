@@ -0,0 +1,286 @@
+package graphite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+// infinityDefault matches the invalid JSON returned by the /functions endpoint of Graphite 1.1.7.
+// See https://github.com/graphite-project/graphite-web/issues/2609
+// The frontend replaces it with 1e9999, which JavaScript parses as Infinity but most JSON decoders reject, so the
+// resource returns the string "Infinity" instead.
+var infinityDefault = regexp.MustCompile(`"default": ?Infinity`)
+
+func (s *Service) registerRoutes() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics/find", handleResourceReq(s, s.handleMetricsFind))
+	mux.HandleFunc("/tags/autoComplete/tags", handleResourceReq(s, s.handleTagsAutoComplete))
+	mux.HandleFunc("/tags/autoComplete/values", handleResourceReq(s, s.handleTagValuesAutoComplete))
+	mux.HandleFunc("/functions", s.handleFunctions)
+	return mux
+}
+
+func (s *Service) CallResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	return s.resourceHandler.CallResource(ctx, req, sender)
+}
+
+// handleResourceReq decodes the JSON body of a resource request, calls the handler with the data source of the request
+// and writes its result as JSON.
+func handleResourceReq[T any, R any](s *Service, handler func(context.Context, *datasourceInfo, T) (R, error)) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		logger := logger.FromContext(ctx)
+		if req.Method != http.MethodPost {
+			writeError(rw, logger, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", req.Method))
+			return
+		}
+
+		var body T
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+			writeError(rw, logger, http.StatusBadRequest, fmt.Errorf("failed to decode request body: %w", err))
+			return
+		}
+
+		dsInfo, err := s.getDSInfo(ctx, backend.PluginConfigFromContext(ctx))
+		if err != nil {
+			writeError(rw, logger, http.StatusInternalServerError, fmt.Errorf("failed to get data source info: %w", err))
+			return
+		}
+
+		result, err := handler(ctx, dsInfo, body)
+		if err != nil {
+			writeError(rw, logger, errorStatus(err), err)
+			return
+		}
+		writeJSON(rw, logger, http.StatusOK, result)
+	}
+}
+
+func (s *Service) handleMetricsFind(ctx context.Context, dsInfo *datasourceInfo, body MetricsFindRequest) ([]MetricsFindResult, error) {
+	if body.Query == "" {
+		return nil, newRequestError(http.StatusBadRequest, errors.New("query is required"))
+	}
+	params := url.Values{"query": []string{body.Query}}
+	setRange(params, body.From, body.Until)
+
+	raw, err := s.doResourceRequest(ctx, dsInfo, http.MethodPost, "metrics/find", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics []graphiteMetric
+	if err := json.Unmarshal(raw, &metrics); err != nil {
+		return nil, fmt.Errorf("failed to parse metrics response: %w", err)
+	}
+	result := make([]MetricsFindResult, 0, len(metrics))
+	for _, m := range metrics {
+		result = append(result, MetricsFindResult{
+			Text:       m.Text,
+			ID:         m.ID,
+			Expandable: m.Expandable != 0,
+			Leaf:       m.Leaf != 0,
+		})
+	}
+	return result, nil
+}
+
+func (s *Service) handleTagsAutoComplete(ctx context.Context, dsInfo *datasourceInfo, body TagsAutoCompleteRequest) ([]string, error) {
+	params := url.Values{"expr": body.Expressions}
+	if body.TagPrefix != "" {
+		params.Set("tagPrefix", body.TagPrefix)
+	}
+	setLimit(params, body.Limit)
+	setRange(params, body.From, body.Until)
+	return s.autoComplete(ctx, dsInfo, "tags/autoComplete/tags", params)
+}
+
+func (s *Service) handleTagValuesAutoComplete(ctx context.Context, dsInfo *datasourceInfo, body TagValuesAutoCompleteRequest) ([]string, error) {
+	if body.Tag == "" {
+		return nil, newRequestError(http.StatusBadRequest, errors.New("tag is required"))
+	}
+	params := url.Values{"expr": body.Expressions, "tag": []string{body.Tag}}
+	if body.ValuePrefix != "" {
+		params.Set("valuePrefix", body.ValuePrefix)
+	}
+	setLimit(params, body.Limit)
+	setRange(params, body.From, body.Until)
+	return s.autoComplete(ctx, dsInfo, "tags/autoComplete/values", params)
+}
+
+func (s *Service) autoComplete(ctx context.Context, dsInfo *datasourceInfo, endpoint string, params url.Values) ([]string, error) {
+	raw, err := s.doResourceRequest(ctx, dsInfo, http.MethodGet, endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0)
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse autocomplete response: %w", err)
+	}
+	return result, nil
+}
+
+// handleFunctions returns the function definitions of the Graphite instance as they are, except for infinite default
+// values that are returned as the string "Infinity".
+func (s *Service) handleFunctions(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logger := logger.FromContext(ctx)
+	if req.Method != http.MethodGet {
+		writeError(rw, logger, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", req.Method))
+		return
+	}
+
+	dsInfo, err := s.getDSInfo(ctx, backend.PluginConfigFromContext(ctx))
+	if err != nil {
+		writeError(rw, logger, http.StatusInternalServerError, fmt.Errorf("failed to get data source info: %w", err))
+		return
+	}
+
+	raw, err := s.doResourceRequest(ctx, dsInfo, http.MethodGet, "functions", nil)
+	if err != nil {
+		writeError(rw, logger, errorStatus(err), err)
+		return
+	}
+	raw = infinityDefault.ReplaceAll(raw, []byte(`"default": "Infinity"`))
+
+	var functions map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &functions); err != nil {
+		writeError(rw, logger, http.StatusInternalServerError, fmt.Errorf("failed to parse functions response: %w", err))
+		return
+	}
+	writeJSON(rw, logger, http.StatusOK, functions)
+}
+
+// doResourceRequest sends a request to an endpoint of the Graphite HTTP API and returns the body of the response.
+// Parameters are sent as a form for POST requests and in the query string otherwise.
+func (s *Service) doResourceRequest(ctx context.Context, dsInfo *datasourceInfo, method string, endpoint string, params url.Values) ([]byte, error) {
+	logger := logger.FromContext(ctx)
+	u, err := url.Parse(dsInfo.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse data source URL: %w", err)
+	}
+	u.Path = path.Join(u.Path, endpoint)
+
+	var body io.Reader
+	if method == http.MethodPost {
+		body = strings.NewReader(params.Encode())
+	} else {
+		u.RawQuery = params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	ctx, span := s.tracer.Start(ctx, "graphite resource")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("endpoint", endpoint),
+		attribute.Int64("datasource_id", dsInfo.Id),
+	)
+	s.tracer.Inject(ctx, req.Header, span)
+
+	res, err := dsInfo.HTTPClient.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			logger.Warn("Failed to close response body", "error", err)
+		}
+	}()
+	span.SetAttributes(attribute.Int("graphite.response.code", res.StatusCode))
+
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode/100 != 2 {
+		logger.Info("Resource request failed", "endpoint", endpoint, "status", res.Status, "body", string(raw))
+		status := http.StatusBadGateway
+		if res.StatusCode/100 == 4 {
+			status = res.StatusCode
+		}
+		return nil, newRequestError(status, fmt.Errorf("request failed, status: %s", res.Status))
+	}
+	return raw, nil
+}
+
+func setRange(params url.Values, from, until string) {
+	if from != "" {
+		params.Set("from", from)
+	}
+	if until != "" {
+		params.Set("until", until)
+	}
+}
+
+func setLimit(params url.Values, limit int) {
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+}
+
+// requestError is an error of a resource request that is returned with the given status.
+type requestError struct {
+	status int
+	err    error
+}
+
+func newRequestError(status int, err error) requestError {
+	return requestError{status: status, err: err}
+}
+
+func (e requestError) Error() string {
+	return e.err.Error()
+}
+
+func (e requestError) Unwrap() error {
+	return e.err
+}
+
+// errorStatus returns the status of the response to a resource request that failed with err.
+func errorStatus(err error) int {
+	var reqErr requestError
+	if errors.As(err, &reqErr) {
+		return reqErr.status
+	}
+	return http.StatusInternalServerError
+}
+
+func writeJSON(rw http.ResponseWriter, logger log.Logger, status int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		logger.Error("Failed to marshal resource response", "error", err)
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	if _, err := rw.Write(body); err != nil {
+		logger.Error("Failed to write resource response", "error", err)
+	}
+}
+
+func writeError(rw http.ResponseWriter, logger log.Logger, status int, err error) {
+	writeJSON(rw, logger, status, map[string]string{"message": err.Error()})
+}
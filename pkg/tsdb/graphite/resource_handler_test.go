@@ -0,0 +1,177 @@
+package graphite
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/resource/httpadapter"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+func TestCallResource(t *testing.T) {
+	var lastRequest *http.Request
+	var lastForm url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		require.NoError(t, req.ParseForm())
+		lastRequest = req
+		lastForm = req.Form
+		switch req.URL.Path {
+		case "/metrics/find":
+			_, _ = rw.Write([]byte(`[{"text":"servers","id":"prod.servers","expandable":1,"leaf":0},{"text":"cpu","id":"prod.cpu","expandable":0,"leaf":1}]`))
+		case "/tags/autoComplete/tags":
+			_, _ = rw.Write([]byte(`["dc","host"]`))
+		case "/tags/autoComplete/values":
+			_, _ = rw.Write([]byte(`["eu-1","eu-2"]`))
+		case "/functions":
+			_, _ = rw.Write([]byte(`{"movingAverage":{"name":"movingAverage","params":[{"name":"windowSize","default": Infinity}]}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	service := newTestService(srv)
+	call := func(t *testing.T, method, path, body string) (int, []byte) {
+		t.Helper()
+		var res *backend.CallResourceResponse
+		err := service.CallResource(context.Background(), &backend.CallResourceRequest{
+			Method: method,
+			Path:   path,
+			URL:    path,
+			Body:   []byte(body),
+		}, backend.CallResourceResponseSenderFunc(func(r *backend.CallResourceResponse) error {
+			res = r
+			return nil
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		return res.Status, res.Body
+	}
+
+	t.Run("metrics/find returns the nodes of the metric tree", func(t *testing.T) {
+		status, body := call(t, http.MethodPost, "metrics/find", `{"query":"prod.*","from":"-1h"}`)
+		require.Equal(t, http.StatusOK, status)
+		require.JSONEq(t, `[{"text":"servers","id":"prod.servers","expandable":true,"leaf":false},{"text":"cpu","id":"prod.cpu","expandable":false,"leaf":true}]`, string(body))
+		require.Equal(t, http.MethodPost, lastRequest.Method)
+		require.Equal(t, "prod.*", lastForm.Get("query"))
+		require.Equal(t, "-1h", lastForm.Get("from"))
+		require.Empty(t, lastForm.Get("until"))
+	})
+
+	t.Run("metrics/find requires a query", func(t *testing.T) {
+		status, body := call(t, http.MethodPost, "metrics/find", `{}`)
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Contains(t, string(body), "query is required")
+	})
+
+	t.Run("tags/autoComplete/tags returns the tag names", func(t *testing.T) {
+		status, body := call(t, http.MethodPost, "tags/autoComplete/tags", `{"expr":["name=cpu","dc=~eu.*"],"tagPrefix":"h","limit":10}`)
+		require.Equal(t, http.StatusOK, status)
+		require.JSONEq(t, `["dc","host"]`, string(body))
+		require.Equal(t, http.MethodGet, lastRequest.Method)
+		require.Equal(t, []string{"name=cpu", "dc=~eu.*"}, lastForm["expr"])
+		require.Equal(t, "h", lastForm.Get("tagPrefix"))
+		require.Equal(t, "10", lastForm.Get("limit"))
+	})
+
+	t.Run("tags/autoComplete/values returns the values of a tag", func(t *testing.T) {
+		status, body := call(t, http.MethodPost, "tags/autoComplete/values", `{"expr":["name=cpu"],"tag":"dc","valuePrefix":"eu"}`)
+		require.Equal(t, http.StatusOK, status)
+		require.JSONEq(t, `["eu-1","eu-2"]`, string(body))
+		require.Equal(t, "dc", lastForm.Get("tag"))
+		require.Equal(t, "eu", lastForm.Get("valuePrefix"))
+
+		status, _ = call(t, http.MethodPost, "tags/autoComplete/values", `{"expr":["name=cpu"]}`)
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("functions fixes infinite defaults", func(t *testing.T) {
+		status, body := call(t, http.MethodGet, "functions", "")
+		require.Equal(t, http.StatusOK, status)
+		require.JSONEq(t, `{"movingAverage":{"name":"movingAverage","params":[{"name":"windowSize","default":"Infinity"}]}}`, string(body))
+	})
+
+	t.Run("only POST is allowed for queries", func(t *testing.T) {
+		status, _ := call(t, http.MethodGet, "metrics/find", "")
+		require.Equal(t, http.StatusMethodNotAllowed, status)
+	})
+
+	t.Run("errors of Graphite are returned", func(t *testing.T) {
+		notFound := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			rw.WriteHeader(http.StatusInternalServerError)
+		}))
+		t.Cleanup(notFound.Close)
+		failing := newTestService(notFound)
+		var res *backend.CallResourceResponse
+		err := failing.CallResource(context.Background(), &backend.CallResourceRequest{
+			Method: http.MethodPost,
+			Path:   "metrics/find",
+			URL:    "metrics/find",
+			Body:   []byte(`{"query":"*"}`),
+		}, backend.CallResourceResponseSenderFunc(func(r *backend.CallResourceResponse) error {
+			res = r
+			return nil
+		}))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadGateway, res.Status)
+	})
+}
+
+func TestCheckHealth(t *testing.T) {
+	t.Run("healthy if Graphite renders the series", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			require.Equal(t, "/render", req.URL.Path)
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			form, err := url.ParseQuery(string(body))
+			require.NoError(t, err)
+			require.Equal(t, "constantLine(100)", form.Get("target"))
+			_, _ = rw.Write([]byte(`[{"target":"100","datapoints":[[100,1700000000]]}]`))
+		}))
+		t.Cleanup(srv.Close)
+
+		res, err := newTestService(srv).CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		require.Equal(t, backend.HealthStatusOk, res.Status)
+	})
+
+	t.Run("unhealthy if Graphite fails", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			rw.WriteHeader(http.StatusInternalServerError)
+		}))
+		t.Cleanup(srv.Close)
+
+		res, err := newTestService(srv).CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		require.Equal(t, backend.HealthStatusError, res.Status)
+	})
+}
+
+func newTestService(srv *httptest.Server) *Service {
+	s := &Service{
+		im:     serverInstanceManager{info: datasourceInfo{HTTPClient: srv.Client(), URL: srv.URL}},
+		tracer: tracing.InitializeTracerForTest(),
+	}
+	s.resourceHandler = httpadapter.New(s.registerRoutes())
+	return s
+}
+
+type serverInstanceManager struct {
+	info datasourceInfo
+}
+
+func (m serverInstanceManager) Get(_ context.Context, _ backend.PluginContext) (instancemgmt.Instance, error) {
+	return m.info, nil
+}
+
+func (m serverInstanceManager) Do(_ context.Context, _ backend.PluginContext, _ instancemgmt.InstanceCallbackFunc) error {
+	return nil
+}
@@ -13,3 +13,48 @@ type TargetResponseDTO struct {
 
 type DataTimePoint [2]null.Float
 type DataTimeSeriesPoints []DataTimePoint
+
+// MetricsFindRequest is the body of the /metrics/find resource.
+type MetricsFindRequest struct {
+	// Query is the metric pattern, e.g. "prod.servers.*".
+	Query string `json:"query"`
+	// From and Until are optional Graphite times, e.g. "-1h" or an epoch in seconds.
+	From  string `json:"from,omitempty"`
+	Until string `json:"until,omitempty"`
+}
+
+// MetricsFindResult is a node of the metric tree returned by the /metrics/find resource.
+type MetricsFindResult struct {
+	Text       string `json:"text"`
+	ID         string `json:"id"`
+	Expandable bool   `json:"expandable"`
+	Leaf       bool   `json:"leaf"`
+}
+
+// TagsAutoCompleteRequest is the body of the /tags/autoComplete/tags resource.
+type TagsAutoCompleteRequest struct {
+	// Expressions are the tag expressions the series must match, e.g. "name=cpu".
+	Expressions []string `json:"expr,omitempty"`
+	TagPrefix   string   `json:"tagPrefix,omitempty"`
+	Limit       int      `json:"limit,omitempty"`
+	From        string   `json:"from,omitempty"`
+	Until       string   `json:"until,omitempty"`
+}
+
+// TagValuesAutoCompleteRequest is the body of the /tags/autoComplete/values resource.
+type TagValuesAutoCompleteRequest struct {
+	Expressions []string `json:"expr,omitempty"`
+	Tag         string   `json:"tag"`
+	ValuePrefix string   `json:"valuePrefix,omitempty"`
+	Limit       int      `json:"limit,omitempty"`
+	From        string   `json:"from,omitempty"`
+	Until       string   `json:"until,omitempty"`
+}
+
+// graphiteMetric is a node of the metric tree as returned by Graphite, which uses integers for booleans.
+type graphiteMetric struct {
+	Text       string `json:"text"`
+	ID         string `json:"id"`
+	Expandable int    `json:"expandable"`
+	Leaf       int    `json:"leaf"`
+}
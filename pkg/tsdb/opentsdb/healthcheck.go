@@ -0,0 +1,39 @@
+package opentsdb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+)
+
+// CheckHealth asks OpenTSDB for a metric suggestion, like the frontend does to test the data source.
+func (s *Service) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
+	logger := logger.FromContext(ctx)
+	dsInfo, err := s.getDSInfo(ctx, req.PluginContext)
+	if err != nil {
+		logger.Error("Failed to get data source info", "error", err)
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusError,
+			Message: fmt.Sprintf("Failed to get data source info: %s", err),
+		}, nil
+	}
+
+	params := url.Values{
+		"type": []string{"metrics"},
+		"q":    []string{"cpu"},
+		"max":  []string{"1"},
+	}
+	if _, _, err := s.suggest(ctx, dsInfo, params); err != nil {
+		logger.Info("Health check failed", "error", err)
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusError,
+			Message: "OpenTSDB health check failed: " + err.Error(),
+		}, nil
+	}
+	return &backend.CheckHealthResult{
+		Status:  backend.HealthStatusOk,
+		Message: "Data source is working",
+	}, nil
+}
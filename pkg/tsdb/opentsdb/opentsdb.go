@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/datasource"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/resource/httpadapter"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
@@ -25,23 +27,42 @@ import (
 
 var logger = log.New("tsdb.opentsdb")
 
+// defaultLookupLimit is the maximum number of suggestions if the data source does not define it.
+const defaultLookupLimit = 1000
+
 type Service struct {
-	im instancemgmt.InstanceManager
+	im              instancemgmt.InstanceManager
+	resourceHandler backend.CallResourceHandler
 }
 
 func ProvideService(httpClientProvider httpclient.Provider) *Service {
-	return &Service{
+	s := &Service{
 		im: datasource.NewInstanceManager(newInstanceSettings(httpClientProvider)),
 	}
+	s.resourceHandler = httpadapter.New(s.registerRoutes())
+	return s
 }
 
 type datasourceInfo struct {
 	HTTPClient *http.Client
 	URL        string
+	// TSDBVersion is 1 for OpenTSDB <=2.1, 2 for ==2.2, 3 for ==2.3 and 4 for ==2.4.
+	TSDBVersion int
+	// TSDBResolution is 1 if timestamps are in seconds and 2 if they are in milliseconds.
+	TSDBResolution int
+	// LookupLimit is the maximum number of suggestions returned by OpenTSDB.
+	LookupLimit int
 }
 
 type DsAccess string
 
+// jsonData is the part of the data source settings used by the backend.
+type jsonData struct {
+	TSDBVersion    int `json:"tsdbVersion"`
+	TSDBResolution int `json:"tsdbResolution"`
+	LookupLimit    int `json:"lookupLimit"`
+}
+
 func newInstanceSettings(httpClientProvider httpclient.Provider) datasource.InstanceFactoryFunc {
 	return func(ctx context.Context, settings backend.DataSourceInstanceSettings) (instancemgmt.Instance, error) {
 		opts, err := settings.HTTPClientOptions(ctx)
@@ -54,42 +75,56 @@ func newInstanceSettings(httpClientProvider httpclient.Provider) datasource.Inst
 			return nil, err
 		}
 
+		var settingsData jsonData
+		if len(settings.JSONData) > 0 {
+			if err := json.Unmarshal(settings.JSONData, &settingsData); err != nil {
+				return nil, fmt.Errorf("error reading settings: %w", err)
+			}
+		}
+
 		model := &datasourceInfo{
-			HTTPClient: client,
-			URL:        settings.URL,
+			HTTPClient:     client,
+			URL:            settings.URL,
+			TSDBVersion:    settingsData.TSDBVersion,
+			TSDBResolution: settingsData.TSDBResolution,
+			LookupLimit:    settingsData.LookupLimit,
+		}
+		if model.TSDBVersion == 0 {
+			model.TSDBVersion = 1
+		}
+		if model.TSDBResolution == 0 {
+			model.TSDBResolution = 1
+		}
+		if model.LookupLimit <= 0 {
+			model.LookupLimit = defaultLookupLimit
 		}
 
 		return model, nil
 	}
 }
 
+// QueryData sends all the queries of the request, including annotation queries, to OpenTSDB in a single request and
+// maps the results back to the queries.
 func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
-	var tsdbQuery OpenTsdbQuery
-
 	logger := logger.FromContext(ctx)
 
-	q := req.Queries[0]
-
-	myRefID := q.RefID
-
-	tsdbQuery.Start = q.TimeRange.From.UnixNano() / int64(time.Millisecond)
-	tsdbQuery.End = q.TimeRange.To.UnixNano() / int64(time.Millisecond)
+	dsInfo, err := s.getDSInfo(ctx, req.PluginContext)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, query := range req.Queries {
-		metric := s.buildMetric(query)
-		tsdbQuery.Queries = append(tsdbQuery.Queries, metric)
+	tsdbQuery, targets := s.buildQuery(req.Queries)
+	if len(targets) == 0 {
+		return backend.NewQueryDataResponse(), nil
 	}
+	tsdbQuery.MsResolution = dsInfo.TSDBResolution == 2
+	tsdbQuery.ShowQuery = dsInfo.TSDBVersion >= 3
 
 	// TODO: Don't use global variable
 	if setting.Env == setting.Dev {
 		logger.Debug("OpenTsdb request", "params", tsdbQuery)
 	}
 
-	dsInfo, err := s.getDSInfo(ctx, req.PluginContext)
-	if err != nil {
-		return nil, err
-	}
-
 	request, err := s.createRequest(ctx, logger, dsInfo, tsdbQuery)
 	if err != nil {
 		return &backend.QueryDataResponse{}, err
@@ -107,7 +142,7 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 		}
 	}()
 
-	result, err := s.parseResponse(logger, res, myRefID)
+	result, err := s.parseResponse(logger, res, targets, tsdbQuery.MsResolution)
 	if err != nil {
 		return &backend.QueryDataResponse{}, err
 	}
@@ -115,6 +150,52 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 	return result, nil
 }
 
+// buildQuery converts the queries of a request to a single OpenTSDB query. The targets are the queries in the order of
+// the sub queries. Hidden queries and queries without a metric are skipped.
+func (s *Service) buildQuery(queries []backend.DataQuery) (OpenTsdbQuery, []queryTarget) {
+	var tsdbQuery OpenTsdbQuery
+	targets := make([]queryTarget, 0, len(queries))
+	if len(queries) == 0 {
+		return tsdbQuery, targets
+	}
+
+	// all queries share the same time range
+	tsdbQuery.Start = queries[0].TimeRange.From.UnixNano() / int64(time.Millisecond)
+	tsdbQuery.End = queries[0].TimeRange.To.UnixNano() / int64(time.Millisecond)
+
+	for _, query := range queries {
+		model, err := simplejson.NewJson(query.JSON)
+		if err != nil || model.Get("hide").MustBool() {
+			continue
+		}
+
+		if model.Get("fromAnnotations").MustBool() {
+			metric := model.Get("target").MustString()
+			if metric == "" {
+				continue
+			}
+			global := model.Get("isGlobal").MustBool()
+			tsdbQuery.GlobalAnnotations = tsdbQuery.GlobalAnnotations || global
+			tsdbQuery.Queries = append(tsdbQuery.Queries, map[string]any{"aggregator": "sum", "metric": metric})
+			targets = append(targets, queryTarget{refID: query.RefID, metric: metric, annotations: true, globalAnnotations: global})
+			continue
+		}
+
+		metric := s.buildMetric(query)
+		if metric == nil || metric["metric"] == "" {
+			continue
+		}
+		target := queryTarget{refID: query.RefID, metric: metric["metric"].(string)}
+		if tags, ok := metric["tags"].(map[string]any); ok {
+			target.tags = tags
+		}
+		_, target.hasFilters = metric["filters"]
+		tsdbQuery.Queries = append(tsdbQuery.Queries, metric)
+		targets = append(targets, target)
+	}
+	return tsdbQuery, targets
+}
+
 func (s *Service) createRequest(ctx context.Context, logger log.Logger, dsInfo *datasourceInfo, data OpenTsdbQuery) (*http.Request, error) {
 	u, err := url.Parse(dsInfo.URL)
 	if err != nil {
@@ -138,7 +219,7 @@ func (s *Service) createRequest(ctx context.Context, logger log.Logger, dsInfo *
 	return req, nil
 }
 
-func (s *Service) parseResponse(logger log.Logger, res *http.Response, myRefID string) (*backend.QueryDataResponse, error) {
+func (s *Service) parseResponse(logger log.Logger, res *http.Response, targets []queryTarget, msResolution bool) (*backend.QueryDataResponse, error) {
 	resp := backend.NewQueryDataResponse()
 
 	body, err := io.ReadAll(res.Body)
@@ -163,8 +244,29 @@ func (s *Service) parseResponse(logger log.Logger, res *http.Response, myRefID s
 		return nil, err
 	}
 
-	frames := data.Frames{}
+	annotated := make(map[int]bool, len(targets))
 	for _, val := range responseData {
+		index := matchTarget(val, targets)
+		if index < 0 {
+			continue
+		}
+		target := targets[index]
+		result := resp.Responses[target.refID]
+
+		if target.annotations {
+			// annotations are attached to every series of the metric, only the first one is used
+			if !annotated[index] {
+				annotated[index] = true
+				annotations := val.Annotations
+				if target.globalAnnotations {
+					annotations = val.GlobalAnnotations
+				}
+				result.Frames = append(result.Frames, annotationsToFrame(target.refID, annotations))
+				resp.Responses[target.refID] = result
+			}
+			continue
+		}
+
 		timeVector := make([]time.Time, 0, len(val.DataPoints))
 		values := make([]float64, 0, len(val.DataPoints))
 		name := val.Metric
@@ -176,19 +278,87 @@ func (s *Service) parseResponse(logger log.Logger, res *http.Response, myRefID s
 				logger.Info("Failed to unmarshal opentsdb timestamp", "timestamp", timeString)
 				return nil, err
 			}
-			timeVector = append(timeVector, time.Unix(timestamp, 0).UTC())
+			if msResolution {
+				timeVector = append(timeVector, time.UnixMilli(timestamp).UTC())
+			} else {
+				timeVector = append(timeVector, time.Unix(timestamp, 0).UTC())
+			}
 			values = append(values, value)
 		}
-		frames = append(frames, data.NewFrame(name,
+		result.Frames = append(result.Frames, data.NewFrame(name,
 			data.NewField("time", nil, timeVector),
 			data.NewField("value", tags, values)))
+		resp.Responses[target.refID] = result
+	}
+
+	// annotation queries without series have no annotations
+	for i, target := range targets {
+		if target.annotations && !annotated[i] {
+			result := resp.Responses[target.refID]
+			result.Frames = append(result.Frames, annotationsToFrame(target.refID, nil))
+			resp.Responses[target.refID] = result
+		}
 	}
-	result := resp.Responses[myRefID]
-	result.Frames = frames
-	resp.Responses[myRefID] = result
 	return resp, nil
 }
 
+// matchTarget returns the index of the target of a series. OpenTSDB >=2.3 returns the index of the sub query with the
+// series, older versions are matched by metric and tags like the frontend does. It returns -1 if no target matches.
+func matchTarget(series OpenTsdbResponse, targets []queryTarget) int {
+	if len(targets) == 0 {
+		return -1
+	}
+	if series.Query != nil {
+		if series.Query.Index >= 0 && series.Query.Index < len(targets) {
+			return series.Query.Index
+		}
+		return -1
+	}
+	for i, target := range targets {
+		if target.metric != series.Metric {
+			continue
+		}
+		if target.annotations || target.hasFilters {
+			return i
+		}
+		matches := true
+		for key, value := range target.tags {
+			v := fmt.Sprint(value)
+			if v == "*" {
+				continue
+			}
+			if !slices.Contains(strings.Split(v, "|"), series.Tags[key]) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return i
+		}
+	}
+	return -1
+}
+
+func annotationsToFrame(refID string, annotations []OpenTsdbAnnotation) *data.Frame {
+	times := make([]time.Time, 0, len(annotations))
+	timeEnds := make([]*time.Time, 0, len(annotations))
+	texts := make([]string, 0, len(annotations))
+	for _, a := range annotations {
+		times = append(times, time.Unix(a.StartTime, 0).UTC())
+		var end *time.Time
+		if a.EndTime > 0 {
+			t := time.Unix(a.EndTime, 0).UTC()
+			end = &t
+		}
+		timeEnds = append(timeEnds, end)
+		texts = append(texts, a.Description)
+	}
+	return data.NewFrame(refID,
+		data.NewField("time", nil, times),
+		data.NewField("timeEnd", nil, timeEnds),
+		data.NewField("text", nil, texts))
+}
+
 func (s *Service) buildMetric(query backend.DataQuery) map[string]any {
 	metric := make(map[string]any)
 
@@ -239,21 +409,44 @@ func (s *Service) buildMetric(query backend.DataQuery) map[string]any {
 		metric["rateOptions"] = rateOptions
 	}
 
+	if model.Get("explicitTags").MustBool() {
+		metric["explicitTags"] = true
+	}
+
+	// Setting filters, which replace tags since OpenTSDB 2.2
+	if filters := buildFilters(model); len(filters) > 0 {
+		metric["filters"] = filters
+		return metric
+	}
+
 	// Setting tags
 	tags, tagsCheck := model.CheckGet("tags")
 	if tagsCheck && len(tags.MustMap()) > 0 {
 		metric["tags"] = tags.MustMap()
 	}
 
-	// Setting filters
-	filters, filtersCheck := model.CheckGet("filters")
-	if filtersCheck && len(filters.MustArray()) > 0 {
-		metric["filters"] = filters.MustArray()
-	}
-
 	return metric
 }
 
+// buildFilters returns the valid filters of a query model. Filters without a type or a tag key are ignored.
+func buildFilters(model *simplejson.Json) []OpenTsdbFilter {
+	filters := make([]OpenTsdbFilter, 0)
+	for i := range model.Get("filters").MustArray() {
+		f := model.Get("filters").GetIndex(i)
+		filter := OpenTsdbFilter{
+			Type:    f.Get("type").MustString(),
+			Tagk:    f.Get("tagk").MustString(),
+			Filter:  f.Get("filter").MustString(),
+			GroupBy: f.Get("groupBy").MustBool(),
+		}
+		if filter.Type == "" || filter.Tagk == "" {
+			continue
+		}
+		filters = append(filters, filter)
+	}
+	return filters
+}
+
 func (s *Service) getDSInfo(ctx context.Context, pluginCtx backend.PluginContext) (*datasourceInfo, error) {
 	i, err := s.im.Get(ctx, pluginCtx)
 	if err != nil {
@@ -33,7 +33,7 @@ func TestOpenTsdbExecutor(t *testing.T) {
 	t.Run("Parse response should handle invalid JSON", func(t *testing.T) {
 		response := `{ invalid }`
 
-		result, err := service.parseResponse(logger, &http.Response{Body: io.NopCloser(strings.NewReader(response))}, "A")
+		result, err := service.parseResponse(logger, &http.Response{Body: io.NopCloser(strings.NewReader(response))}, []queryTarget{{refID: "A"}}, false)
 		require.Nil(t, result)
 		require.Error(t, err)
 	})
@@ -63,7 +63,7 @@ func TestOpenTsdbExecutor(t *testing.T) {
 
 		resp := http.Response{Body: io.NopCloser(strings.NewReader(response))}
 		resp.StatusCode = 200
-		result, err := service.parseResponse(logger, &resp, "A")
+		result, err := service.parseResponse(logger, &resp, []queryTarget{{refID: "A", metric: "test"}}, false)
 		require.NoError(t, err)
 
 		frame := result.Responses["A"]
@@ -100,7 +100,7 @@ func TestOpenTsdbExecutor(t *testing.T) {
 
 		resp := http.Response{Body: io.NopCloser(strings.NewReader(response))}
 		resp.StatusCode = 200
-		result, err := service.parseResponse(logger, &resp, myRefid)
+		result, err := service.parseResponse(logger, &resp, []queryTarget{{refID: myRefid, metric: "test"}}, false)
 		require.NoError(t, err)
 
 		if diff := cmp.Diff(testFrame, result.Responses[myRefid].Frames[0], data.FrameTestCompareOptions()...); diff != "" {
@@ -276,4 +276,106 @@ func TestOpenTsdbExecutor(t *testing.T) {
 		require.Equal(t, float64(45), metricRateOptions["counterMax"])
 		require.Equal(t, float64(60), metricRateOptions["resetValue"])
 	})
+
+	t.Run("Build metric with filters ignores tags", func(t *testing.T) {
+		query := backend.DataQuery{
+			JSON: []byte(`
+					{
+						"metric": "cpu.average.percent",
+						"aggregator": "avg",
+						"disableDownsampling": true,
+						"explicitTags": true,
+						"tags": {
+							"env": "prod"
+						},
+						"filters": [
+							{"type": "wildcard", "tagk": "host", "filter": "web-*", "groupBy": true},
+							{"type": "literal_or", "filter": "missing tag key"}
+						]
+					}`,
+			),
+		}
+
+		metric := service.buildMetric(query)
+
+		require.Len(t, metric, 4)
+		require.True(t, metric["explicitTags"].(bool))
+		require.Nil(t, metric["tags"])
+		require.Equal(t, []OpenTsdbFilter{{Type: "wildcard", Tagk: "host", Filter: "web-*", GroupBy: true}}, metric["filters"])
+	})
+
+	t.Run("Build query batches queries and annotations", func(t *testing.T) {
+		queries := []backend.DataQuery{
+			{RefID: "A", JSON: []byte(`{"metric": "cpu", "aggregator": "sum", "disableDownsampling": true}`)},
+			{RefID: "B", JSON: []byte(`{"metric": "cpu", "aggregator": "sum", "hide": true}`)},
+			{RefID: "C", JSON: []byte(`{"aggregator": "sum"}`)},
+			{RefID: "D", JSON: []byte(`{"fromAnnotations": true, "target": "deploys", "isGlobal": true}`)},
+		}
+
+		query, targets := service.buildQuery(queries)
+
+		require.Len(t, query.Queries, 2)
+		require.Equal(t, "cpu", query.Queries[0]["metric"])
+		require.Equal(t, map[string]any{"aggregator": "sum", "metric": "deploys"}, query.Queries[1])
+		require.True(t, query.GlobalAnnotations)
+		require.Equal(t, []queryTarget{
+			{refID: "A", metric: "cpu"},
+			{refID: "D", metric: "deploys", annotations: true, globalAnnotations: true},
+		}, targets)
+	})
+
+	t.Run("Parse response maps series to their queries", func(t *testing.T) {
+		response := `
+		[
+			{"metric": "cpu", "tags": {"host": "a"}, "dps": {"1405544146000": 1}, "query": {"index": 1}},
+			{"metric": "cpu", "tags": {"host": "b"}, "dps": {"1405544146000": 2}, "query": {"index": 0}},
+			{"metric": "deploys", "tags": {}, "dps": {}, "query": {"index": 2},
+				"annotations": [{"description": "local", "startTime": 1405544146}],
+				"globalAnnotations": [{"description": "release", "startTime": 1405544146, "endTime": 1405544206}]}
+		]`
+		targets := []queryTarget{
+			{refID: "A", metric: "cpu"},
+			{refID: "B", metric: "cpu"},
+			{refID: "C", metric: "deploys", annotations: true, globalAnnotations: true},
+		}
+
+		resp := http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(response))}
+		result, err := service.parseResponse(logger, &resp, targets, true)
+		require.NoError(t, err)
+
+		require.Len(t, result.Responses["A"].Frames, 1)
+		require.Equal(t, map[string]string{"host": "b"}, map[string]string(result.Responses["A"].Frames[0].Fields[1].Labels))
+		require.Equal(t, time.Date(2014, 7, 16, 20, 55, 46, 0, time.UTC), result.Responses["A"].Frames[0].Fields[0].At(0))
+		require.Len(t, result.Responses["B"].Frames, 1)
+		require.Equal(t, map[string]string{"host": "a"}, map[string]string(result.Responses["B"].Frames[0].Fields[1].Labels))
+
+		annotations := result.Responses["C"].Frames[0]
+		require.Equal(t, 1, annotations.Rows())
+		require.Equal(t, "release", annotations.Fields[2].At(0))
+		end := time.Date(2014, 7, 16, 20, 56, 46, 0, time.UTC)
+		require.Equal(t, &end, annotations.Fields[1].At(0))
+	})
+
+	t.Run("Parse response matches series by metric and tags without query index", func(t *testing.T) {
+		response := `
+		[
+			{"metric": "cpu", "tags": {"host": "b"}, "dps": {"1405544146": 2}},
+			{"metric": "mem", "tags": {"host": "a"}, "dps": {"1405544146": 3}},
+			{"metric": "disk", "tags": {"host": "a"}, "dps": {"1405544146": 4}}
+		]`
+		targets := []queryTarget{
+			{refID: "A", metric: "cpu", tags: map[string]any{"host": "a"}},
+			{refID: "B", metric: "cpu", tags: map[string]any{"host": "b|c"}},
+			{refID: "C", metric: "mem", tags: map[string]any{"host": "*"}},
+		}
+
+		resp := http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(response))}
+		result, err := service.parseResponse(logger, &resp, targets, false)
+		require.NoError(t, err)
+
+		// series that match no target are dropped
+		require.Empty(t, result.Responses["A"].Frames)
+		require.Len(t, result.Responses["B"].Frames, 1)
+		require.Len(t, result.Responses["C"].Frames, 1)
+	})
 }
@@ -0,0 +1,178 @@
+package opentsdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+// suggestTypes are the types of the suggest API of OpenTSDB.
+var suggestTypes = map[string]struct{}{
+	"metrics": {},
+	"tagk":    {},
+	"tagv":    {},
+}
+
+func (s *Service) registerRoutes() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/suggest", handleResourceReq(s, s.suggest))
+	mux.HandleFunc("/aggregators", handleResourceReq(s, s.aggregators))
+	mux.HandleFunc("/filters", handleResourceReq(s, s.filters))
+	return mux
+}
+
+// CallResource serves the suggest, aggregators and filters resources, which proxy the OpenTSDB HTTP API.
+func (s *Service) CallResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	return s.resourceHandler.CallResource(ctx, req, sender)
+}
+
+// handleResourceReq calls the handler with the data source and the query parameters of a GET resource request.
+// The response is the JSON encoded result, or a JSON object with the message of the error.
+func handleResourceReq(s *Service, handler func(context.Context, *datasourceInfo, url.Values) ([]string, int, error)) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		logger := logger.FromContext(ctx)
+		if req.Method != http.MethodGet {
+			writeError(rw, logger, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", req.Method))
+			return
+		}
+
+		dsInfo, err := s.getDSInfo(ctx, backend.PluginConfigFromContext(ctx))
+		if err != nil {
+			writeError(rw, logger, http.StatusInternalServerError, fmt.Errorf("failed to get data source info: %w", err))
+			return
+		}
+
+		result, status, err := handler(ctx, dsInfo, req.URL.Query())
+		if err != nil {
+			logger.Info("Resource request failed", "path", req.URL.Path, "status", status, "error", err)
+			writeError(rw, logger, status, err)
+			return
+		}
+		writeJSON(rw, logger, http.StatusOK, result)
+	}
+}
+
+// suggest returns the metrics, tag keys or tag values that start with a prefix.
+// The query parameters are "type" (metrics, tagk or tagv), "q" for the prefix and "max" for the number of suggestions,
+// which defaults to the lookup limit of the data source.
+func (s *Service) suggest(ctx context.Context, dsInfo *datasourceInfo, query url.Values) ([]string, int, error) {
+	suggestType := query.Get("type")
+	if _, ok := suggestTypes[suggestType]; !ok {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid suggest type %q, must be one of metrics, tagk or tagv", suggestType)
+	}
+	limit := dsInfo.LookupLimit
+	if maxParam := query.Get("max"); maxParam != "" {
+		m, err := strconv.Atoi(maxParam)
+		if err != nil || m <= 0 {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid max %q", maxParam)
+		}
+		limit = m
+	}
+
+	params := url.Values{
+		"type": []string{suggestType},
+		"q":    []string{query.Get("q")},
+		"max":  []string{strconv.Itoa(limit)},
+	}
+	suggestions := make([]string, 0)
+	if status, err := s.getJSON(ctx, dsInfo, "api/suggest", params, &suggestions); err != nil {
+		return nil, status, err
+	}
+	return suggestions, http.StatusOK, nil
+}
+
+// aggregators returns the sorted names of the aggregators of OpenTSDB.
+func (s *Service) aggregators(ctx context.Context, dsInfo *datasourceInfo, _ url.Values) ([]string, int, error) {
+	aggregators := make([]string, 0)
+	if status, err := s.getJSON(ctx, dsInfo, "api/aggregators", nil, &aggregators); err != nil {
+		return nil, status, err
+	}
+	sort.Strings(aggregators)
+	return aggregators, http.StatusOK, nil
+}
+
+// filters returns the sorted types of the filters of OpenTSDB. Requires OpenTSDB 2.2.
+func (s *Service) filters(ctx context.Context, dsInfo *datasourceInfo, _ url.Values) ([]string, int, error) {
+	var config map[string]json.RawMessage
+	if status, err := s.getJSON(ctx, dsInfo, "api/config/filters", nil, &config); err != nil {
+		return nil, status, err
+	}
+	filters := make([]string, 0, len(config))
+	for name := range config {
+		filters = append(filters, name)
+	}
+	sort.Strings(filters)
+	return filters, http.StatusOK, nil
+}
+
+// getJSON sends a GET request to an endpoint of the OpenTSDB HTTP API and decodes the response into v.
+// It returns the status of the resource response if it fails.
+func (s *Service) getJSON(ctx context.Context, dsInfo *datasourceInfo, endpoint string, params url.Values, v any) (int, error) {
+	logger := logger.FromContext(ctx)
+	u, err := url.Parse(dsInfo.URL)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to parse data source URL: %w", err)
+	}
+	u.Path = path.Join(u.Path, endpoint)
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to create request: %w", err)
+	}
+	res, err := dsInfo.HTTPClient.Do(req)
+	if err != nil {
+		return http.StatusBadGateway, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			logger.Warn("Failed to close response body", "error", err)
+		}
+	}()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return http.StatusBadGateway, err
+	}
+	if res.StatusCode/100 != 2 {
+		logger.Info("Request failed", "endpoint", endpoint, "status", res.Status, "body", string(body))
+		status := http.StatusBadGateway
+		if res.StatusCode/100 == 4 {
+			status = res.StatusCode
+		}
+		return status, fmt.Errorf("request failed, status: %s", res.Status)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return http.StatusBadGateway, fmt.Errorf("failed to parse response of %s: %w", endpoint, err)
+	}
+	return http.StatusOK, nil
+}
+
+func writeJSON(rw http.ResponseWriter, logger log.Logger, status int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		logger.Error("Failed to marshal resource response", "error", err)
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	if _, err := rw.Write(body); err != nil {
+		logger.Error("Failed to write resource response", "error", err)
+	}
+}
+
+func writeError(rw http.ResponseWriter, logger log.Logger, status int, err error) {
+	writeJSON(rw, logger, status, map[string]string{"message": err.Error()})
+}
@@ -0,0 +1,184 @@
+package opentsdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/resource/httpadapter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallResource(t *testing.T) {
+	var lastRequest *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		lastRequest = req
+		switch req.URL.Path {
+		case "/api/suggest":
+			_, _ = rw.Write([]byte(`["cpu.idle","cpu.user"]`))
+		case "/api/aggregators":
+			_, _ = rw.Write([]byte(`["sum","avg","count"]`))
+		case "/api/config/filters":
+			_, _ = rw.Write([]byte(`{"wildcard":{"description":"..."},"literal_or":{"description":"..."}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	service := newTestService(srv)
+	call := func(t *testing.T, method, url string) (int, []byte) {
+		t.Helper()
+		var res *backend.CallResourceResponse
+		err := service.CallResource(context.Background(), &backend.CallResourceRequest{
+			Method: method,
+			Path:   strings.Split(url, "?")[0],
+			URL:    url,
+		}, backend.CallResourceResponseSenderFunc(func(r *backend.CallResourceResponse) error {
+			res = r
+			return nil
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		return res.Status, res.Body
+	}
+
+	t.Run("suggest returns the suggestions", func(t *testing.T) {
+		status, body := call(t, http.MethodGet, "suggest?type=metrics&q=cpu")
+		require.Equal(t, http.StatusOK, status)
+		require.JSONEq(t, `["cpu.idle","cpu.user"]`, string(body))
+		require.Equal(t, "metrics", lastRequest.URL.Query().Get("type"))
+		require.Equal(t, "cpu", lastRequest.URL.Query().Get("q"))
+		require.Equal(t, "1000", lastRequest.URL.Query().Get("max"))
+
+		status, _ = call(t, http.MethodGet, "suggest?type=tagv&q=web&max=5")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "tagv", lastRequest.URL.Query().Get("type"))
+		require.Equal(t, "5", lastRequest.URL.Query().Get("max"))
+	})
+
+	t.Run("suggest validates the parameters", func(t *testing.T) {
+		status, _ := call(t, http.MethodGet, "suggest?type=unknown")
+		require.Equal(t, http.StatusBadRequest, status)
+		status, _ = call(t, http.MethodGet, "suggest?type=tagk&max=none")
+		require.Equal(t, http.StatusBadRequest, status)
+		status, body := call(t, http.MethodPost, "suggest?type=tagk")
+		require.Equal(t, http.StatusMethodNotAllowed, status)
+		require.JSONEq(t, `{"message":"method POST is not allowed"}`, string(body))
+	})
+
+	t.Run("unknown resources are not found", func(t *testing.T) {
+		status, _ := call(t, http.MethodGet, "unknown")
+		require.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("aggregators returns the sorted aggregators", func(t *testing.T) {
+		status, body := call(t, http.MethodGet, "aggregators")
+		require.Equal(t, http.StatusOK, status)
+		require.JSONEq(t, `["avg","count","sum"]`, string(body))
+	})
+
+	t.Run("filters returns the sorted filter types", func(t *testing.T) {
+		status, body := call(t, http.MethodGet, "filters")
+		require.Equal(t, http.StatusOK, status)
+		require.JSONEq(t, `["literal_or","wildcard"]`, string(body))
+	})
+}
+
+func TestQueryData(t *testing.T) {
+	var lastQuery OpenTsdbQuery
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/api/query", req.URL.Path)
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &lastQuery))
+		_, _ = rw.Write([]byte(`[
+			{"metric": "mem", "tags": {}, "dps": {"1405544146": 2}, "query": {"index": 1}},
+			{"metric": "cpu", "tags": {}, "dps": {"1405544146": 1}, "query": {"index": 0}}
+		]`))
+	}))
+	t.Cleanup(srv.Close)
+
+	service := newTestService(srv)
+	service.im = testInstanceManager{info: &datasourceInfo{HTTPClient: srv.Client(), URL: srv.URL, TSDBVersion: 3, TSDBResolution: 1, LookupLimit: 1000}}
+	timeRange := backend.TimeRange{From: time.Unix(1405544000, 0), To: time.Unix(1405545000, 0)}
+	res, err := service.QueryData(context.Background(), &backend.QueryDataRequest{
+		Queries: []backend.DataQuery{
+			{RefID: "A", TimeRange: timeRange, JSON: []byte(`{"metric": "cpu", "aggregator": "sum", "disableDownsampling": true}`)},
+			{RefID: "B", TimeRange: timeRange, JSON: []byte(`{"metric": "mem", "aggregator": "sum", "disableDownsampling": true}`)},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, lastQuery.Queries, 2)
+	require.True(t, lastQuery.ShowQuery)
+	require.False(t, lastQuery.MsResolution)
+	require.Equal(t, int64(1405544000000), lastQuery.Start)
+	require.Equal(t, "cpu", res.Responses["A"].Frames[0].Name)
+	require.Equal(t, "mem", res.Responses["B"].Frames[0].Name)
+}
+
+func TestCheckHealth(t *testing.T) {
+	t.Run("healthy if OpenTSDB suggests metrics", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			require.Equal(t, "/api/suggest", req.URL.Path)
+			_, _ = rw.Write([]byte(`[]`))
+		}))
+		t.Cleanup(srv.Close)
+
+		res, err := newTestService(srv).CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		require.Equal(t, backend.HealthStatusOk, res.Status)
+	})
+
+	t.Run("unhealthy if OpenTSDB fails", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			rw.WriteHeader(http.StatusInternalServerError)
+		}))
+		t.Cleanup(srv.Close)
+
+		res, err := newTestService(srv).CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		require.Equal(t, backend.HealthStatusError, res.Status)
+	})
+
+	t.Run("unhealthy if the data source settings are invalid", func(t *testing.T) {
+		service := &Service{im: testInstanceManager{err: errors.New("invalid settings")}}
+		res, err := service.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		require.Equal(t, backend.HealthStatusError, res.Status)
+		require.Contains(t, res.Message, "invalid settings")
+	})
+}
+
+func newTestService(srv *httptest.Server) *Service {
+	s := &Service{
+		im: testInstanceManager{info: &datasourceInfo{HTTPClient: srv.Client(), URL: srv.URL, TSDBVersion: 1, TSDBResolution: 1, LookupLimit: defaultLookupLimit}},
+	}
+	s.resourceHandler = httpadapter.New(s.registerRoutes())
+	return s
+}
+
+type testInstanceManager struct {
+	info *datasourceInfo
+	err  error
+}
+
+func (m testInstanceManager) Get(_ context.Context, _ backend.PluginContext) (instancemgmt.Instance, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.info, nil
+}
+
+func (m testInstanceManager) Do(_ context.Context, _ backend.PluginContext, _ instancemgmt.InstanceCallbackFunc) error {
+	return nil
+}
@@ -4,10 +4,53 @@ type OpenTsdbQuery struct {
 	Start   int64            `json:"start"`
 	End     int64            `json:"end"`
 	Queries []map[string]any `json:"queries"`
+	// MsResolution requests timestamps in milliseconds.
+	MsResolution bool `json:"msResolution,omitempty"`
+	// GlobalAnnotations requests the annotations that are not attached to a time series.
+	GlobalAnnotations bool `json:"globalAnnotations,omitempty"`
+	// ShowQuery adds the sub query, with its index, to each series of the response. Requires OpenTSDB 2.3.
+	ShowQuery bool `json:"showQuery,omitempty"`
 }
 
 type OpenTsdbResponse struct {
-	Metric     string             `json:"metric"`
-	Tags       map[string]string  `json:"tags"`
-	DataPoints map[string]float64 `json:"dps"`
+	Metric            string               `json:"metric"`
+	Tags              map[string]string    `json:"tags"`
+	AggregateTags     []string             `json:"aggregateTags"`
+	DataPoints        map[string]float64   `json:"dps"`
+	Annotations       []OpenTsdbAnnotation `json:"annotations"`
+	GlobalAnnotations []OpenTsdbAnnotation `json:"globalAnnotations"`
+	Query             *OpenTsdbSubQuery    `json:"query"`
+}
+
+// OpenTsdbSubQuery is the sub query of a series, returned if OpenTsdbQuery.ShowQuery is set.
+type OpenTsdbSubQuery struct {
+	Index int `json:"index"`
+}
+
+type OpenTsdbAnnotation struct {
+	TSUID       string         `json:"tsuid"`
+	Description string         `json:"description"`
+	Notes       string         `json:"notes"`
+	Custom      map[string]any `json:"custom"`
+	StartTime   int64          `json:"startTime"`
+	EndTime     int64          `json:"endTime"`
+}
+
+// OpenTsdbFilter is a filter of the filters API of OpenTSDB 2.2 and later.
+type OpenTsdbFilter struct {
+	Type    string `json:"type"`
+	Tagk    string `json:"tagk"`
+	Filter  string `json:"filter"`
+	GroupBy bool   `json:"groupBy"`
+}
+
+// queryTarget is a query of a request sent as a sub query of the OpenTSDB query.
+type queryTarget struct {
+	refID      string
+	metric     string
+	tags       map[string]any
+	hasFilters bool
+	// annotations is set for annotation queries, which return the annotations of the metric instead of its series.
+	annotations       bool
+	globalAnnotations bool
 }
@@ -66,7 +66,7 @@ export const expressionTypes: Array<SelectableValue<ExpressionQueryType>> = [
   {
     value: ExpressionQueryType.sql,
     label: 'SQL',
-    description: 'Transform data using SQL. Supports Aggregate/Analytics functions from DuckDB',
+    description: 'Transform data using SQL. Supports aggregate and window functions',
   },
 ].filter((expr) => {
   if (expr.value === ExpressionQueryType.sql) {
@@ -82,6 +82,17 @@ export const reducerTypes: Array<SelectableValue<string>> = [
   { value: ReducerID.sum, label: 'Sum', description: 'Get the sum of all values' },
   { value: ReducerID.count, label: 'Count', description: 'Get the number of values' },
   { value: ReducerID.last, label: 'Last', description: 'Get the last value' },
+  { value: 'first', label: 'First', description: 'Get the first value' },
+  { value: 'median', label: 'Median', description: 'Get the median value' },
+  { value: 'p90', label: '90th percentile', description: 'Get the 90th percentile value' },
+  { value: 'p95', label: '95th percentile', description: 'Get the 95th percentile value' },
+  { value: 'p99', label: '99th percentile', description: 'Get the 99th percentile value' },
+  { value: 'stddev', label: 'Standard deviation', description: 'Get the standard deviation of all values' },
+  { value: 'variance', label: 'Variance', description: 'Get the variance of all values' },
+  { value: 'range', label: 'Range', description: 'Get the difference between the maximum and minimum values' },
+  { value: 'diff', label: 'Difference', description: 'Get the difference between the last and first values' },
+  { value: 'percent_diff', label: 'Percent difference', description: 'Get the difference between the last and first values in percent' },
+  { value: 'count_non_null', label: 'Count non-null', description: 'Get the number of values that are not null or NaN' },
 ];
 
 export enum ReducerMode {
@@ -114,12 +125,17 @@ export const downsamplingTypes: Array<SelectableValue<string>> = [
   { value: ReducerID.max, label: 'Max', description: 'Fill with the maximum value' },
   { value: ReducerID.mean, label: 'Mean', description: 'Fill with the average value' },
   { value: ReducerID.sum, label: 'Sum', description: 'Fill with the sum of all values' },
+  { value: 'median', label: 'Median', description: 'Fill with the median value' },
+  { value: 'p95', label: '95th percentile', description: 'Fill with the 95th percentile value' },
+  { value: 'stddev', label: 'Standard deviation', description: 'Fill with the standard deviation of all values' },
 ];
 
 export const upsamplingTypes: Array<SelectableValue<string>> = [
   { value: 'pad', label: 'pad', description: 'fill with the last known value' },
   { value: 'backfilling', label: 'backfilling', description: 'fill with the next known value' },
   { value: 'fillna', label: 'fillna', description: 'Fill with NaNs' },
+  { value: 'linear', label: 'linear', description: 'interpolate between the last and the next known values' },
+  { value: 'nearest', label: 'nearest', description: 'fill with the known value closest in time' },
 ];
 
 export const thresholdFunctions: Array<SelectableValue<EvalFunction>> = [
@@ -140,6 +156,7 @@ export interface ExpressionQuery extends DataQuery {
   window?: string;
   downsampler?: string;
   upsampler?: string;
+  maxGap?: number;
   conditions?: ClassicCondition[];
   settings?: ExpressionQuerySettings;
 }
@@ -218,6 +218,10 @@ export interface GrafanaNotificationSettings {
   repeat_interval?: string;
   mute_time_intervals?: string[];
 }
+export interface GrafanaRuleDependency {
+  rule_uid: string;
+  condition?: 'firing' | 'not_firing';
+}
 export interface PostableGrafanaRuleDefinition {
   uid?: string;
   title: string;
@@ -230,7 +234,9 @@ export interface PostableGrafanaRuleDefinition {
   record?: {
     metric: string;
     from: string;
+    target?: 'prometheus' | 'influxdb' | 'sql' | 'live';
   };
+  dependencies?: GrafanaRuleDependency[];
 }
 export interface GrafanaRuleDefinition extends PostableGrafanaRuleDefinition {
   id?: string;